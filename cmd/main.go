@@ -2,18 +2,33 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"log/slog"
+	"net"
+	"net/http"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
 	"time"
 
 	_ "post-tech-challenge-10soat/docs"
 	router "post-tech-challenge-10soat/internal/delivery/http"
+	"post-tech-challenge-10soat/internal/delivery/menufile"
+	entity "post-tech-challenge-10soat/internal/entities"
 	"post-tech-challenge-10soat/internal/external/mongo"
 	"post-tech-challenge-10soat/internal/external/postgres"
 	"post-tech-challenge-10soat/internal/infrastructure/config"
 	dependency "post-tech-challenge-10soat/internal/infrastructure/di"
 	"post-tech-challenge-10soat/internal/infrastructure/logger"
+	"post-tech-challenge-10soat/internal/infrastructure/tracing"
+	"post-tech-challenge-10soat/internal/utils"
+
+	"google.golang.org/grpc"
 )
 
 //	@title			POS-Tech API
@@ -33,18 +48,276 @@ import (
 
 //	@securityDefinitions.basic	BasicAuth
 
+//	@securityDefinitions.apikey	BearerAuth
+//	@in							header
+//	@name						Authorization
+//	@description				Token de sessão no formato "Bearer <token>"
+
 // @externalDocs.description	OpenAPI
 // @externalDocs.url			https://swagger.io/resources/open-api/
 func main() {
-	conf, err := config.New()
+	configPath := flag.String("config", os.Getenv("CONFIG_FILE"), "YAML file with the settings, overridden by the environment")
+	flag.Usage = usage
+	flag.Parse()
+
+	conf, err := config.New(*configPath)
 	if err != nil {
-		slog.Error("Error loading environment variables", "error", err)
+		slog.Error("Error loading the configuration", "error", err)
+		os.Exit(1)
+	}
+	args := flag.Args()
+	command := "serve"
+	if len(args) > 0 {
+		command = args[0]
+	}
+	switch command {
+	case "serve":
+		serveFlags := flag.NewFlagSet("serve", flag.ExitOnError)
+		serveFlags.Usage = usage
+		autoMigrate := serveFlags.Bool("auto-migrate", conf.DB.AutoMigrate, "apply pending Postgres and Mongo migrations before serving")
+		if len(args) > 0 {
+			serveFlags.Parse(args[1:])
+		}
+		if serveFlags.NArg() > 0 {
+			usage()
+			os.Exit(2)
+		}
+		conf.DB.AutoMigrate = *autoMigrate
+		serve(conf)
+	case "migrate":
+		os.Exit(migrateCommand(conf, args[1:]))
+	case "menu":
+		os.Exit(menuCommand(conf, args[1:]))
+	case "config":
+		if strings.Join(args[1:], " ") != "print" {
+			usage()
+			os.Exit(2)
+		}
+		if err := config.Print(os.Stdout, conf); err != nil {
+			slog.Error("Error printing the configuration", "error", err)
+			os.Exit(1)
+		}
+	default:
+		usage()
+		os.Exit(2)
+	}
+}
+
+func usage() {
+	fmt.Fprintf(flag.CommandLine.Output(), `Usage: %s [-config file] [command]
+
+Commands:
+  serve [-auto-migrate=false]  start the HTTP and gRPC servers (default),
+                               applying pending migrations unless disabled
+  migrate up                   apply every pending Postgres and Mongo migration
+  migrate down N               revert the last N Postgres migrations
+  migrate status               print the current and the latest versions
+  migrate force V              record Postgres version V and clear the dirty
+                               flag, after fixing a failed migration by hand
+  menu import [-dry-run] [-format json|csv] FILE
+                               create or update the categories and products
+                               in FILE by SKU, reporting every invalid row
+  menu export [-format json|csv] [FILE]
+                               write the menu to FILE, or to the output
+  config print                 print the configuration with the secrets redacted
+
+Flags:
+`, os.Args[0])
+	flag.PrintDefaults()
+}
+
+// migrateCommand runs a migrate subcommand and returns the exit code.
+// Migrations wait for any other process migrating the same database, which
+// an interrupt gives up on. Only up and status touch Mongo: its migrations
+// cannot be reverted and a failed one is simply not recorded, so there is
+// nothing to revert or force.
+func migrateCommand(conf *config.Container, args []string) int {
+	var run func(ctx context.Context, db *postgres.DB, mongoDb *mongo.MONGO) error
+	withMongo := false
+	switch {
+	case len(args) == 1 && args[0] == "up":
+		withMongo = true
+		run = func(ctx context.Context, db *postgres.DB, mongoDb *mongo.MONGO) error {
+			if err := db.Migrate(ctx); err != nil {
+				return err
+			}
+			return mongoDb.Migrate(ctx)
+		}
+	case len(args) == 2 && args[0] == "down":
+		steps, err := strconv.Atoi(args[1])
+		if err != nil || steps < 1 {
+			usage()
+			return 2
+		}
+		run = func(ctx context.Context, db *postgres.DB, mongoDb *mongo.MONGO) error {
+			return db.MigrateDown(ctx, steps)
+		}
+	case len(args) == 1 && args[0] == "status":
+		withMongo = true
+		run = func(ctx context.Context, db *postgres.DB, mongoDb *mongo.MONGO) error {
+			status, err := db.MigrationStatus()
+			if err != nil {
+				return err
+			}
+			mongoVersion, err := mongoDb.Version(ctx)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("version: %d\ndirty: %t\nlatest: %d\nmongo version: %d\nmongo latest: %d\n",
+				status.Version, status.Dirty, status.Latest, mongoVersion, mongo.LatestMigrationVersion())
+			return nil
+		}
+	case len(args) == 2 && args[0] == "force":
+		version, err := strconv.Atoi(args[1])
+		if err != nil || version < -1 {
+			usage()
+			return 2
+		}
+		run = func(ctx context.Context, db *postgres.DB, mongoDb *mongo.MONGO) error {
+			return db.ForceMigrationVersion(ctx, version)
+		}
+	default:
+		usage()
+		return 2
+	}
+
+	if err := logger.Set(conf.LOG); err != nil {
+		slog.Error("Error initializing the logger", "error", err)
+		return 1
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	db, err := postgres.New(ctx, conf.DB)
+	if err != nil {
+		slog.Error("Error initializing database connection", "error", err)
+		return 1
+	}
+	defer db.Close()
+	var mongoDb *mongo.MONGO
+	if withMongo {
+		if mongoDb, err = mongo.New(ctx, conf.MONGO); err != nil {
+			slog.Error("Error initializing database connection", "error", err)
+			return 1
+		}
+		defer func() {
+			disconnectCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			_ = mongoDb.Close(disconnectCtx)
+		}()
+	}
+	if err := run(ctx, db, mongoDb); err != nil {
+		slog.Error("Error running migrate "+args[0], "error", err)
+		return 1
+	}
+	return 0
+}
+
+// menuCommand imports or exports the menu as the /v1/menu routes do and
+// returns the exit code, 1 when the file has invalid rows. The format
+// defaults to the extension of the file.
+func menuCommand(conf *config.Container, args []string) int {
+	if len(args) == 0 || (args[0] != "import" && args[0] != "export") {
+		usage()
+		return 2
+	}
+	menuFlags := flag.NewFlagSet("menu "+args[0], flag.ExitOnError)
+	menuFlags.Usage = usage
+	format := menuFlags.String("format", "", "json or csv")
+	dryRun := menuFlags.Bool("dry-run", false, "validate the file without applying it")
+	menuFlags.Parse(args[1:])
+	if args[0] == "import" && menuFlags.NArg() != 1 || args[0] == "export" && menuFlags.NArg() > 1 {
+		usage()
+		return 2
+	}
+	name := menuFlags.Arg(0)
+	if *format == "" {
+		*format = menufile.FormatOf(filepath.Ext(name))
+	}
+	if *format != menufile.FormatJSON && *format != menufile.FormatCSV {
+		usage()
+		return 2
+	}
+
+	if err := logger.Set(conf.LOG); err != nil {
+		slog.Error("Error initializing the logger", "error", err)
+		return 1
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	db, err := postgres.New(ctx, conf.DB)
+	if err != nil {
+		slog.Error("Error initializing database connection", "error", err)
+		return 1
+	}
+	defer db.Close()
+	menuController := dependency.NewMenuController(db)
+
+	if args[0] == "export" {
+		menu, err := menuController.ExportMenu(ctx)
+		if err != nil {
+			slog.Error("Error exporting the menu", "error", err)
+			return 1
+		}
+		output := os.Stdout
+		if name != "" {
+			if output, err = os.Create(name); err != nil {
+				slog.Error("Error creating the menu file", "error", err)
+				return 1
+			}
+			defer output.Close()
+		}
+		if err := menufile.Encode(output, *format, menu); err != nil {
+			slog.Error("Error writing the menu file", "error", err)
+			return 1
+		}
+		return 0
+	}
+
+	input, err := os.Open(name)
+	if err != nil {
+		slog.Error("Error opening the menu file", "error", err)
+		return 1
+	}
+	defer input.Close()
+	menu, err := menufile.Decode(input, *format)
+	if err != nil {
+		slog.Error("Error reading the menu file", "error", err)
+		return 1
+	}
+	report, err := menuController.ImportMenu(ctx, menu, *dryRun)
+	if err != nil {
+		slog.Error("Error importing the menu", "error", err)
+		return 1
+	}
+	for _, row := range report.Rows {
+		if row.Action == entity.MenuImportActionInvalid {
+			fmt.Printf("row %d (%s): %s\n", row.Row, row.Sku, strings.Join(row.Errors, "; "))
+		}
+	}
+	fmt.Printf("created: %d\nupdated: %d\nunchanged: %d\ninvalid: %d\ncreated categories: %d\napplied: %t\n",
+		report.Created, report.Updated, report.Unchanged, report.Invalid, len(report.CreatedCategories), report.Applied)
+	if report.Invalid > 0 {
+		return 1
+	}
+	return 0
+}
+
+func serve(conf *config.Container) {
+	if err := logger.Set(conf.LOG); err != nil {
+		slog.Error("Error initializing the logger", "error", err)
 		os.Exit(1)
 	}
-	logger.Set(conf.App)
 	slog.Info("Starting the application", "app", conf.App.Name, "env", conf.App.Env)
 
-	ctx := context.Background()
+	// Both servers stop on SIGINT or SIGTERM, or when one of them fails. A
+	// signal while waiting for the databases gives up on them.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	shutdownTracing, err := tracing.Set(ctx, conf.App, conf.TRACING)
+	if err != nil {
+		slog.Error("Error initializing tracing", "error", err)
+		os.Exit(1)
+	}
 	db, errPostgres := postgres.New(ctx, conf.DB)
 
 	if errPostgres != nil {
@@ -52,10 +325,7 @@ func main() {
 		os.Exit(1)
 	}
 
-	ctxMongo, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
-	mongo, errMongo := mongo.New(ctxMongo, conf.MONGO)
+	mongo, errMongo := mongo.New(ctx, conf.MONGO)
 
 	if errMongo != nil {
 		slog.Error("Error initializing database connection", "error", errMongo)
@@ -63,42 +333,150 @@ func main() {
 	}
 
 	slog.Info("Successfully connected to the database", "MONGO", conf.MONGO.Connection)
-
 	slog.Info("Successfully connected to the database", "DB", conf.DB.Connection)
 
-	errPostgresMigrate := db.Migrate()
-	if errPostgresMigrate != nil {
-		slog.Error("Error migrating database", "error", errPostgresMigrate)
-		os.Exit(1)
+	// With auto-migration disabled the migrate command is run once per
+	// deploy, and the readiness probe keeps the replicas out of the load
+	// balancer until the schema is at the version they expect. Mongo being
+	// behind only degrades it, like Mongo being down.
+	if conf.DB.AutoMigrate {
+		if err := db.Migrate(ctx); err != nil {
+			slog.Error("Error migrating database", "error", err)
+			os.Exit(1)
+		}
+		if err := mongo.Migrate(ctx); err != nil {
+			slog.Error("Error migrating mongo database", "error", err)
+			os.Exit(1)
+		}
+	} else {
+		slog.Info("Auto-migration disabled, skipping Postgres and Mongo migrations")
 	}
 
-	defer db.Close()
-
-	if (errMongo != nil) || (errPostgresMigrate != nil || errPostgres != nil) {
-		slog.Error("Error initializing database connection", "error", errMongo)
+	errBootstrap := dependency.BootstrapAdmin(ctx, conf.AUTH, db)
+	if errBootstrap != nil {
+		slog.Error("Error creating bootstrap admin user", "error", errBootstrap)
 		os.Exit(1)
 	}
 
 	// di
-	healthHandler, clientHandler, productHandler, orderHandler := dependency.Setup(conf.App, db, mongo)
+	// Work started by requests but finished after them, such as customer
+	// notifications, is drained before the databases are closed.
+	background := &utils.Background{}
+	handlers, err := dependency.Setup(conf, db, mongo, background)
+	if err != nil {
+		slog.Error("Error initializing dependencies", "error", err)
+		os.Exit(1)
+	}
 
 	router, err := router.NewRouter(
 		conf.HTTP,
-		healthHandler,
-		clientHandler,
-		productHandler,
-		orderHandler,
+		handlers.Health,
+		handlers.Client,
+		handlers.Product,
+		handlers.Order,
+		handlers.User,
+		handlers.ApiKey,
+		handlers.Session,
+		handlers.ApiKeyAuth,
+		handlers.Idempotency,
+		handlers.RateLimit,
+		handlers.Metrics,
+		handlers.LogLevel,
+		handlers.Menu,
+		handlers.Graph,
 	)
 	if err != nil {
 		slog.Error("Error initializing router", "error", err)
 		os.Exit(1)
 	}
 
-	listenAddress := fmt.Sprintf("%s:%s", conf.HTTP.URL, conf.HTTP.Port)
-	slog.Info("Starting the HTTP server", "listen_address", listenAddress)
-	err = router.Run(listenAddress)
+	serverErrors := make(chan error, 2)
+
+	grpcListenAddress := fmt.Sprintf("%s:%s", conf.GRPC.URL, conf.GRPC.Port)
+	grpcListener, err := net.Listen("tcp", grpcListenAddress)
 	if err != nil {
-		slog.Error("Error starting the HTTP server", "error", err)
+		slog.Error("Error listening for gRPC", "error", err)
 		os.Exit(1)
 	}
+	slog.Info("Starting the gRPC server", "listen_address", grpcListenAddress)
+	go func() {
+		if err := handlers.Grpc.Serve(grpcListener); err != nil {
+			serverErrors <- fmt.Errorf("gRPC server - %w", err)
+		}
+	}()
+
+	listenAddress := fmt.Sprintf("%s:%s", conf.HTTP.URL, conf.HTTP.Port)
+	httpServer := &http.Server{
+		Addr:         listenAddress,
+		Handler:      router,
+		ReadTimeout:  conf.HTTP.ReadTimeout,
+		WriteTimeout: conf.HTTP.WriteTimeout,
+		IdleTimeout:  conf.HTTP.IdleTimeout,
+	}
+	slog.Info("Starting the HTTP server", "listen_address", listenAddress)
+	go func() {
+		if err := httpServer.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+			serverErrors <- fmt.Errorf("HTTP server - %w", err)
+		}
+	}()
+
+	exitCode := 0
+	select {
+	case <-ctx.Done():
+		slog.Info("Shutting down", "grace_period", conf.HTTP.ShutdownTimeout)
+	case err := <-serverErrors:
+		slog.Error("Error running the server, shutting down", "error", err)
+		exitCode = 1
+	}
+	stop()
+
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), conf.HTTP.ShutdownTimeout)
+	defer cancelShutdown()
+	if err := shutdown(shutdownCtx, httpServer, handlers.Grpc, background, db, mongo); err != nil {
+		slog.Error("Error shutting down", "error", err)
+		exitCode = 1
+	}
+	// Spans of the last requests are flushed once the servers are done.
+	tracingCtx, cancelTracing := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelTracing()
+	if err := shutdownTracing(tracingCtx); err != nil {
+		slog.Error("Error flushing traces", "error", err)
+		exitCode = 1
+	}
+	slog.Info("Shutdown complete")
+	os.Exit(exitCode)
+}
+
+// shutdown stops accepting requests and waits for the in-flight ones, and
+// then for the background work they started, until the context is done. Only
+// then it closes the Postgres pool and the Mongo client, in that order, so no
+// request or notification is cut while still using them.
+func shutdown(ctx context.Context, httpServer *http.Server, grpcServer *grpc.Server, background *utils.Background, db *postgres.DB, mongo *mongo.MONGO) error {
+	var errs []error
+	grpcStopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(grpcStopped)
+	}()
+	if err := httpServer.Shutdown(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("failed to drain HTTP requests - %w", err))
+	}
+	select {
+	case <-grpcStopped:
+	case <-ctx.Done():
+		// Streams such as WatchOrderStatus only end with the client, so they
+		// are cut once the grace period is over.
+		grpcServer.Stop()
+		<-grpcStopped
+	}
+	if err := background.Wait(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("failed to drain background work - %w", err))
+	}
+	db.Close()
+	mongoCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := mongo.Close(mongoCtx); err != nil {
+		errs = append(errs, fmt.Errorf("failed to disconnect from mongo - %w", err))
+	}
+	return errors.Join(errs...)
 }
@@ -0,0 +1,81 @@
+package controllers
+
+import (
+	"context"
+	dto "post-tech-challenge-10soat/internal/dto/apikey"
+	entity "post-tech-challenge-10soat/internal/entities"
+	"post-tech-challenge-10soat/internal/usecases/apikey"
+)
+
+// ApiKeyController defines the interface for api key controller
+type ApiKeyController interface {
+	CreateApiKey(ctx context.Context, createApiKey dto.CreateApiKeyDTO) (entity.ApiKey, error)
+	ListApiKeys(ctx context.Context) ([]entity.ApiKey, error)
+	RotateApiKey(ctx context.Context, id string) (entity.ApiKey, error)
+	RevokeApiKey(ctx context.Context, id string) (entity.ApiKey, error)
+	AuthenticateApiKey(ctx context.Context, key string) (entity.Device, error)
+}
+
+type apiKeyController struct {
+	createApiKey       apikey.CreateApiKeyUseCase
+	listApiKeys        apikey.ListApiKeysUseCase
+	rotateApiKey       apikey.RotateApiKeyUseCase
+	revokeApiKey       apikey.RevokeApiKeyUseCase
+	authenticateApiKey apikey.AuthenticateApiKeyUseCase
+}
+
+func NewApiKeyController(
+	createApiKey apikey.CreateApiKeyUseCase,
+	listApiKeys apikey.ListApiKeysUseCase,
+	rotateApiKey apikey.RotateApiKeyUseCase,
+	revokeApiKey apikey.RevokeApiKeyUseCase,
+	authenticateApiKey apikey.AuthenticateApiKeyUseCase,
+) ApiKeyController {
+	return &apiKeyController{
+		createApiKey:       createApiKey,
+		listApiKeys:        listApiKeys,
+		rotateApiKey:       rotateApiKey,
+		revokeApiKey:       revokeApiKey,
+		authenticateApiKey: authenticateApiKey,
+	}
+}
+
+func (c *apiKeyController) CreateApiKey(ctx context.Context, createApiKey dto.CreateApiKeyDTO) (entity.ApiKey, error) {
+	apiKey, err := c.createApiKey.Execute(ctx, createApiKey)
+	if err != nil {
+		return entity.ApiKey{}, err
+	}
+	return apiKey, nil
+}
+
+func (c *apiKeyController) ListApiKeys(ctx context.Context) ([]entity.ApiKey, error) {
+	apiKeys, err := c.listApiKeys.Execute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return apiKeys, nil
+}
+
+func (c *apiKeyController) RotateApiKey(ctx context.Context, id string) (entity.ApiKey, error) {
+	apiKey, err := c.rotateApiKey.Execute(ctx, id)
+	if err != nil {
+		return entity.ApiKey{}, err
+	}
+	return apiKey, nil
+}
+
+func (c *apiKeyController) RevokeApiKey(ctx context.Context, id string) (entity.ApiKey, error) {
+	apiKey, err := c.revokeApiKey.Execute(ctx, id)
+	if err != nil {
+		return entity.ApiKey{}, err
+	}
+	return apiKey, nil
+}
+
+func (c *apiKeyController) AuthenticateApiKey(ctx context.Context, key string) (entity.Device, error) {
+	device, err := c.authenticateApiKey.Execute(ctx, key)
+	if err != nil {
+		return entity.Device{}, err
+	}
+	return device, nil
+}
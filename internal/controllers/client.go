@@ -3,6 +3,7 @@ package controllers
 import (
 	"context"
 	dto "post-tech-challenge-10soat/internal/dto/client"
+	consentdto "post-tech-challenge-10soat/internal/dto/consent"
 	entity "post-tech-challenge-10soat/internal/entities"
 	"post-tech-challenge-10soat/internal/usecases/client"
 )
@@ -12,23 +13,47 @@ type ClientController interface {
 	CreateClient(ctx context.Context, createClient dto.CreateClientDTO) (entity.Client, error)
 	GetClientByCpf(ctx context.Context, cpf string) (entity.Client, error)
 	GetClientById(ctx context.Context, id string) (entity.Client, error)
+	ListClientsByIds(ctx context.Context, ids []string) ([]entity.Client, error)
+	RequestIdentificationCode(ctx context.Context, cpf string) (entity.ClientIdentification, error)
+	VerifyIdentificationCode(ctx context.Context, identificationId string, code string) (entity.Token, error)
+	ListConsents(ctx context.Context) ([]entity.ClientConsent, error)
+	GrantConsent(ctx context.Context, consent consentdto.UpdateClientConsentDTO) (entity.ClientConsent, error)
+	RevokeConsent(ctx context.Context, consent consentdto.UpdateClientConsentDTO) (entity.ClientConsent, error)
 }
 
 type clientController struct {
 	getClientByCpf client.GetClientByCpfUseCase
 	getClientById  client.GetClientByIdUseCase
+	listByIds      client.ListClientsByIdsUseCase
 	createClient   client.CreateClientUseCase
+	requestCode    client.RequestIdentificationCodeUseCase
+	verifyCode     client.VerifyIdentificationCodeUseCase
+	listConsents   client.ListClientConsentsUseCase
+	grantConsent   client.GrantClientConsentUseCase
+	revokeConsent  client.RevokeClientConsentUseCase
 }
 
 func NewClientController(
 	getClientByCpf client.GetClientByCpfUseCase,
 	getClientById client.GetClientByIdUseCase,
+	listByIds client.ListClientsByIdsUseCase,
 	createClient client.CreateClientUseCase,
+	requestCode client.RequestIdentificationCodeUseCase,
+	verifyCode client.VerifyIdentificationCodeUseCase,
+	listConsents client.ListClientConsentsUseCase,
+	grantConsent client.GrantClientConsentUseCase,
+	revokeConsent client.RevokeClientConsentUseCase,
 ) ClientController {
 	return &clientController{
 		getClientByCpf: getClientByCpf,
 		getClientById:  getClientById,
+		listByIds:      listByIds,
 		createClient:   createClient,
+		requestCode:    requestCode,
+		verifyCode:     verifyCode,
+		listConsents:   listConsents,
+		grantConsent:   grantConsent,
+		revokeConsent:  revokeConsent,
 	}
 }
 
@@ -48,6 +73,10 @@ func (c *clientController) GetClientById(ctx context.Context, id string) (entity
 	return client, nil
 }
 
+func (c *clientController) ListClientsByIds(ctx context.Context, ids []string) ([]entity.Client, error) {
+	return c.listByIds.Execute(ctx, ids)
+}
+
 func (c *clientController) CreateClient(ctx context.Context, createClient dto.CreateClientDTO) (entity.Client, error) {
 	client, err := c.createClient.Execute(ctx, createClient)
 	if err != nil {
@@ -55,3 +84,43 @@ func (c *clientController) CreateClient(ctx context.Context, createClient dto.Cr
 	}
 	return client, nil
 }
+
+func (c *clientController) RequestIdentificationCode(ctx context.Context, cpf string) (entity.ClientIdentification, error) {
+	identification, err := c.requestCode.Execute(ctx, cpf)
+	if err != nil {
+		return entity.ClientIdentification{}, err
+	}
+	return identification, nil
+}
+
+func (c *clientController) VerifyIdentificationCode(ctx context.Context, identificationId string, code string) (entity.Token, error) {
+	token, err := c.verifyCode.Execute(ctx, identificationId, code)
+	if err != nil {
+		return entity.Token{}, err
+	}
+	return token, nil
+}
+
+func (c *clientController) ListConsents(ctx context.Context) ([]entity.ClientConsent, error) {
+	consents, err := c.listConsents.Execute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return consents, nil
+}
+
+func (c *clientController) GrantConsent(ctx context.Context, consent consentdto.UpdateClientConsentDTO) (entity.ClientConsent, error) {
+	granted, err := c.grantConsent.Execute(ctx, consent)
+	if err != nil {
+		return entity.ClientConsent{}, err
+	}
+	return granted, nil
+}
+
+func (c *clientController) RevokeConsent(ctx context.Context, consent consentdto.UpdateClientConsentDTO) (entity.ClientConsent, error) {
+	revoked, err := c.revokeConsent.Execute(ctx, consent)
+	if err != nil {
+		return entity.ClientConsent{}, err
+	}
+	return revoked, nil
+}
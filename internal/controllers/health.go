@@ -0,0 +1,28 @@
+package controllers
+
+import (
+	"context"
+	entity "post-tech-challenge-10soat/internal/entities"
+	"post-tech-challenge-10soat/internal/usecases/health"
+)
+
+// HealthController defines the interface for the health probes controller
+type HealthController interface {
+	CheckReadiness(ctx context.Context) entity.HealthReport
+}
+
+type healthController struct {
+	checkReadiness health.CheckReadinessUseCase
+}
+
+func NewHealthController(
+	checkReadiness health.CheckReadinessUseCase,
+) HealthController {
+	return &healthController{
+		checkReadiness: checkReadiness,
+	}
+}
+
+func (c *healthController) CheckReadiness(ctx context.Context) entity.HealthReport {
+	return c.checkReadiness.Execute(ctx)
+}
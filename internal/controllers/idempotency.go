@@ -0,0 +1,40 @@
+package controllers
+
+import (
+	"context"
+	entity "post-tech-challenge-10soat/internal/entities"
+	"post-tech-challenge-10soat/internal/usecases/idempotency"
+)
+
+// IdempotencyController defines the interface for idempotency controller
+type IdempotencyController interface {
+	BeginRequest(ctx context.Context, request entity.IdempotencyRecord) (entity.IdempotencyRecord, bool, error)
+	FinishRequest(ctx context.Context, record entity.IdempotencyRecord) error
+}
+
+type idempotencyController struct {
+	beginRequest  idempotency.BeginIdempotentRequestUseCase
+	finishRequest idempotency.FinishIdempotentRequestUseCase
+}
+
+func NewIdempotencyController(
+	beginRequest idempotency.BeginIdempotentRequestUseCase,
+	finishRequest idempotency.FinishIdempotentRequestUseCase,
+) IdempotencyController {
+	return &idempotencyController{
+		beginRequest:  beginRequest,
+		finishRequest: finishRequest,
+	}
+}
+
+func (c *idempotencyController) BeginRequest(ctx context.Context, request entity.IdempotencyRecord) (entity.IdempotencyRecord, bool, error) {
+	record, replay, err := c.beginRequest.Execute(ctx, request)
+	if err != nil {
+		return entity.IdempotencyRecord{}, false, err
+	}
+	return record, replay, nil
+}
+
+func (c *idempotencyController) FinishRequest(ctx context.Context, record entity.IdempotencyRecord) error {
+	return c.finishRequest.Execute(ctx, record)
+}
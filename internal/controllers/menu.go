@@ -0,0 +1,36 @@
+package controllers
+
+import (
+	"context"
+	entity "post-tech-challenge-10soat/internal/entities"
+	"post-tech-challenge-10soat/internal/usecases/menu"
+)
+
+// MenuController defines the interface for the menu controller
+type MenuController interface {
+	ImportMenu(ctx context.Context, menu entity.Menu, dryRun bool) (entity.MenuImport, error)
+	ExportMenu(ctx context.Context) (entity.Menu, error)
+}
+
+type menuController struct {
+	importMenu menu.ImportMenuUseCase
+	exportMenu menu.ExportMenuUseCase
+}
+
+func NewMenuController(
+	importMenu menu.ImportMenuUseCase,
+	exportMenu menu.ExportMenuUseCase,
+) MenuController {
+	return &menuController{
+		importMenu: importMenu,
+		exportMenu: exportMenu,
+	}
+}
+
+func (c *menuController) ImportMenu(ctx context.Context, menu entity.Menu, dryRun bool) (entity.MenuImport, error) {
+	return c.importMenu.Execute(ctx, menu, dryRun)
+}
+
+func (c *menuController) ExportMenu(ctx context.Context) (entity.Menu, error) {
+	return c.exportMenu.Execute(ctx)
+}
@@ -0,0 +1,27 @@
+package controllers
+
+import (
+	"context"
+	"post-tech-challenge-10soat/internal/usecases/metrics"
+)
+
+// MetricsController defines the interface for the metrics controller
+type MetricsController interface {
+	RefreshOrdersByStatus(ctx context.Context) error
+}
+
+type metricsController struct {
+	refreshOrdersByStatus metrics.RefreshOrdersByStatusUseCase
+}
+
+func NewMetricsController(
+	refreshOrdersByStatus metrics.RefreshOrdersByStatusUseCase,
+) MetricsController {
+	return &metricsController{
+		refreshOrdersByStatus: refreshOrdersByStatus,
+	}
+}
+
+func (c *metricsController) RefreshOrdersByStatus(ctx context.Context) error {
+	return c.refreshOrdersByStatus.Execute(ctx)
+}
@@ -12,6 +12,8 @@ type OrderController struct {
 	listOrders            order.ListOrdersUseCase
 	getOrderPaymentStatus order.GetOrderPaymentStatusUseCase
 	updateOrderStatus     order.UpdateOrderStatusUseCase
+	listOrderItems        order.ListOrderItemsUseCase
+	watchOrderStatus      order.WatchOrderStatusUseCase
 }
 
 func NewOrderController(
@@ -19,12 +21,16 @@ func NewOrderController(
 	listOrders order.ListOrdersUseCase,
 	getOrderPaymentStatus order.GetOrderPaymentStatusUseCase,
 	updateOrderStatus order.UpdateOrderStatusUseCase,
+	listOrderItems order.ListOrderItemsUseCase,
+	watchOrderStatus order.WatchOrderStatusUseCase,
 ) *OrderController {
 	return &OrderController{
 		createOrder,
 		listOrders,
 		getOrderPaymentStatus,
 		updateOrderStatus,
+		listOrderItems,
+		watchOrderStatus,
 	}
 }
 
@@ -52,10 +58,26 @@ func (c *OrderController) GetOrderPaymentStatus(ctx context.Context, id string)
 	return orderPaymentStatus, nil
 }
 
-func (c *OrderController) UpdateOrderStatus(ctx context.Context, id string, status string) (entity.Order, error) {
-	order, err := c.updateOrderStatus.Execute(ctx, id, status)
+func (c *OrderController) UpdateOrderStatus(ctx context.Context, id string, status string, version int) (entity.Order, error) {
+	order, err := c.updateOrderStatus.Execute(ctx, id, status, version)
 	if err != nil {
 		return entity.Order{}, err
 	}
 	return order, nil
 }
+
+func (c *OrderController) ListOrderItems(ctx context.Context, orderIds []string) ([]entity.OrderProduct, error) {
+	items, err := c.listOrderItems.Execute(ctx, orderIds)
+	if err != nil {
+		return []entity.OrderProduct{}, err
+	}
+	return items, nil
+}
+
+func (c *OrderController) WatchOrderStatus(ctx context.Context, orderId string) (<-chan entity.Order, error) {
+	orders, err := c.watchOrderStatus.Execute(ctx, orderId)
+	if err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
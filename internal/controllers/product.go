@@ -8,10 +8,11 @@ import (
 )
 
 type ProductController struct {
-	createProduct product.CreateProductUseCase
-	deleteProduct product.DeleteProductUseCase
-	updateProduct product.UpdateProductUseCase
-	listProducts  product.ListProductsUseCase
+	createProduct     product.CreateProductUseCase
+	deleteProduct     product.DeleteProductUseCase
+	updateProduct     product.UpdateProductUseCase
+	listProducts      product.ListProductsUseCase
+	listProductsByIds product.ListProductsByIdsUseCase
 }
 
 func NewProductController(
@@ -19,12 +20,14 @@ func NewProductController(
 	deleteProduct product.DeleteProductUseCase,
 	updateProduct product.UpdateProductUseCase,
 	listProducts product.ListProductsUseCase,
+	listProductsByIds product.ListProductsByIdsUseCase,
 ) *ProductController {
 	return &ProductController{
 		createProduct,
 		deleteProduct,
 		updateProduct,
 		listProducts,
+		listProductsByIds,
 	}
 }
 
@@ -59,3 +62,11 @@ func (c *ProductController) ListProducts(ctx context.Context, categoryId string)
 	}
 	return products, nil
 }
+
+func (c *ProductController) ListProductsByIds(ctx context.Context, ids []string) ([]entity.Product, error) {
+	products, err := c.listProductsByIds.Execute(ctx, ids)
+	if err != nil {
+		return []entity.Product{}, err
+	}
+	return products, nil
+}
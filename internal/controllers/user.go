@@ -0,0 +1,45 @@
+package controllers
+
+import (
+	"context"
+	dto "post-tech-challenge-10soat/internal/dto/user"
+	entity "post-tech-challenge-10soat/internal/entities"
+	"post-tech-challenge-10soat/internal/usecases/user"
+)
+
+// UserController defines the interface for staff user controller
+type UserController interface {
+	CreateUser(ctx context.Context, createUser dto.CreateUserDTO) (entity.User, error)
+	IssueToken(ctx context.Context, username string, password string) (entity.Token, error)
+}
+
+type userController struct {
+	createUser user.CreateUserUseCase
+	issueToken user.IssueStaffTokenUseCase
+}
+
+func NewUserController(
+	createUser user.CreateUserUseCase,
+	issueToken user.IssueStaffTokenUseCase,
+) UserController {
+	return &userController{
+		createUser: createUser,
+		issueToken: issueToken,
+	}
+}
+
+func (c *userController) CreateUser(ctx context.Context, createUser dto.CreateUserDTO) (entity.User, error) {
+	user, err := c.createUser.Execute(ctx, createUser)
+	if err != nil {
+		return entity.User{}, err
+	}
+	return user, nil
+}
+
+func (c *userController) IssueToken(ctx context.Context, username string, password string) (entity.Token, error) {
+	token, err := c.issueToken.Execute(ctx, username, password)
+	if err != nil {
+		return entity.Token{}, err
+	}
+	return token, nil
+}
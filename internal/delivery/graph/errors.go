@@ -0,0 +1,43 @@
+package graph
+
+import (
+	"context"
+	"log/slog"
+	entity "post-tech-challenge-10soat/internal/entities"
+	"post-tech-challenge-10soat/internal/infrastructure/logger"
+)
+
+// resolverError carries the same stable code as the problem details returned
+// by the REST API, under the error's extensions.
+type resolverError struct {
+	err       error
+	code      entity.ErrorCode
+	requestId string
+}
+
+func newResolverError(ctx context.Context, err error) error {
+	code := entity.ErrorCodeOf(err)
+	// Clients only see the message, the log line carries the full error and
+	// the request id returned to them.
+	if code == entity.ErrCodeInternal {
+		slog.ErrorContext(ctx, "Error resolving graphql field", "error", err)
+	}
+	requestId, _ := logger.RequestIdFromContext(ctx)
+	return resolverError{err, code, requestId}
+}
+
+func (e resolverError) Error() string {
+	return e.err.Error()
+}
+
+func (e resolverError) Unwrap() error {
+	return e.err
+}
+
+func (e resolverError) Extensions() map[string]interface{} {
+	extensions := map[string]interface{}{"code": e.code}
+	if e.requestId != "" {
+		extensions["request_id"] = e.requestId
+	}
+	return extensions
+}
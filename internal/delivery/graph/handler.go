@@ -0,0 +1,59 @@
+package graph
+
+import (
+	_ "embed"
+	"net/http"
+	"post-tech-challenge-10soat/internal/controllers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/graph-gophers/graphql-go"
+)
+
+//go:embed schema.graphql
+var schema string
+
+// maxDepth bounds how deeply queries can nest, so a single request cannot
+// fan out into an arbitrary number of lookups.
+const maxDepth = 8
+
+type Handler struct {
+	schema            *graphql.Schema
+	productController controllers.ProductController
+	orderController   controllers.OrderController
+	clientController  controllers.ClientController
+}
+
+func NewHandler(productController controllers.ProductController, orderController controllers.OrderController, clientController controllers.ClientController) (Handler, error) {
+	parsedSchema, err := graphql.ParseSchema(schema, &resolver{
+		productController,
+		orderController,
+	}, graphql.MaxDepth(maxDepth))
+	if err != nil {
+		return Handler{}, err
+	}
+	return Handler{
+		parsedSchema,
+		productController,
+		orderController,
+		clientController,
+	}, nil
+}
+
+type request struct {
+	Query         string                 `json:"query" binding:"required"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Query executes a GraphQL request. Errors are reported in the response body
+// with a 200, as GraphQL clients expect, except for unparseable requests.
+func (h *Handler) Query(ctx *gin.Context) {
+	var req request
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"errors": []gin.H{{"message": err.Error()}}})
+		return
+	}
+	requestCtx := contextWithLoaders(ctx.Request.Context(), newLoaders(h.productController, h.orderController, h.clientController))
+	response := h.schema.Exec(requestCtx, req.Query, req.OperationName, req.Variables)
+	ctx.JSON(http.StatusOK, response)
+}
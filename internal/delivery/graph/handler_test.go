@@ -0,0 +1,292 @@
+package graph
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"post-tech-challenge-10soat/internal/controllers"
+	dto "post-tech-challenge-10soat/internal/dto/order"
+	entity "post-tech-challenge-10soat/internal/entities"
+	"post-tech-challenge-10soat/internal/usecases/order"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockListOrdersUseCase struct {
+	mock.Mock
+}
+
+func (m *MockListOrdersUseCase) Execute(ctx context.Context, limit uint64) ([]entity.Order, error) {
+	args := m.Called(ctx, limit)
+	return args.Get(0).([]entity.Order), args.Error(1)
+}
+
+type MockListOrderItemsUseCase struct {
+	mock.Mock
+}
+
+func (m *MockListOrderItemsUseCase) Execute(ctx context.Context, orderIds []string) ([]entity.OrderProduct, error) {
+	args := m.Called(ctx, orderIds)
+	return args.Get(0).([]entity.OrderProduct), args.Error(1)
+}
+
+type MockListProductsByIdsUseCase struct {
+	mock.Mock
+}
+
+func (m *MockListProductsByIdsUseCase) Execute(ctx context.Context, ids []string) ([]entity.Product, error) {
+	args := m.Called(ctx, ids)
+	return args.Get(0).([]entity.Product), args.Error(1)
+}
+
+type MockCreateOrderUseCase struct {
+	mock.Mock
+}
+
+func (m *MockCreateOrderUseCase) Execute(ctx context.Context, createOrder dto.CreateOrderDTO) (entity.Order, error) {
+	args := m.Called(ctx, createOrder)
+	return args.Get(0).(entity.Order), args.Error(1)
+}
+
+type MockListClientsByIdsUseCase struct {
+	mock.Mock
+}
+
+func (m *MockListClientsByIdsUseCase) Execute(ctx context.Context, ids []string) ([]entity.Client, error) {
+	args := m.Called(ctx, ids)
+	return args.Get(0).([]entity.Client), args.Error(1)
+}
+
+type MockGetOrderPaymentStatusUseCase struct {
+	mock.Mock
+}
+
+func (m *MockGetOrderPaymentStatusUseCase) Execute(ctx context.Context, id string) (order.OrderPaymentStatus, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(order.OrderPaymentStatus), args.Error(1)
+}
+
+type mocks struct {
+	listOrders            *MockListOrdersUseCase
+	listOrderItems        *MockListOrderItemsUseCase
+	listProductsByIds     *MockListProductsByIdsUseCase
+	createOrder           *MockCreateOrderUseCase
+	listClientsByIds      *MockListClientsByIdsUseCase
+	getOrderPaymentStatus *MockGetOrderPaymentStatusUseCase
+}
+
+func setupGraphTestRouter(t *testing.T, session *entity.Session) (*gin.Engine, mocks) {
+	m := mocks{
+		&MockListOrdersUseCase{},
+		&MockListOrderItemsUseCase{},
+		&MockListProductsByIdsUseCase{},
+		&MockCreateOrderUseCase{},
+		&MockListClientsByIdsUseCase{},
+		&MockGetOrderPaymentStatusUseCase{},
+	}
+	productController := controllers.NewProductController(nil, nil, nil, nil, m.listProductsByIds)
+	orderController := controllers.NewOrderController(m.createOrder, m.listOrders, m.getOrderPaymentStatus, nil, m.listOrderItems, nil)
+	clientController := controllers.NewClientController(nil, nil, m.listClientsByIds, nil, nil, nil, nil, nil, nil)
+	h, err := NewHandler(*productController, *orderController, clientController)
+	assert.NoError(t, err)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/graphql", func(ctx *gin.Context) {
+		if session != nil {
+			ctx.Request = ctx.Request.WithContext(entity.ContextWithSession(ctx.Request.Context(), *session))
+		}
+	}, h.Query)
+	return r, m
+}
+
+type graphResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message    string                 `json:"message"`
+		Extensions map[string]interface{} `json:"extensions"`
+	} `json:"errors"`
+}
+
+func execute(r *gin.Engine, query string, variables map[string]interface{}) (*httptest.ResponseRecorder, graphResponse) {
+	body, _ := json.Marshal(map[string]interface{}{"query": query, "variables": variables})
+	req, _ := http.NewRequest("POST", "/graphql", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	var response graphResponse
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+	return w, response
+}
+
+func TestHandler_Orders_BatchesItemsAndProducts(t *testing.T) {
+	// Setup
+	r, m := setupGraphTestRouter(t, &entity.Session{Subject: "user-1", Role: entity.RoleKitchen})
+	m.listOrders.On("Execute", mock.Anything, uint64(10)).Return([]entity.Order{
+		{Id: "order-1", Number: 1, Status: entity.OrderStatusReady, Version: 2},
+		{Id: "order-2", Number: 2, Status: entity.OrderStatusReceived, Version: 1},
+	}, nil)
+	m.listOrderItems.On("Execute", mock.Anything, mock.MatchedBy(func(ids []string) bool {
+		return assert.ElementsMatch(t, []string{"order-1", "order-2"}, ids)
+	})).Return([]entity.OrderProduct{
+		{Id: "item-1", OrderId: "order-1", ProductId: "product-1", Quantity: 2},
+		{Id: "item-2", OrderId: "order-2", ProductId: "product-1", Quantity: 1},
+		{Id: "item-3", OrderId: "order-2", ProductId: "product-2", Quantity: 1},
+	}, nil).Once()
+	m.listProductsByIds.On("Execute", mock.Anything, mock.MatchedBy(func(ids []string) bool {
+		return assert.ElementsMatch(t, []string{"product-1", "product-2"}, ids)
+	})).Return([]entity.Product{
+		{Id: "product-1", Name: "X-Burger", Category: entity.Category{Id: "category-1", Name: "Lanche"}},
+		{Id: "product-2", Name: "Batata", Category: entity.Category{Id: "category-2", Name: "Acompanhamento"}},
+	}, nil).Once()
+
+	// Act
+	w, response := execute(r, `{ orders(limit: 10) { id version items { quantity product { name category { name } } } } }`, nil)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, response.Errors)
+	assert.JSONEq(t, `{"orders": [
+		{"id": "order-1", "version": 2, "items": [{"quantity": 2, "product": {"name": "X-Burger", "category": {"name": "Lanche"}}}]},
+		{"id": "order-2", "version": 1, "items": [
+			{"quantity": 1, "product": {"name": "X-Burger", "category": {"name": "Lanche"}}},
+			{"quantity": 1, "product": {"name": "Batata", "category": {"name": "Acompanhamento"}}}
+		]}
+	]}`, string(response.Data))
+	m.listOrderItems.AssertExpectations(t)
+	m.listProductsByIds.AssertExpectations(t)
+}
+
+func TestHandler_Orders_BatchesClients(t *testing.T) {
+	// Setup
+	r, m := setupGraphTestRouter(t, &entity.Session{Subject: "user-1", Role: entity.RoleKitchen})
+	m.listOrders.On("Execute", mock.Anything, uint64(10)).Return([]entity.Order{
+		{Id: "order-1", ClientId: "client-1"},
+		{Id: "order-2"},
+		{Id: "order-3", ClientId: "client-2"},
+		{Id: "order-4", ClientId: "client-1"},
+	}, nil)
+	m.listClientsByIds.On("Execute", mock.Anything, mock.MatchedBy(func(ids []string) bool {
+		return assert.ElementsMatch(t, []string{"client-1", "client-2"}, ids)
+	})).Return([]entity.Client{
+		{Id: "client-1", Name: "Ana", Cpf: "12345678900", Email: "ana@email.com"},
+		{Id: "client-2", Name: "Bruno", Cpf: "98765432100", Email: "bruno@email.com"},
+	}, nil).Once()
+
+	// Act
+	w, response := execute(r, `{ orders(limit: 10) { id client { id name cpf email } } }`, nil)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, response.Errors)
+	assert.JSONEq(t, `{"orders": [
+		{"id": "order-1", "client": {"id": "client-1", "name": "Ana", "cpf": null, "email": null}},
+		{"id": "order-2", "client": null},
+		{"id": "order-3", "client": {"id": "client-2", "name": "Bruno", "cpf": null, "email": null}},
+		{"id": "order-4", "client": {"id": "client-1", "name": "Ana", "cpf": null, "email": null}}
+	]}`, string(response.Data))
+	m.listClientsByIds.AssertExpectations(t)
+}
+
+func TestHandler_CreateOrder_ShowsTheClientItsContact(t *testing.T) {
+	// Setup
+	r, m := setupGraphTestRouter(t, &entity.Session{Subject: "client-1", Role: entity.RoleCustomer})
+	m.createOrder.On("Execute", mock.Anything, mock.Anything).Return(entity.Order{Id: "order-1", ClientId: "client-1"}, nil)
+	m.listClientsByIds.On("Execute", mock.Anything, []string{"client-1"}).Return([]entity.Client{
+		{Id: "client-1", Name: "Ana", Cpf: "12345678900", Email: "ana@email.com"},
+	}, nil)
+
+	// Act
+	w, response := execute(r, `mutation { createOrder(input: {clientId: "client-1", products: [{productId: "product-1", quantity: 1}]}) { paymentStatus client { name cpf email } } }`, nil)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, response.Errors)
+	assert.JSONEq(t, `{"createOrder": {"paymentStatus": "payment_pending", "client": {"name": "Ana", "cpf": "12345678900", "email": "ana@email.com"}}}`, string(response.Data))
+}
+
+func TestHandler_Orders_PaymentStatus(t *testing.T) {
+	// Setup
+	r, m := setupGraphTestRouter(t, &entity.Session{Subject: "user-1", Role: entity.RoleKitchen})
+	m.listOrders.On("Execute", mock.Anything, uint64(10)).Return([]entity.Order{{Id: "order-1", PaymentId: "payment-1"}, {Id: "order-2"}}, nil)
+
+	// Act
+	w, response := execute(r, `{ orders(limit: 10) { id paymentStatus } }`, nil)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, response.Errors)
+	assert.JSONEq(t, `{"orders": [{"id": "order-1", "paymentStatus": "payment_approved"}, {"id": "order-2", "paymentStatus": "payment_pending"}]}`, string(response.Data))
+	m.getOrderPaymentStatus.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+}
+
+func TestHandler_Orders_RequiresCredentials(t *testing.T) {
+	// Setup
+	r, m := setupGraphTestRouter(t, nil)
+
+	// Act
+	w, response := execute(r, `{ orders(limit: 10) { id } }`, nil)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Len(t, response.Errors, 1)
+	assert.Equal(t, string(entity.ErrCodeUnauthorized), response.Errors[0].Extensions["code"])
+	m.listOrders.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+}
+
+func TestHandler_CreateOrder(t *testing.T) {
+	// Setup
+	r, m := setupGraphTestRouter(t, &entity.Session{Subject: "kiosk-1", Role: entity.RoleKiosk})
+	m.createOrder.On("Execute", mock.Anything, dto.CreateOrderDTO{
+		Products: []dto.CreateOrderProduct{{ProductId: "product-1", Quantity: 2, Observation: "Sem cebola"}},
+	}).Return(entity.Order{Id: "order-1", Number: 7, Status: entity.OrderStatusPaymentPending, Total: 50, Version: 1}, nil)
+
+	// Act
+	w, response := execute(r, `mutation($input: CreateOrderInput!) { createOrder(input: $input) { id number status total } }`, map[string]interface{}{
+		"input": map[string]interface{}{
+			"products": []map[string]interface{}{{"productId": "product-1", "quantity": 2, "observation": "Sem cebola"}},
+		},
+	})
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, response.Errors)
+	assert.JSONEq(t, `{"createOrder": {"id": "order-1", "number": 7, "status": "payment_pending", "total": 50}}`, string(response.Data))
+}
+
+func TestHandler_CreateOrder_ReadsItemsFromThePrimary(t *testing.T) {
+	// Setup
+	r, m := setupGraphTestRouter(t, &entity.Session{Subject: "kiosk-1", Role: entity.RoleKiosk})
+	m.createOrder.On("Execute", mock.Anything, mock.Anything).Return(entity.Order{Id: "order-1", Number: 7, Status: entity.OrderStatusPaymentPending, Total: 50, Version: 1}, nil)
+	m.listOrderItems.On("Execute", mock.MatchedBy(entity.ReadYourWritesFromContext), []string{"order-1"}).Return([]entity.OrderProduct{
+		{Id: "item-1", OrderId: "order-1", ProductId: "product-1", Quantity: 2},
+	}, nil).Once()
+
+	// Act
+	w, response := execute(r, `mutation { createOrder(input: {products: [{productId: "product-1", quantity: 2}]}) { id items { quantity } } }`, nil)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, response.Errors)
+	assert.JSONEq(t, `{"createOrder": {"id": "order-1", "items": [{"quantity": 2}]}}`, string(response.Data))
+	m.listOrderItems.AssertExpectations(t)
+}
+
+func TestHandler_CreateOrder_ReportsErrorCode(t *testing.T) {
+	// Setup
+	r, m := setupGraphTestRouter(t, &entity.Session{Subject: "kiosk-1", Role: entity.RoleKiosk})
+	m.createOrder.On("Execute", mock.Anything, mock.Anything).Return(entity.Order{}, entity.ErrProductNotFound)
+
+	// Act
+	_, response := execute(r, `mutation { createOrder(input: {products: [{productId: "missing", quantity: 1}]}) { id } }`, nil)
+
+	// Assert
+	assert.Len(t, response.Errors, 1)
+	assert.Equal(t, string(entity.ErrCodeProductNotFound), response.Errors[0].Extensions["code"])
+}
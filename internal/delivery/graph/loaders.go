@@ -0,0 +1,110 @@
+package graph
+
+import (
+	"context"
+	"post-tech-challenge-10soat/internal/controllers"
+	entity "post-tech-challenge-10soat/internal/entities"
+
+	"github.com/graph-gophers/dataloader/v7"
+)
+
+// loaders batch the lookups made while resolving a single request, so listing
+// orders costs one query for their items, one for the products and one for
+// the clients, however many orders are returned. They are created per request and never share
+// cached data between callers.
+type loaders struct {
+	product    *dataloader.Loader[string, entity.Product]
+	orderItems *dataloader.Loader[string, []entity.OrderProduct]
+	client     *dataloader.Loader[string, entity.Client]
+}
+
+func newLoaders(productController controllers.ProductController, orderController controllers.OrderController, clientController controllers.ClientController) loaders {
+	return loaders{
+		product:    dataloader.NewBatchedLoader(loadProducts(productController)),
+		orderItems: dataloader.NewBatchedLoader(loadOrderItems(orderController)),
+		client:     dataloader.NewBatchedLoader(loadClients(clientController)),
+	}
+}
+
+func loadProducts(productController controllers.ProductController) dataloader.BatchFunc[string, entity.Product] {
+	return func(ctx context.Context, ids []string) []*dataloader.Result[entity.Product] {
+		results := make([]*dataloader.Result[entity.Product], len(ids))
+		products, err := productController.ListProductsByIds(ctx, ids)
+		if err != nil {
+			for i := range results {
+				results[i] = &dataloader.Result[entity.Product]{Error: err}
+			}
+			return results
+		}
+		productsById := make(map[string]entity.Product, len(products))
+		for _, product := range products {
+			productsById[product.Id] = product
+		}
+		for i, id := range ids {
+			product, ok := productsById[id]
+			if !ok {
+				results[i] = &dataloader.Result[entity.Product]{Error: entity.ErrProductNotFound}
+				continue
+			}
+			results[i] = &dataloader.Result[entity.Product]{Data: product}
+		}
+		return results
+	}
+}
+
+func loadOrderItems(orderController controllers.OrderController) dataloader.BatchFunc[string, []entity.OrderProduct] {
+	return func(ctx context.Context, orderIds []string) []*dataloader.Result[[]entity.OrderProduct] {
+		results := make([]*dataloader.Result[[]entity.OrderProduct], len(orderIds))
+		items, err := orderController.ListOrderItems(ctx, orderIds)
+		if err != nil {
+			for i := range results {
+				results[i] = &dataloader.Result[[]entity.OrderProduct]{Error: err}
+			}
+			return results
+		}
+		itemsByOrderId := make(map[string][]entity.OrderProduct, len(orderIds))
+		for _, item := range items {
+			itemsByOrderId[item.OrderId] = append(itemsByOrderId[item.OrderId], item)
+		}
+		for i, orderId := range orderIds {
+			results[i] = &dataloader.Result[[]entity.OrderProduct]{Data: itemsByOrderId[orderId]}
+		}
+		return results
+	}
+}
+
+func loadClients(clientController controllers.ClientController) dataloader.BatchFunc[string, entity.Client] {
+	return func(ctx context.Context, ids []string) []*dataloader.Result[entity.Client] {
+		results := make([]*dataloader.Result[entity.Client], len(ids))
+		clients, err := clientController.ListClientsByIds(ctx, ids)
+		if err != nil {
+			for i := range results {
+				results[i] = &dataloader.Result[entity.Client]{Error: err}
+			}
+			return results
+		}
+		clientsById := make(map[string]entity.Client, len(clients))
+		for _, client := range clients {
+			clientsById[client.Id] = client
+		}
+		for i, id := range ids {
+			client, ok := clientsById[id]
+			if !ok {
+				results[i] = &dataloader.Result[entity.Client]{Error: entity.ErrClientNotFound}
+				continue
+			}
+			results[i] = &dataloader.Result[entity.Client]{Data: client}
+		}
+		return results
+	}
+}
+
+type loadersContextKey struct{}
+
+func contextWithLoaders(ctx context.Context, l loaders) context.Context {
+	return context.WithValue(ctx, loadersContextKey{}, l)
+}
+
+func loadersFromContext(ctx context.Context) loaders {
+	return ctx.Value(loadersContextKey{}).(loaders)
+}
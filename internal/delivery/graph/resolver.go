@@ -0,0 +1,306 @@
+package graph
+
+import (
+	"context"
+	"post-tech-challenge-10soat/internal/controllers"
+	dto "post-tech-challenge-10soat/internal/dto/order"
+	entity "post-tech-challenge-10soat/internal/entities"
+	"post-tech-challenge-10soat/internal/usecases/order"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+)
+
+// Fields check the same roles and API key scopes as the matching REST routes.
+var (
+	menuRoles    = []entity.Role{entity.RoleCustomer, entity.RoleKiosk, entity.RoleKitchen, entity.RoleAdmin}
+	orderRoles   = []entity.Role{entity.RoleCustomer, entity.RoleKiosk, entity.RoleAdmin}
+	kitchenRoles = []entity.Role{entity.RoleKitchen, entity.RoleAdmin}
+)
+
+type resolver struct {
+	productController controllers.ProductController
+	orderController   controllers.OrderController
+}
+
+type productsArgs struct {
+	CategoryId *graphql.ID
+}
+
+func (r *resolver) Products(ctx context.Context, args productsArgs) ([]*productResolver, error) {
+	if err := entity.Authorize(ctx, entity.ApiKeyScopeCatalogRead, menuRoles); err != nil {
+		return nil, newResolverError(ctx, err)
+	}
+	var categoryId string
+	if args.CategoryId != nil {
+		categoryId = string(*args.CategoryId)
+	}
+	products, err := r.productController.ListProducts(ctx, categoryId)
+	if err != nil {
+		return nil, newResolverError(ctx, err)
+	}
+	resolvers := make([]*productResolver, 0, len(products))
+	for _, product := range products {
+		resolvers = append(resolvers, &productResolver{product})
+	}
+	return resolvers, nil
+}
+
+type ordersArgs struct {
+	Limit int32
+}
+
+func (r *resolver) Orders(ctx context.Context, args ordersArgs) ([]*orderResolver, error) {
+	if err := entity.Authorize(ctx, entity.ApiKeyScopeOrdersManage, kitchenRoles); err != nil {
+		return nil, newResolverError(ctx, err)
+	}
+	if args.Limit < 1 {
+		return nil, newResolverError(ctx, entity.NewDomainError(entity.ErrInvalidData, entity.ErrCodeValidationFailed, "limit must be at least 1"))
+	}
+	orders, err := r.orderController.ListOrders(ctx, uint64(args.Limit))
+	if err != nil {
+		return nil, newResolverError(ctx, err)
+	}
+	resolvers := make([]*orderResolver, 0, len(orders))
+	for _, order := range orders {
+		resolvers = append(resolvers, &orderResolver{order: order})
+	}
+	return resolvers, nil
+}
+
+type createOrderArgs struct {
+	Input struct {
+		ClientId *graphql.ID
+		Products []struct {
+			ProductId   graphql.ID
+			Quantity    int32
+			Observation *string
+		}
+	}
+}
+
+func (r *resolver) CreateOrder(ctx context.Context, args createOrderArgs) (*orderResolver, error) {
+	if err := entity.Authorize(ctx, entity.ApiKeyScopeOrdersWrite, orderRoles); err != nil {
+		return nil, newResolverError(ctx, err)
+	}
+	var createOrderDTO dto.CreateOrderDTO
+	if args.Input.ClientId != nil {
+		createOrderDTO.ClientId = string(*args.Input.ClientId)
+	}
+	for _, product := range args.Input.Products {
+		if product.Quantity < 1 {
+			return nil, newResolverError(ctx, entity.NewDomainError(entity.ErrInvalidData, entity.ErrCodeValidationFailed, "quantity must be at least 1"))
+		}
+		orderProduct := dto.CreateOrderProduct{
+			ProductId: string(product.ProductId),
+			Quantity:  int(product.Quantity),
+		}
+		if product.Observation != nil {
+			orderProduct.Observation = *product.Observation
+		}
+		createOrderDTO.Products = append(createOrderDTO.Products, orderProduct)
+	}
+	order, err := r.orderController.CreateOrder(ctx, createOrderDTO)
+	if err != nil {
+		return nil, newResolverError(ctx, err)
+	}
+	// The items were just inserted, a replica may not have them yet.
+	return &orderResolver{order: order, readYourWrites: true}, nil
+}
+
+type updateOrderStatusArgs struct {
+	Id      graphql.ID
+	Status  string
+	Version int32
+}
+
+func (r *resolver) UpdateOrderStatus(ctx context.Context, args updateOrderStatusArgs) (*orderResolver, error) {
+	if err := entity.Authorize(ctx, entity.ApiKeyScopeOrdersManage, kitchenRoles); err != nil {
+		return nil, newResolverError(ctx, err)
+	}
+	order, err := r.orderController.UpdateOrderStatus(ctx, string(args.Id), args.Status, int(args.Version))
+	if err != nil {
+		return nil, newResolverError(ctx, err)
+	}
+	return &orderResolver{order: order}, nil
+}
+
+type categoryResolver struct {
+	category entity.Category
+}
+
+func (r *categoryResolver) Id() graphql.ID {
+	return graphql.ID(r.category.Id)
+}
+
+func (r *categoryResolver) Name() string {
+	return r.category.Name
+}
+
+type productResolver struct {
+	product entity.Product
+}
+
+func (r *productResolver) Id() graphql.ID {
+	return graphql.ID(r.product.Id)
+}
+
+func (r *productResolver) Name() string {
+	return r.product.Name
+}
+
+func (r *productResolver) Description() string {
+	return r.product.Description
+}
+
+func (r *productResolver) Image() string {
+	return r.product.Image
+}
+
+func (r *productResolver) Value() float64 {
+	return r.product.Value
+}
+
+func (r *productResolver) Version() int32 {
+	return int32(r.product.Version)
+}
+
+func (r *productResolver) Category() *categoryResolver {
+	return &categoryResolver{r.product.Category}
+}
+
+type orderResolver struct {
+	order          entity.Order
+	readYourWrites bool
+}
+
+func (r *orderResolver) Id() graphql.ID {
+	return graphql.ID(r.order.Id)
+}
+
+func (r *orderResolver) Number() int32 {
+	return int32(r.order.Number)
+}
+
+func (r *orderResolver) Status() string {
+	return string(r.order.Status)
+}
+
+func (r *orderResolver) ClientId() *string {
+	if r.order.ClientId == "" {
+		return nil
+	}
+	return &r.order.ClientId
+}
+
+func (r *orderResolver) Client(ctx context.Context) (*clientResolver, error) {
+	if r.order.ClientId == "" {
+		return nil, nil
+	}
+	client, err := loadersFromContext(ctx).client.Load(ctx, r.order.ClientId)()
+	if err != nil {
+		return nil, newResolverError(ctx, err)
+	}
+	return &clientResolver{client}, nil
+}
+
+// PaymentStatus comes from the order already loaded, rather than fetching
+// it again for every order of a list.
+func (r *orderResolver) PaymentStatus() string {
+	return string(order.PaymentStatusOf(r.order))
+}
+
+func (r *orderResolver) Total() float64 {
+	return r.order.Total
+}
+
+func (r *orderResolver) Version() int32 {
+	return int32(r.order.Version)
+}
+
+func (r *orderResolver) CreatedAt() string {
+	return r.order.CreatedAt.Format(time.RFC3339)
+}
+
+func (r *orderResolver) UpdatedAt() string {
+	return r.order.UpdatedAt.Format(time.RFC3339)
+}
+
+func (r *orderResolver) Items(ctx context.Context) ([]*orderItemResolver, error) {
+	if r.readYourWrites {
+		ctx = entity.ContextWithReadYourWrites(ctx)
+	}
+	items, err := loadersFromContext(ctx).orderItems.Load(ctx, r.order.Id)()
+	if err != nil {
+		return nil, newResolverError(ctx, err)
+	}
+	resolvers := make([]*orderItemResolver, 0, len(items))
+	for _, item := range items {
+		resolvers = append(resolvers, &orderItemResolver{item})
+	}
+	return resolvers, nil
+}
+
+type clientResolver struct {
+	client entity.Client
+}
+
+func (r *clientResolver) Id() graphql.ID {
+	return graphql.ID(r.client.Id)
+}
+
+func (r *clientResolver) Name() string {
+	return r.client.Name
+}
+
+func (r *clientResolver) Cpf(ctx context.Context) *string {
+	if !canSeeClientContact(ctx, r.client.Id) {
+		return nil
+	}
+	return &r.client.Cpf
+}
+
+func (r *clientResolver) Email(ctx context.Context) *string {
+	if !canSeeClientContact(ctx, r.client.Id) {
+		return nil
+	}
+	return &r.client.Email
+}
+
+// canSeeClientContact lets the client itself and admins see the personal
+// data, while the kitchen only gets the name to call the order.
+func canSeeClientContact(ctx context.Context, clientId string) bool {
+	session, ok := entity.SessionFromContext(ctx)
+	if !ok {
+		return false
+	}
+	return session.Role == entity.RoleAdmin || (session.Role == entity.RoleCustomer && session.Subject == clientId)
+}
+
+type orderItemResolver struct {
+	item entity.OrderProduct
+}
+
+func (r *orderItemResolver) Id() graphql.ID {
+	return graphql.ID(r.item.Id)
+}
+
+func (r *orderItemResolver) Product(ctx context.Context) (*productResolver, error) {
+	product, err := loadersFromContext(ctx).product.Load(ctx, r.item.ProductId)()
+	if err != nil {
+		return nil, newResolverError(ctx, err)
+	}
+	return &productResolver{product}, nil
+}
+
+func (r *orderItemResolver) Quantity() int32 {
+	return int32(r.item.Quantity)
+}
+
+func (r *orderItemResolver) SubTotal() float64 {
+	return r.item.SubTotal
+}
+
+func (r *orderItemResolver) Observation() string {
+	return r.item.Observation
+}
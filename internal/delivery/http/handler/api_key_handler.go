@@ -0,0 +1,139 @@
+package handler
+
+import (
+	"post-tech-challenge-10soat/internal/controllers"
+	cm "post-tech-challenge-10soat/internal/delivery/http/mapper"
+	dto "post-tech-challenge-10soat/internal/dto/apikey"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ApiKeyHandler struct {
+	apiKeyController controllers.ApiKeyController
+}
+
+func NewApiKeyHandler(apiKeyController controllers.ApiKeyController) ApiKeyHandler {
+	return ApiKeyHandler{
+		apiKeyController: apiKeyController,
+	}
+}
+
+type createApiKeyRequest struct {
+	Name      string    `json:"name" binding:"required" example:"totem-loja-01"`
+	Scopes    []string  `json:"scopes" binding:"required,min=1,dive,oneof=catalog:read catalog:write clients:write orders:write orders:manage" example:"catalog:read,orders:write"`
+	ExpiresAt time.Time `json:"expires_at" example:"2030-01-01T00:00:00Z"`
+}
+
+// CreateApiKey godoc
+//
+//	@Summary     Cria uma chave de API
+//	@Description Cria uma chave de API com escopos para totens e integrações. A chave só é exibida nesta resposta
+//	@Tags        ApiKeys
+//	@Accept      json
+//	@Produce		json
+//	@Param	    createApiKeyRequest	body createApiKeyRequest true "Criar chave request"
+//	@Success		200	{object} cm.ApiKeyResponse	"Chave criada"
+//	@Failure		400	{object} Problem	"Erro de validação"
+//	@Failure		401	{object} Problem	"Token ausente ou inválido"
+//	@Failure		403	{object} Problem	"Perfil sem permissão"
+//	@Router		/api-keys [post]
+//	@Security	BearerAuth
+func (h *ApiKeyHandler) CreateApiKey(ctx *gin.Context) {
+	var request createApiKeyRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		validationError(ctx, err)
+		return
+	}
+	apiKey, err := h.apiKeyController.CreateApiKey(ctx, dto.CreateApiKeyDTO{
+		Name:      request.Name,
+		Scopes:    request.Scopes,
+		ExpiresAt: request.ExpiresAt,
+	})
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	response := cm.NewApiKeyResponse(apiKey)
+	handleSuccess(ctx, response)
+}
+
+// ListApiKeys godoc
+//
+//	@Summary     Lista as chaves de API
+//	@Description Lista as chaves de API com escopos, validade e último uso
+//	@Tags        ApiKeys
+//	@Produce		json
+//	@Success		200	{array}  cm.ApiKeyResponse	"Chaves"
+//	@Failure		401	{object} Problem	"Token ausente ou inválido"
+//	@Failure		403	{object} Problem	"Perfil sem permissão"
+//	@Router		/api-keys [get]
+//	@Security	BearerAuth
+func (h *ApiKeyHandler) ListApiKeys(ctx *gin.Context) {
+	apiKeys, err := h.apiKeyController.ListApiKeys(ctx)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	response := cm.NewApiKeysResponse(apiKeys)
+	handleSuccess(ctx, response)
+}
+
+type apiKeyIdRequest struct {
+	Id string `uri:"id" binding:"required,uuid" example:"ed6ac028-8016-4cbd-aeee-c3a155cdb2a4"`
+}
+
+// RotateApiKey godoc
+//
+//	@Summary     Rotaciona uma chave de API
+//	@Description Gera uma nova chave com os mesmos escopos e validade e revoga a atual. A nova chave só é exibida nesta resposta
+//	@Tags        ApiKeys
+//	@Produce		json
+//	@Param	    id	path		string	true	"ID da chave"
+//	@Success		200	{object} cm.ApiKeyResponse	"Nova chave"
+//	@Failure		400	{object} Problem	"Erro de validação"
+//	@Failure		404	{object} Problem	"Chave nao encontrada"
+//	@Failure		409	{object} Problem	"Chave já revogada"
+//	@Router		/api-keys/{id}/rotate [post]
+//	@Security	BearerAuth
+func (h *ApiKeyHandler) RotateApiKey(ctx *gin.Context) {
+	var request apiKeyIdRequest
+	if err := ctx.ShouldBindUri(&request); err != nil {
+		validationError(ctx, err)
+		return
+	}
+	apiKey, err := h.apiKeyController.RotateApiKey(ctx, request.Id)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	response := cm.NewApiKeyResponse(apiKey)
+	handleSuccess(ctx, response)
+}
+
+// RevokeApiKey godoc
+//
+//	@Summary     Revoga uma chave de API
+//	@Description Revoga a chave de API, que deixa de ser aceita imediatamente
+//	@Tags        ApiKeys
+//	@Produce		json
+//	@Param	    id	path		string	true	"ID da chave"
+//	@Success		200	{object} cm.ApiKeyResponse	"Chave revogada"
+//	@Failure		400	{object} Problem	"Erro de validação"
+//	@Failure		404	{object} Problem	"Chave nao encontrada ou já revogada"
+//	@Router		/api-keys/{id} [delete]
+//	@Security	BearerAuth
+func (h *ApiKeyHandler) RevokeApiKey(ctx *gin.Context) {
+	var request apiKeyIdRequest
+	if err := ctx.ShouldBindUri(&request); err != nil {
+		validationError(ctx, err)
+		return
+	}
+	apiKey, err := h.apiKeyController.RevokeApiKey(ctx, request.Id)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	response := cm.NewApiKeyResponse(apiKey)
+	handleSuccess(ctx, response)
+}
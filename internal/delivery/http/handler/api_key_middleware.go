@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"post-tech-challenge-10soat/internal/controllers"
+	entity "post-tech-challenge-10soat/internal/entities"
+
+	"github.com/gin-gonic/gin"
+)
+
+const apiKeyHeader = "X-API-Key"
+
+type ApiKeyMiddleware struct {
+	apiKeyController controllers.ApiKeyController
+}
+
+func NewApiKeyMiddleware(apiKeyController controllers.ApiKeyController) ApiKeyMiddleware {
+	return ApiKeyMiddleware{
+		apiKeyController,
+	}
+}
+
+// Authenticate resolves an optional X-API-Key header into the device stored in
+// the request context. Invalid, revoked or expired keys are rejected.
+func (m *ApiKeyMiddleware) Authenticate(ctx *gin.Context) {
+	key := ctx.GetHeader(apiKeyHeader)
+	if key == "" {
+		ctx.Next()
+		return
+	}
+	device, err := m.apiKeyController.AuthenticateApiKey(ctx, key)
+	if err != nil {
+		handleError(ctx, err)
+		ctx.Abort()
+		return
+	}
+	ctx.Request = ctx.Request.WithContext(entity.ContextWithDevice(ctx.Request.Context(), device))
+	ctx.Next()
+}
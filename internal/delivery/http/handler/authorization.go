@@ -0,0 +1,30 @@
+package handler
+
+import (
+	entity "post-tech-challenge-10soat/internal/entities"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequireRoles only lets requests through when the session holds one of the
+// roles, answering 401 without a session and 403 with any other role.
+func RequireRoles(roles ...entity.Role) gin.HandlerFunc {
+	return authorize("", roles)
+}
+
+// RequireScopeOrRoles also accepts requests made with an API key that holds
+// the scope, for routes called by devices and integrations.
+func RequireScopeOrRoles(scope entity.ApiKeyScope, roles ...entity.Role) gin.HandlerFunc {
+	return authorize(scope, roles)
+}
+
+func authorize(scope entity.ApiKeyScope, roles []entity.Role) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if err := entity.Authorize(ctx.Request.Context(), scope, roles); err != nil {
+			handleError(ctx, err)
+			ctx.Abort()
+			return
+		}
+		ctx.Next()
+	}
+}
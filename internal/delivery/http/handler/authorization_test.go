@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	entity "post-tech-challenge-10soat/internal/entities"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupAuthorizationTestRouter(credentials func(ctx *gin.Context), authorization gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.ContextWithFallback = true
+	r.PATCH("/orders/:id/status", credentials, authorization, func(ctx *gin.Context) {
+		ctx.Status(http.StatusOK)
+	})
+	return r
+}
+
+func withSession(role entity.Role) func(ctx *gin.Context) {
+	return func(ctx *gin.Context) {
+		ctx.Request = ctx.Request.WithContext(entity.ContextWithSession(ctx.Request.Context(), entity.Session{Subject: "user-1", Role: role}))
+	}
+}
+
+func withDevice(scopes ...entity.ApiKeyScope) func(ctx *gin.Context) {
+	return func(ctx *gin.Context) {
+		ctx.Request = ctx.Request.WithContext(entity.ContextWithDevice(ctx.Request.Context(), entity.Device{ApiKeyId: "key-1", Scopes: scopes}))
+	}
+}
+
+func TestRequireScopeOrRoles(t *testing.T) {
+	authorization := RequireScopeOrRoles(entity.ApiKeyScopeOrdersManage, entity.RoleKitchen, entity.RoleAdmin)
+
+	tests := []struct {
+		name           string
+		credentials    func(ctx *gin.Context)
+		expectedStatus int
+	}{
+		{name: "anonymous", credentials: func(ctx *gin.Context) {}, expectedStatus: http.StatusUnauthorized},
+		{name: "allowed role", credentials: withSession(entity.RoleKitchen), expectedStatus: http.StatusOK},
+		{name: "forbidden role", credentials: withSession(entity.RoleKiosk), expectedStatus: http.StatusForbidden},
+		{name: "api key with scope", credentials: withDevice(entity.ApiKeyScopeOrdersManage), expectedStatus: http.StatusOK},
+		{name: "api key without scope", credentials: withDevice(entity.ApiKeyScopeCatalogRead), expectedStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := setupAuthorizationTestRouter(tt.credentials, authorization)
+			req, _ := http.NewRequest("PATCH", "/orders/1/status", nil)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+func TestRequireRoles_RejectsApiKeys(t *testing.T) {
+	r := setupAuthorizationTestRouter(withDevice(entity.ApiKeyScopeOrdersManage), RequireRoles(entity.RoleAdmin))
+	req, _ := http.NewRequest("PATCH", "/orders/1/status", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
@@ -4,24 +4,36 @@ import (
 	"post-tech-challenge-10soat/internal/controllers"
 	cm "post-tech-challenge-10soat/internal/delivery/http/mapper"
 	dto "post-tech-challenge-10soat/internal/dto/client"
+	consentdto "post-tech-challenge-10soat/internal/dto/consent"
 
 	"github.com/gin-gonic/gin"
 )
 
 type ClientHandler struct {
 	clientController controllers.ClientController
+	// identification is false when identification codes cannot be sent,
+	// and their routes are left out.
+	identification bool
 }
 
-func NewClientHandler(clientController controllers.ClientController) ClientHandler {
+func NewClientHandler(clientController controllers.ClientController, identification bool) ClientHandler {
 	return ClientHandler{
 		clientController: clientController,
+		identification:   identification,
 	}
 }
 
+// IdentificationEnabled tells whether the identification code routes are
+// served.
+func (h *ClientHandler) IdentificationEnabled() bool {
+	return h.identification
+}
+
 type createClientRequest struct {
-	Cpf   string `json:"cpf" binding:"required" example:"12345678010"`
-	Name  string `json:"name" binding:"required" example:"John Doe"`
-	Email string `json:"email" binding:"required" example:"john-doe@email.com"`
+	Cpf      string `json:"cpf" binding:"required" example:"12345678010"`
+	Name     string `json:"name" binding:"required" example:"John Doe"`
+	Email    string `json:"email" binding:"required" example:"john-doe@email.com"`
+	Language string `json:"language" binding:"omitempty,oneof=pt-BR en es" example:"pt-BR"`
 }
 
 // CreateClient godoc
@@ -33,8 +45,9 @@ type createClientRequest struct {
 //	@Produce		json
 //	@Param	    createClientRequest	body createClientRequest true "Registrar novo cliente request"
 //	@Success		200	{object} cm.ClientResponse	"Cliente registrado"
-//	@Failure		400	{object} ErrorResponse	"Erro de validação"
+//	@Failure		400	{object} Problem	"Erro de validação"
 //	@Router		/clients [post]
+//	@Security	BearerAuth
 func (h *ClientHandler) CreateClient(ctx *gin.Context) {
 	var request createClientRequest
 	if err := ctx.ShouldBindJSON(&request); err != nil {
@@ -42,9 +55,10 @@ func (h *ClientHandler) CreateClient(ctx *gin.Context) {
 		return
 	}
 	newClient := dto.CreateClientDTO{
-		Cpf:   request.Cpf,
-		Name:  request.Name,
-		Email: request.Email,
+		Cpf:      request.Cpf,
+		Name:     request.Name,
+		Email:    request.Email,
+		Language: request.Language,
 	}
 	createdClient, err := h.clientController.CreateClient(ctx, newClient)
 	if err != nil {
@@ -68,9 +82,10 @@ type getClientByCpfRequest struct {
 //	    @Produce		json
 //		   @Param	    cpf	path		string				true	"CPF"
 //	    @Success		200	{object}    cm.ClientResponse	"Cliente"
-//	    @Failure		400	{object}    ErrorResponse	"Erro de validação"
-//		   @Failure		404	{object}	ErrorResponse   "Cliente nao encontrado"
+//	    @Failure		400	{object}    Problem	"Erro de validação"
+//		   @Failure		404	{object}	Problem   "Cliente nao encontrado"
 //	    @Router		/clients/{cpf} [get]
+//	    @Security	BearerAuth
 func (h *ClientHandler) GetClientByCpf(ctx *gin.Context) {
 	var request getClientByCpfRequest
 	if err := ctx.ShouldBindUri(&request); err != nil {
@@ -85,3 +100,173 @@ func (h *ClientHandler) GetClientByCpf(ctx *gin.Context) {
 	response := cm.NewClientResponse(c)
 	handleSuccess(ctx, response)
 }
+
+type requestIdentificationCodeRequest struct {
+	Cpf string `json:"cpf" binding:"required,min=1" example:"12345678010"`
+}
+
+// RequestIdentificationCode godoc
+//
+//	@Summary     Solicita um código de identificação
+//	@Description Envia um código de uso único para o e-mail cadastrado do cliente com o CPF informado
+//	@Tags        Clients
+//	@Accept      json
+//	@Produce		json
+//	@Param	    requestIdentificationCodeRequest	body requestIdentificationCodeRequest true "Solicitar código request"
+//	@Success		200	{object} cm.ClientIdentificationResponse	"Código enviado"
+//	@Failure		400	{object} Problem	"Erro de validação"
+//	@Failure		404	{object} Problem	"Cliente nao encontrado"
+//	@Failure		429	{object} Problem	"Novo código solicitado antes do intervalo mínimo"
+//	@Router		/clients/identification [post]
+//	@Security	BearerAuth
+func (h *ClientHandler) RequestIdentificationCode(ctx *gin.Context) {
+	var request requestIdentificationCodeRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		validationError(ctx, err)
+		return
+	}
+	identification, err := h.clientController.RequestIdentificationCode(ctx, request.Cpf)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	response := cm.NewClientIdentificationResponse(identification)
+	handleSuccess(ctx, response)
+}
+
+type verifyIdentificationCodeRequest struct {
+	IdentificationId string `json:"identification_id" binding:"required,uuid" example:"ed6ac028-8016-4cbd-aeee-c3a155cdb2a4"`
+	Code             string `json:"code" binding:"required,len=6,numeric" example:"123456"`
+}
+
+// VerifyIdentificationCode godoc
+//
+//	@Summary     Valida o código de identificação
+//	@Description Valida o código de uso único e retorna um token de sessão de curta duração do cliente
+//	@Tags        Clients
+//	@Accept      json
+//	@Produce		json
+//	@Param	    verifyIdentificationCodeRequest	body verifyIdentificationCodeRequest true "Validar código request"
+//	@Success		200	{object} cm.ClientSessionResponse	"Sessão do cliente"
+//	@Failure		400	{object} Problem	"Erro de validação"
+//	@Failure		401	{object} Problem	"Código inválido ou expirado"
+//	@Failure		403	{object} Problem	"Tentativas esgotadas"
+//	@Router		/clients/identification/verify [post]
+//	@Security	BearerAuth
+func (h *ClientHandler) VerifyIdentificationCode(ctx *gin.Context) {
+	var request verifyIdentificationCodeRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		validationError(ctx, err)
+		return
+	}
+	token, err := h.clientController.VerifyIdentificationCode(ctx, request.IdentificationId, request.Code)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	response := cm.NewClientSessionResponse(token)
+	handleSuccess(ctx, response)
+}
+
+// ListConsents godoc
+//
+//	@Summary     Lista os consentimentos do cliente
+//	@Description Lista a situação atual de cada consentimento do cliente identificado pelo token de sessão
+//	@Tags        Clients
+//	@Produce		json
+//	@Security    BearerAuth
+//	@Success		200	{array}  cm.ClientConsentResponse	"Consentimentos"
+//	@Failure		401	{object} Problem	"Sessão do cliente ausente"
+//	@Router		/clients/me/consents [get]
+func (h *ClientHandler) ListConsents(ctx *gin.Context) {
+	consents, err := h.clientController.ListConsents(ctx)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	response := cm.NewClientConsentsResponse(consents)
+	handleSuccess(ctx, response)
+}
+
+type updateConsentUri struct {
+	Purpose string `uri:"purpose" binding:"required,oneof=marketing_email order_notifications" example:"marketing_email"`
+}
+
+type updateConsentRequest struct {
+	Source        string `json:"source" binding:"required" example:"kiosk"`
+	PolicyVersion string `json:"policy_version" binding:"required" example:"2024-01"`
+}
+
+func bindUpdateConsent(ctx *gin.Context) (consentdto.UpdateClientConsentDTO, bool) {
+	var uri updateConsentUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		validationError(ctx, err)
+		return consentdto.UpdateClientConsentDTO{}, false
+	}
+	var request updateConsentRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		validationError(ctx, err)
+		return consentdto.UpdateClientConsentDTO{}, false
+	}
+	return consentdto.UpdateClientConsentDTO{
+		Purpose:       uri.Purpose,
+		Source:        request.Source,
+		PolicyVersion: request.PolicyVersion,
+	}, true
+}
+
+// GrantConsent godoc
+//
+//	@Summary     Concede um consentimento
+//	@Description Registra o consentimento do cliente para a finalidade informada, com origem e versão da política de privacidade
+//	@Tags        Clients
+//	@Accept      json
+//	@Produce		json
+//	@Security    BearerAuth
+//	@Param	    purpose	path	string	true	"Finalidade"	Enums(marketing_email, order_notifications)
+//	@Param	    updateConsentRequest	body updateConsentRequest true "Consentimento request"
+//	@Success		200	{object} cm.ClientConsentResponse	"Consentimento registrado"
+//	@Failure		400	{object} Problem	"Erro de validação"
+//	@Failure		401	{object} Problem	"Sessão do cliente ausente"
+//	@Router		/clients/me/consents/{purpose} [put]
+func (h *ClientHandler) GrantConsent(ctx *gin.Context) {
+	consent, ok := bindUpdateConsent(ctx)
+	if !ok {
+		return
+	}
+	granted, err := h.clientController.GrantConsent(ctx, consent)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	response := cm.NewClientConsentResponse(granted)
+	handleSuccess(ctx, response)
+}
+
+// RevokeConsent godoc
+//
+//	@Summary     Revoga um consentimento
+//	@Description Registra a revogação do consentimento do cliente para a finalidade informada
+//	@Tags        Clients
+//	@Accept      json
+//	@Produce		json
+//	@Security    BearerAuth
+//	@Param	    purpose	path	string	true	"Finalidade"	Enums(marketing_email, order_notifications)
+//	@Param	    updateConsentRequest	body updateConsentRequest true "Consentimento request"
+//	@Success		200	{object} cm.ClientConsentResponse	"Revogação registrada"
+//	@Failure		400	{object} Problem	"Erro de validação"
+//	@Failure		401	{object} Problem	"Sessão do cliente ausente"
+//	@Router		/clients/me/consents/{purpose} [delete]
+func (h *ClientHandler) RevokeConsent(ctx *gin.Context) {
+	consent, ok := bindUpdateConsent(ctx)
+	if !ok {
+		return
+	}
+	revoked, err := h.clientController.RevokeConsent(ctx, consent)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	response := cm.NewClientConsentResponse(revoked)
+	handleSuccess(ctx, response)
+}
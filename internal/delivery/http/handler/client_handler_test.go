@@ -4,12 +4,15 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"post-tech-challenge-10soat/internal/controllers"
 	dto "post-tech-challenge-10soat/internal/dto/client"
+	consentdto "post-tech-challenge-10soat/internal/dto/consent"
 	entity "post-tech-challenge-10soat/internal/entities"
 
 	"github.com/gin-gonic/gin"
@@ -39,11 +42,46 @@ func (m *MockClientController) GetClientById(ctx context.Context, id string) (en
 	return args.Get(0).(entity.Client), args.Error(1)
 }
 
+func (m *MockClientController) ListClientsByIds(ctx context.Context, ids []string) ([]entity.Client, error) {
+	args := m.Called(ctx, ids)
+	return args.Get(0).([]entity.Client), args.Error(1)
+}
+
+func (m *MockClientController) RequestIdentificationCode(ctx context.Context, cpf string) (entity.ClientIdentification, error) {
+	args := m.Called(ctx, cpf)
+	return args.Get(0).(entity.ClientIdentification), args.Error(1)
+}
+
+func (m *MockClientController) VerifyIdentificationCode(ctx context.Context, identificationId string, code string) (entity.Token, error) {
+	args := m.Called(ctx, identificationId, code)
+	return args.Get(0).(entity.Token), args.Error(1)
+}
+
+func (m *MockClientController) ListConsents(ctx context.Context) ([]entity.ClientConsent, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]entity.ClientConsent), args.Error(1)
+}
+
+func (m *MockClientController) GrantConsent(ctx context.Context, consent consentdto.UpdateClientConsentDTO) (entity.ClientConsent, error) {
+	args := m.Called(ctx, consent)
+	return args.Get(0).(entity.ClientConsent), args.Error(1)
+}
+
+func (m *MockClientController) RevokeConsent(ctx context.Context, consent consentdto.UpdateClientConsentDTO) (entity.ClientConsent, error) {
+	args := m.Called(ctx, consent)
+	return args.Get(0).(entity.ClientConsent), args.Error(1)
+}
+
 func setupTestRouter(handler *ClientHandler) *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	r := gin.Default()
 	r.POST("/clients", handler.CreateClient)
 	r.GET("/clients/:cpf", handler.GetClientByCpf)
+	r.POST("/clients/identification", handler.RequestIdentificationCode)
+	r.POST("/clients/identification/verify", handler.VerifyIdentificationCode)
+	r.GET("/clients/me/consents", handler.ListConsents)
+	r.PUT("/clients/me/consents/:purpose", handler.GrantConsent)
+	r.DELETE("/clients/me/consents/:purpose", handler.RevokeConsent)
 	return r
 }
 
@@ -150,3 +188,181 @@ func TestClientHandler_GetClientByCpf_Success(t *testing.T) {
 	// Verify mock was called
 	mockCtrl.AssertExpectations(t)
 }
+
+func TestClientHandler_RequestIdentificationCode_Success(t *testing.T) {
+	// Setup
+	mockCtrl := &MockClientController{}
+	handler := &ClientHandler{
+		clientController: mockCtrl,
+	}
+	r := setupTestRouter(handler)
+
+	// Mock expectations
+	expectedIdentification := entity.ClientIdentification{
+		Id:          "ed6ac028-8016-4cbd-aeee-c3a155cdb2a4",
+		Destination: "t***@example.com",
+		ExpiresAt:   time.Now().Add(5 * time.Minute),
+	}
+
+	mockCtrl.On("RequestIdentificationCode", mock.Anything, "12345678901").
+		Return(expectedIdentification, nil)
+
+	// Test request
+	jsonValue, _ := json.Marshal(map[string]string{"cpf": "12345678901"})
+	req, _ := http.NewRequest("POST", "/clients/identification", bytes.NewBuffer(jsonValue))
+	req.Header.Set("Content-Type", "application/json")
+
+	// Act
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Data struct {
+			ID          string `json:"identification_id"`
+			Destination string `json:"destination"`
+		} `json:"data"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedIdentification.Id, response.Data.ID)
+	assert.Equal(t, "t***@example.com", response.Data.Destination)
+
+	mockCtrl.AssertExpectations(t)
+}
+
+func TestClientHandler_VerifyIdentificationCode_Success(t *testing.T) {
+	// Setup
+	mockCtrl := &MockClientController{}
+	handler := &ClientHandler{
+		clientController: mockCtrl,
+	}
+	r := setupTestRouter(handler)
+
+	identificationId := "ed6ac028-8016-4cbd-aeee-c3a155cdb2a4"
+	mockCtrl.On("VerifyIdentificationCode", mock.Anything, identificationId, "123456").
+		Return(entity.Token{AccessToken: "token", ExpiresAt: time.Now().Add(15 * time.Minute)}, nil)
+
+	// Test request
+	jsonValue, _ := json.Marshal(map[string]string{"identification_id": identificationId, "code": "123456"})
+	req, _ := http.NewRequest("POST", "/clients/identification/verify", bytes.NewBuffer(jsonValue))
+	req.Header.Set("Content-Type", "application/json")
+
+	// Act
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Data struct {
+			AccessToken string `json:"access_token"`
+			TokenType   string `json:"token_type"`
+		} `json:"data"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "token", response.Data.AccessToken)
+	assert.Equal(t, "Bearer", response.Data.TokenType)
+
+	mockCtrl.AssertExpectations(t)
+}
+
+func TestClientHandler_VerifyIdentificationCode_InvalidCode(t *testing.T) {
+	// Setup
+	mockCtrl := &MockClientController{}
+	handler := &ClientHandler{
+		clientController: mockCtrl,
+	}
+	r := setupTestRouter(handler)
+
+	identificationId := "ed6ac028-8016-4cbd-aeee-c3a155cdb2a4"
+	mockCtrl.On("VerifyIdentificationCode", mock.Anything, identificationId, "000000").
+		Return(entity.Token{}, fmt.Errorf("%w: invalid code", entity.ErrUnauthorized))
+
+	// Test request
+	jsonValue, _ := json.Marshal(map[string]string{"identification_id": identificationId, "code": "000000"})
+	req, _ := http.NewRequest("POST", "/clients/identification/verify", bytes.NewBuffer(jsonValue))
+	req.Header.Set("Content-Type", "application/json")
+
+	// Act
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	mockCtrl.AssertExpectations(t)
+}
+
+func TestClientHandler_GrantConsent_Success(t *testing.T) {
+	// Setup
+	mockCtrl := &MockClientController{}
+	handler := &ClientHandler{
+		clientController: mockCtrl,
+	}
+	r := setupTestRouter(handler)
+
+	expectedConsent := consentdto.UpdateClientConsentDTO{
+		Purpose:       "marketing_email",
+		Source:        "kiosk",
+		PolicyVersion: "2024-01",
+	}
+	mockCtrl.On("GrantConsent", mock.Anything, expectedConsent).
+		Return(entity.ClientConsent{
+			Purpose:       entity.ConsentPurposeMarketingEmail,
+			Granted:       true,
+			Source:        "kiosk",
+			PolicyVersion: "2024-01",
+			CreatedAt:     time.Now(),
+		}, nil)
+
+	// Test request
+	jsonValue, _ := json.Marshal(map[string]string{"source": "kiosk", "policy_version": "2024-01"})
+	req, _ := http.NewRequest("PUT", "/clients/me/consents/marketing_email", bytes.NewBuffer(jsonValue))
+	req.Header.Set("Content-Type", "application/json")
+
+	// Act
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Data struct {
+			Purpose string `json:"purpose"`
+			Granted bool   `json:"granted"`
+		} `json:"data"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "marketing_email", response.Data.Purpose)
+	assert.True(t, response.Data.Granted)
+
+	mockCtrl.AssertExpectations(t)
+}
+
+func TestClientHandler_RevokeConsent_UnknownPurpose(t *testing.T) {
+	// Setup
+	mockCtrl := &MockClientController{}
+	handler := &ClientHandler{
+		clientController: mockCtrl,
+	}
+	r := setupTestRouter(handler)
+
+	// Test request
+	jsonValue, _ := json.Marshal(map[string]string{"source": "kiosk", "policy_version": "2024-01"})
+	req, _ := http.NewRequest("DELETE", "/clients/me/consents/sms", bytes.NewBuffer(jsonValue))
+	req.Header.Set("Content-Type", "application/json")
+
+	// Act
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockCtrl.AssertNotCalled(t, "RevokeConsent", mock.Anything, mock.Anything)
+}
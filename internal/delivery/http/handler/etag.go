@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"fmt"
+	entity "post-tech-challenge-10soat/internal/entities"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Products and orders carry a version that is bumped on every update. It is
+// exposed as a strong ETag, and updates must send it back in If-Match so two
+// clients never overwrite each other without noticing.
+
+func setETag(ctx *gin.Context, version int) {
+	ctx.Header("ETag", strconv.Quote(strconv.Itoa(version)))
+}
+
+// ifMatchVersion reads the version the client based its change on. A missing
+// header answers 428 and anything other than a single ETag from setETag 400.
+func ifMatchVersion(ctx *gin.Context) (int, error) {
+	header := strings.TrimSpace(ctx.GetHeader("If-Match"))
+	if header == "" {
+		return 0, fmt.Errorf("%w: send the ETag of the resource in the If-Match header", entity.ErrPreconditionRequired)
+	}
+	value, err := strconv.Unquote(header)
+	if err != nil {
+		return 0, fmt.Errorf("%w: If-Match must be a single ETag", entity.ErrInvalidData)
+	}
+	version, err := strconv.Atoi(value)
+	if err != nil || version < 1 {
+		return 0, fmt.Errorf("%w: If-Match does not match any version", entity.ErrPreconditionFailed)
+	}
+	return version, nil
+}
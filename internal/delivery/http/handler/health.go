@@ -2,19 +2,49 @@ package handler
 
 import (
 	"net/http"
+	"post-tech-challenge-10soat/internal/controllers"
+	hm "post-tech-challenge-10soat/internal/delivery/http/mapper"
+	entity "post-tech-challenge-10soat/internal/entities"
 
 	"github.com/gin-gonic/gin"
 )
 
 type HealthHandler struct {
+	healthController controllers.HealthController
 }
 
-func NewHealthHandler() HealthHandler {
-	return HealthHandler{}
+func NewHealthHandler(healthController controllers.HealthController) HealthHandler {
+	return HealthHandler{
+		healthController,
+	}
 }
 
-func (handler *HealthHandler) HealthCheck(ctx *gin.Context) {
-	ctx.JSON(http.StatusOK, gin.H{
-		"message": "Application is healthy",
-	})
+// Live godoc
+//
+//	@Summary     Liveness probe
+//	@Description Responde enquanto o processo está de pé, sem consultar dependências
+//	@Tags        Health
+//	@Produce		json
+//	@Success		200	{object}  hm.HealthResponse	"Processo de pé"
+//	@Router		/health/live [get]
+func (handler *HealthHandler) Live(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, hm.HealthResponse{Status: entity.HealthStatusUp})
+}
+
+// Ready godoc
+//
+//	@Summary     Readiness probe
+//	@Description Consulta o Postgres, incluindo a versão das migrations, e o Mongo, com o status e a latência de cada um. Sem o Mongo a instância fica degraded, mas continua recebendo tráfego
+//	@Tags        Health
+//	@Produce		json
+//	@Success		200	{object}  hm.HealthResponse	"Pronto para receber tráfego, mesmo degraded"
+//	@Failure		503	{object}  hm.HealthResponse	"Alguma dependência obrigatória fora do ar"
+//	@Router		/health/ready [get]
+func (handler *HealthHandler) Ready(ctx *gin.Context) {
+	report := handler.healthController.CheckReadiness(ctx)
+	statusCode := http.StatusOK
+	if !report.IsReady() {
+		statusCode = http.StatusServiceUnavailable
+	}
+	ctx.JSON(statusCode, hm.NewHealthResponse(report))
 }
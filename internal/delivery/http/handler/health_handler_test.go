@@ -1,39 +1,123 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
+
+	"post-tech-challenge-10soat/internal/controllers"
+	entity "post-tech-challenge-10soat/internal/entities"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
+type MockCheckReadinessUseCase struct {
+	mock.Mock
+}
+
+func (m *MockCheckReadinessUseCase) Execute(ctx context.Context) entity.HealthReport {
+	args := m.Called(ctx)
+	return args.Get(0).(entity.HealthReport)
+}
+
 func setupHealthTestRouter(handler *HealthHandler) *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	r := gin.Default()
-	r.GET("/health", handler.HealthCheck)
+	r.GET("/health/live", handler.Live)
+	r.GET("/health/ready", handler.Ready)
 	return r
 }
 
-func TestHealthHandler_HealthCheck(t *testing.T) {
+func TestHealthHandler_Live(t *testing.T) {
 	// Setup
-	handler := NewHealthHandler()
+	mockCheckReadiness := &MockCheckReadinessUseCase{}
+	handler := NewHealthHandler(controllers.NewHealthController(mockCheckReadiness))
 	r := setupHealthTestRouter(&handler)
 
-	// Test request
-	req, _ := http.NewRequest("GET", "/health", nil)
-
 	// Execute
+	req, _ := http.NewRequest("GET", "/health/live", nil)
 	w := httptest.NewRecorder()
 	r.ServeHTTP(w, req)
 
 	// Assert
 	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"status": "up"}`, w.Body.String())
+	mockCheckReadiness.AssertNotCalled(t, "Execute", mock.Anything)
+}
+
+func TestHealthHandler_Ready(t *testing.T) {
+	tests := []struct {
+		name           string
+		report         entity.HealthReport
+		expectedStatus int
+	}{
+		{
+			name: "all dependencies up",
+			report: entity.HealthReport{Status: entity.HealthStatusUp, Dependencies: []entity.DependencyHealth{
+				{Name: "postgres", Status: entity.HealthStatusUp, Latency: 1500 * time.Microsecond},
+				{Name: "mongo", Status: entity.HealthStatusUp, Latency: 2 * time.Millisecond},
+			}},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "one dependency down",
+			report: entity.HealthReport{Status: entity.HealthStatusDown, Dependencies: []entity.DependencyHealth{
+				{Name: "postgres", Status: entity.HealthStatusDown, Latency: 2 * time.Second, Error: "context deadline exceeded"},
+				{Name: "mongo", Status: entity.HealthStatusUp, Latency: 2 * time.Millisecond},
+			}},
+			expectedStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name: "optional dependency down",
+			report: entity.HealthReport{Status: entity.HealthStatusDegraded, Dependencies: []entity.DependencyHealth{
+				{Name: "postgres", Required: true, Status: entity.HealthStatusUp, Latency: 1500 * time.Microsecond},
+				{Name: "mongo", Status: entity.HealthStatusDown, Latency: 2 * time.Second, Error: "server selection timeout"},
+			}},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			mockCheckReadiness := &MockCheckReadinessUseCase{}
+			mockCheckReadiness.On("Execute", mock.Anything).Return(tt.report)
+			handler := NewHealthHandler(controllers.NewHealthController(mockCheckReadiness))
+			r := setupHealthTestRouter(&handler)
+
+			// Execute
+			req, _ := http.NewRequest("GET", "/health/ready", nil)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
 
-	var response map[string]string
-	err := json.Unmarshal(w.Body.Bytes(), &response)
-	assert.NoError(t, err)
-	assert.Equal(t, "Application is healthy", response["message"])
+			// Assert
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			var response struct {
+				Status       string `json:"status"`
+				Dependencies []struct {
+					Name      string  `json:"name"`
+					Required  bool    `json:"required"`
+					Status    string  `json:"status"`
+					LatencyMs float64 `json:"latency_ms"`
+					Error     string  `json:"error"`
+				} `json:"dependencies"`
+			}
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			assert.NoError(t, err)
+			assert.Equal(t, string(tt.report.Status), response.Status)
+			assert.Len(t, response.Dependencies, 2)
+			for i, dependency := range tt.report.Dependencies {
+				assert.Equal(t, dependency.Name, response.Dependencies[i].Name)
+				assert.Equal(t, dependency.Required, response.Dependencies[i].Required)
+				assert.Equal(t, string(dependency.Status), response.Dependencies[i].Status)
+				assert.Equal(t, float64(dependency.Latency.Microseconds())/1000, response.Dependencies[i].LatencyMs)
+				assert.Equal(t, dependency.Error, response.Dependencies[i].Error)
+			}
+		})
+	}
 }
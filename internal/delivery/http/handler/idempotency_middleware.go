@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"post-tech-challenge-10soat/internal/controllers"
+	entity "post-tech-challenge-10soat/internal/entities"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	idempotencyKeyHeader      = "Idempotency-Key"
+	idempotencyReplayedHeader = "Idempotent-Replayed"
+	maxIdempotencyKeyLength   = 255
+)
+
+type IdempotencyMiddleware struct {
+	idempotencyController controllers.IdempotencyController
+}
+
+func NewIdempotencyMiddleware(idempotencyController controllers.IdempotencyController) IdempotencyMiddleware {
+	return IdempotencyMiddleware{
+		idempotencyController,
+	}
+}
+
+// Handle makes any mutating request carrying an Idempotency-Key safe to retry.
+// The first response is stored and replayed to retries with the same body,
+// while reusing the key with a different body is answered with 409.
+func (m *IdempotencyMiddleware) Handle(ctx *gin.Context) {
+	key := ctx.GetHeader(idempotencyKeyHeader)
+	if key == "" || !isMutatingMethod(ctx.Request.Method) {
+		ctx.Next()
+		return
+	}
+	if len(key) > maxIdempotencyKeyLength {
+		handleError(ctx, fmt.Errorf("%w: %s must have at most %d characters", entity.ErrInvalidData, idempotencyKeyHeader, maxIdempotencyKeyLength))
+		ctx.Abort()
+		return
+	}
+	body, err := io.ReadAll(ctx.Request.Body)
+	if err != nil {
+		handleError(ctx, fmt.Errorf("%w: cannot read request body", entity.ErrInvalidData))
+		ctx.Abort()
+		return
+	}
+	ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+	record, replay, err := m.idempotencyController.BeginRequest(ctx, entity.IdempotencyRecord{
+		Principal:   requestPrincipal(ctx),
+		Key:         key,
+		RequestHash: hashRequest(ctx.Request, body),
+	})
+	if err != nil {
+		handleError(ctx, err)
+		ctx.Abort()
+		return
+	}
+	if replay {
+		ctx.Header(idempotencyReplayedHeader, "true")
+		ctx.Data(record.StatusCode, record.ContentType, record.ResponseBody)
+		ctx.Abort()
+		return
+	}
+
+	writer := &recordingWriter{ResponseWriter: ctx.Writer}
+	ctx.Writer = writer
+	finish := func(statusCode int) {
+		record.StatusCode = statusCode
+		record.ResponseBody = writer.body.Bytes()
+		record.ContentType = writer.Header().Get("Content-Type")
+		// The response is stored even if the client has already gone away.
+		if err := m.idempotencyController.FinishRequest(context.WithoutCancel(ctx.Request.Context()), record); err != nil {
+			slog.ErrorContext(ctx, "Error finishing idempotent request", "idempotency_key", key, "error", err)
+		}
+	}
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			finish(http.StatusInternalServerError)
+			panic(recovered)
+		}
+	}()
+	ctx.Next()
+	finish(writer.Status())
+}
+
+func isMutatingMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// requestPrincipal identifies the caller, so the same idempotency key sent by
+// two devices never shares a response and each caller has its own rate limit.
+func requestPrincipal(ctx *gin.Context) string {
+	if session, ok := entity.SessionFromContext(ctx.Request.Context()); ok {
+		return fmt.Sprintf("session:%s:%s", session.Role, session.Subject)
+	}
+	if device, ok := entity.DeviceFromContext(ctx.Request.Context()); ok {
+		return "api_key:" + device.ApiKeyId
+	}
+	return "anonymous:" + ctx.ClientIP()
+}
+
+func hashRequest(request *http.Request, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(request.Method + " " + request.URL.RequestURI() + "\n"))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+type recordingWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *recordingWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *recordingWriter) WriteString(data string) (int, error) {
+	w.body.WriteString(data)
+	return w.ResponseWriter.WriteString(data)
+}
@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"post-tech-challenge-10soat/internal/controllers"
+	entity "post-tech-challenge-10soat/internal/entities"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeIdempotencyController keeps records in memory, mirroring the use cases.
+type fakeIdempotencyController struct {
+	records map[string]entity.IdempotencyRecord
+}
+
+var _ controllers.IdempotencyController = (*fakeIdempotencyController)(nil)
+
+func (f *fakeIdempotencyController) BeginRequest(ctx context.Context, request entity.IdempotencyRecord) (entity.IdempotencyRecord, bool, error) {
+	id := request.Principal + "|" + request.Key
+	existing, ok := f.records[id]
+	if !ok {
+		request.Id = id
+		f.records[id] = request
+		return request, false, nil
+	}
+	if existing.RequestHash != request.RequestHash || !existing.IsCompleted() {
+		return entity.IdempotencyRecord{}, false, entity.ErrConflictingData
+	}
+	return existing, true, nil
+}
+
+func (f *fakeIdempotencyController) FinishRequest(ctx context.Context, record entity.IdempotencyRecord) error {
+	if record.StatusCode >= http.StatusInternalServerError || record.StatusCode == http.StatusTooManyRequests {
+		delete(f.records, record.Id)
+		return nil
+	}
+	record.CompletedAt = record.CreatedAt.AddDate(0, 0, 1)
+	f.records[record.Id] = record
+	return nil
+}
+
+func setupIdempotencyTestRouter(status *int, calls *int) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	middleware := NewIdempotencyMiddleware(&fakeIdempotencyController{records: map[string]entity.IdempotencyRecord{}})
+	r := gin.New()
+	r.POST("/orders", middleware.Handle, func(ctx *gin.Context) {
+		*calls++
+		ctx.JSON(*status, gin.H{"number": *calls})
+	})
+	return r
+}
+
+func sendIdempotent(r *gin.Engine, key string, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest("POST", "/orders", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		req.Header.Set(idempotencyKeyHeader, key)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestIdempotencyMiddleware_ReplaysResponse(t *testing.T) {
+	// Setup
+	status, calls := http.StatusOK, 0
+	r := setupIdempotencyTestRouter(&status, &calls)
+
+	// Act
+	first := sendIdempotent(r, "key-1", `{"products":[]}`)
+	second := sendIdempotent(r, "key-1", `{"products":[]}`)
+
+	// Assert
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, http.StatusOK, second.Code)
+	assert.Equal(t, first.Body.String(), second.Body.String())
+	assert.Equal(t, "true", second.Header().Get(idempotencyReplayedHeader))
+	assert.Equal(t, "application/json; charset=utf-8", second.Header().Get("Content-Type"))
+}
+
+func TestIdempotencyMiddleware_DifferentBody(t *testing.T) {
+	// Setup
+	status, calls := http.StatusOK, 0
+	r := setupIdempotencyTestRouter(&status, &calls)
+
+	// Act
+	sendIdempotent(r, "key-1", `{"products":[]}`)
+	second := sendIdempotent(r, "key-1", `{"products":[{"product_id":"1"}]}`)
+
+	// Assert
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, http.StatusConflict, second.Code)
+}
+
+func TestIdempotencyMiddleware_ServerErrorIsRetried(t *testing.T) {
+	// Setup
+	status, calls := http.StatusInternalServerError, 0
+	r := setupIdempotencyTestRouter(&status, &calls)
+
+	// Act
+	sendIdempotent(r, "key-1", `{}`)
+	status = http.StatusOK
+	second := sendIdempotent(r, "key-1", `{}`)
+
+	// Assert
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, http.StatusOK, second.Code)
+	assert.Empty(t, second.Header().Get(idempotencyReplayedHeader))
+}
+
+func TestIdempotencyMiddleware_WithoutKey(t *testing.T) {
+	// Setup
+	status, calls := http.StatusOK, 0
+	r := setupIdempotencyTestRouter(&status, &calls)
+
+	// Act
+	sendIdempotent(r, "", `{}`)
+	sendIdempotent(r, "", `{}`)
+
+	// Assert
+	assert.Equal(t, 2, calls)
+}
@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"log/slog"
+	lm "post-tech-challenge-10soat/internal/delivery/http/mapper"
+	interfaces "post-tech-challenge-10soat/internal/interfaces/gateways"
+
+	"github.com/gin-gonic/gin"
+)
+
+type LogLevelHandler struct {
+	logLevelGateway interfaces.LogLevelGateway
+}
+
+func NewLogLevelHandler(logLevelGateway interfaces.LogLevelGateway) LogLevelHandler {
+	return LogLevelHandler{
+		logLevelGateway,
+	}
+}
+
+type setLogLevelRequest struct {
+	Level string `json:"level" binding:"required,oneof=debug info warn error" example:"debug"`
+}
+
+// GetLogLevel godoc
+//
+//	@Summary     Consulta o nível de log
+//	@Description Retorna o nível mínimo dos logs da aplicação
+//	@Tags        Admin
+//	@Produce		json
+//	@Security    BearerAuth
+//	@Success		200	{object} lm.LogLevelResponse	"Nível de log"
+//	@Failure		401	{object} Problem	"Token ausente ou inválido"
+//	@Failure		403	{object} Problem	"Perfil sem permissão"
+//	@Router		/admin/log-level [get]
+func (handler *LogLevelHandler) GetLogLevel(ctx *gin.Context) {
+	handleSuccess(ctx, lm.NewLogLevelResponse(handler.logLevelGateway.Level()))
+}
+
+// SetLogLevel godoc
+//
+//	@Summary     Altera o nível de log
+//	@Description Altera o nível mínimo dos logs da aplicação sem reiniciá-la. O nível volta ao configurado em LOG_LEVEL no próximo início
+//	@Tags        Admin
+//	@Accept      json
+//	@Produce		json
+//	@Security    BearerAuth
+//	@Param	    setLogLevelRequest	body setLogLevelRequest true "Nível de log"
+//	@Success		200	{object} lm.LogLevelResponse	"Nível de log alterado"
+//	@Failure		400	{object} Problem	"Erro de validação"
+//	@Failure		401	{object} Problem	"Token ausente ou inválido"
+//	@Failure		403	{object} Problem	"Perfil sem permissão"
+//	@Router		/admin/log-level [put]
+func (handler *LogLevelHandler) SetLogLevel(ctx *gin.Context) {
+	var request setLogLevelRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		validationError(ctx, err)
+		return
+	}
+	previous := handler.logLevelGateway.Level()
+	if err := handler.logLevelGateway.SetLevel(request.Level); err != nil {
+		handleError(ctx, err)
+		return
+	}
+	slog.WarnContext(ctx, "Log level changed", "from", previous, "to", request.Level)
+	handleSuccess(ctx, lm.NewLogLevelResponse(request.Level))
+}
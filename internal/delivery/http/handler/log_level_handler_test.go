@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockLogLevelGateway struct {
+	mock.Mock
+}
+
+func (m *MockLogLevelGateway) Level() string {
+	args := m.Called()
+	return args.String(0)
+}
+
+func (m *MockLogLevelGateway) SetLevel(level string) error {
+	args := m.Called(level)
+	return args.Error(0)
+}
+
+func setupLogLevelTestRouter(handler *LogLevelHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/admin/log-level", handler.GetLogLevel)
+	r.PUT("/admin/log-level", handler.SetLogLevel)
+	return r
+}
+
+func TestLogLevelHandler_GetLogLevel(t *testing.T) {
+	// Setup
+	mockLogLevelGateway := &MockLogLevelGateway{}
+	mockLogLevelGateway.On("Level").Return("info")
+	handler := NewLogLevelHandler(mockLogLevelGateway)
+	r := setupLogLevelTestRouter(&handler)
+
+	// Execute
+	req, _ := http.NewRequest("GET", "/admin/log-level", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response struct {
+		Data struct {
+			Level string `json:"level"`
+		} `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "info", response.Data.Level)
+}
+
+func TestLogLevelHandler_SetLogLevel(t *testing.T) {
+	tests := []struct {
+		name         string
+		body         string
+		setupMocks   func(*MockLogLevelGateway)
+		expectedCode int
+	}{
+		{
+			name: "changes the level",
+			body: `{"level":"debug"}`,
+			setupMocks: func(m *MockLogLevelGateway) {
+				m.On("Level").Return("info")
+				m.On("SetLevel", "debug").Return(nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "rejects unknown levels",
+			body:         `{"level":"verbose"}`,
+			setupMocks:   func(m *MockLogLevelGateway) {},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			mockLogLevelGateway := &MockLogLevelGateway{}
+			tt.setupMocks(mockLogLevelGateway)
+			handler := NewLogLevelHandler(mockLogLevelGateway)
+			r := setupLogLevelTestRouter(&handler)
+
+			// Execute
+			req, _ := http.NewRequest("PUT", "/admin/log-level", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedCode, w.Code)
+			mockLogLevelGateway.AssertExpectations(t)
+		})
+	}
+}
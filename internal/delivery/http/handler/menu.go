@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"post-tech-challenge-10soat/internal/controllers"
+	mm "post-tech-challenge-10soat/internal/delivery/http/mapper"
+	"post-tech-challenge-10soat/internal/delivery/menufile"
+	entity "post-tech-challenge-10soat/internal/entities"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxMenuFileSize bounds the body of an import, far above the size of a
+// menu with the most products accepted.
+const maxMenuFileSize = 5 << 20
+
+type MenuHandler struct {
+	menuController controllers.MenuController
+}
+
+func NewMenuHandler(menuController controllers.MenuController) MenuHandler {
+	return MenuHandler{
+		menuController,
+	}
+}
+
+type importMenuRequest struct {
+	DryRun bool   `form:"dry_run" example:"true"`
+	Format string `form:"format" binding:"omitempty,oneof=json csv" example:"csv"`
+}
+
+// ImportMenu godoc
+//
+//	@Summary     Importa o cardápio
+//	@Description Cria ou atualiza categorias e produtos a partir de um arquivo JSON ou CSV, identificando os produtos pelo SKU. O formato vem do parâmetro format ou do Content-Type. Se alguma linha for inválida nada é gravado e o relatório traz os erros de cada linha. Com dry_run o arquivo só é validado
+//	@Tags        Menu
+//	@Accept      json
+//	@Accept      text/csv
+//	@Produce		json
+//	@Security    BearerAuth
+//	@Param       dry_run	query	bool	false	"Só valida, sem gravar"
+//	@Param       format	query	string	false	"json ou csv"
+//	@Success		200	{object} mm.MenuImportResponse	"Relatório da importação"
+//	@Failure		400	{object} Problem	"Arquivo inválido"
+//	@Failure		401	{object} Problem	"Token ausente ou inválido"
+//	@Failure		403	{object} Problem	"Perfil sem permissão"
+//	@Failure		422	{object} mm.MenuImportResponse	"Linhas inválidas, nada foi gravado"
+//	@Router		/menu/import [post]
+func (handler *MenuHandler) ImportMenu(ctx *gin.Context) {
+	var request importMenuRequest
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		validationError(ctx, err)
+		return
+	}
+	format := request.Format
+	if format == "" {
+		format = menufile.FormatOf(ctx.ContentType())
+	}
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxMenuFileSize)
+	menu, err := menufile.Decode(ctx.Request.Body, format)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			err = fmt.Errorf("%w: the menu is larger than %d MB", entity.ErrInvalidData, maxMenuFileSize>>20)
+		}
+		handleError(ctx, err)
+		return
+	}
+	report, err := handler.menuController.ImportMenu(ctx, menu, request.DryRun)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	response := mm.NewMenuImportResponse(report)
+	if report.Invalid > 0 {
+		ctx.JSON(http.StatusUnprocessableEntity, newResponse(false, "The menu has invalid rows", response))
+		return
+	}
+	handleSuccess(ctx, response)
+}
+
+type exportMenuRequest struct {
+	Format string `form:"format" binding:"omitempty,oneof=json csv" example:"csv"`
+}
+
+// ExportMenu godoc
+//
+//	@Summary     Exporta o cardápio
+//	@Description Exporta categorias e produtos em JSON ou CSV, no formato aceito pela importação. O formato vem do parâmetro format ou do Accept
+//	@Tags        Menu
+//	@Produce		json
+//	@Produce		text/csv
+//	@Security    BearerAuth
+//	@Param       format	query	string	false	"json ou csv"
+//	@Success		200	{file}	file	"Cardápio"
+//	@Failure		401	{object} Problem	"Token ausente ou inválido"
+//	@Failure		403	{object} Problem	"Perfil sem permissão"
+//	@Router		/menu/export [get]
+func (handler *MenuHandler) ExportMenu(ctx *gin.Context) {
+	var request exportMenuRequest
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		validationError(ctx, err)
+		return
+	}
+	format := request.Format
+	if format == "" {
+		format = menufile.FormatOf(ctx.NegotiateFormat(gin.MIMEJSON, "text/csv"))
+	}
+	menu, err := handler.menuController.ExportMenu(ctx)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	ctx.Header("Content-Type", menufile.ContentType(format))
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="menu.%s"`, format))
+	ctx.Status(http.StatusOK)
+	// The status is already sent, a failure can only cut the file short.
+	if err := menufile.Encode(ctx.Writer, format, menu); err != nil {
+		slog.ErrorContext(ctx, "Error writing the menu", "error", err)
+	}
+}
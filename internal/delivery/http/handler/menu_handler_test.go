@@ -0,0 +1,199 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"post-tech-challenge-10soat/internal/controllers"
+	entity "post-tech-challenge-10soat/internal/entities"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockImportMenuUseCase struct {
+	mock.Mock
+}
+
+func (m *MockImportMenuUseCase) Execute(ctx context.Context, menu entity.Menu, dryRun bool) (entity.MenuImport, error) {
+	args := m.Called(ctx, menu, dryRun)
+	return args.Get(0).(entity.MenuImport), args.Error(1)
+}
+
+type MockExportMenuUseCase struct {
+	mock.Mock
+}
+
+func (m *MockExportMenuUseCase) Execute(ctx context.Context) (entity.Menu, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(entity.Menu), args.Error(1)
+}
+
+func setupMenuTestRouter(handler *MenuHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/menu/import", handler.ImportMenu)
+	r.GET("/menu/export", handler.ExportMenu)
+	return r
+}
+
+func TestMenuHandler_ImportMenu(t *testing.T) {
+	csvMenu := entity.Menu{Items: []entity.MenuItem{
+		{Row: 2, Sku: "LANCHE-1", Name: "Lanche 1", Value: 15.9, Category: "Lanche"},
+	}}
+	tests := []struct {
+		name           string
+		url            string
+		contentType    string
+		body           string
+		setupMocks     func(*MockImportMenuUseCase)
+		expectedCode   int
+		expectedAction string
+	}{
+		{
+			name:        "imports a CSV menu",
+			url:         "/menu/import",
+			contentType: "text/csv",
+			body:        "sku,name,value,category\nLANCHE-1,Lanche 1,15.90,Lanche\n",
+			setupMocks: func(m *MockImportMenuUseCase) {
+				m.On("Execute", mock.Anything, csvMenu, false).Return(entity.MenuImport{
+					Applied: true,
+					Created: 1,
+					Rows:    []entity.MenuImportRow{{Row: 2, Sku: "LANCHE-1", Action: entity.MenuImportActionCreate}},
+				}, nil)
+			},
+			expectedCode:   http.StatusOK,
+			expectedAction: "create",
+		},
+		{
+			name:        "validates only on a dry run",
+			url:         "/menu/import?dry_run=true&format=csv",
+			contentType: "application/octet-stream",
+			body:        "sku,name,value,category\nLANCHE-1,Lanche 1,15.90,Lanche\n",
+			setupMocks: func(m *MockImportMenuUseCase) {
+				m.On("Execute", mock.Anything, csvMenu, true).Return(entity.MenuImport{
+					DryRun: true,
+					Rows:   []entity.MenuImportRow{{Row: 2, Sku: "LANCHE-1", Action: entity.MenuImportActionUpdate}},
+				}, nil)
+			},
+			expectedCode:   http.StatusOK,
+			expectedAction: "update",
+		},
+		{
+			name:        "reports invalid rows",
+			url:         "/menu/import",
+			contentType: "application/json",
+			body:        `{"products":[{"sku":"LANCHE-1","value":15.9,"category":"Lanche"}]}`,
+			setupMocks: func(m *MockImportMenuUseCase) {
+				m.On("Execute", mock.Anything, mock.Anything, false).Return(entity.MenuImport{
+					Invalid: 1,
+					Rows:    []entity.MenuImportRow{{Row: 1, Sku: "LANCHE-1", Action: entity.MenuImportActionInvalid, Errors: []string{"name is required"}}},
+				}, nil)
+			},
+			expectedCode:   http.StatusUnprocessableEntity,
+			expectedAction: "invalid",
+		},
+		{
+			name:         "rejects a file that cannot be read",
+			url:          "/menu/import",
+			contentType:  "application/json",
+			body:         `{"products":`,
+			setupMocks:   func(m *MockImportMenuUseCase) {},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			mockImportMenu := &MockImportMenuUseCase{}
+			tt.setupMocks(mockImportMenu)
+			handler := NewMenuHandler(controllers.NewMenuController(mockImportMenu, &MockExportMenuUseCase{}))
+			r := setupMenuTestRouter(&handler)
+
+			// Execute
+			req, _ := http.NewRequest("POST", tt.url, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedCode, w.Code)
+			mockImportMenu.AssertExpectations(t)
+			if tt.expectedAction == "" {
+				return
+			}
+			var response struct {
+				Data struct {
+					Rows []struct {
+						Action string `json:"action"`
+					} `json:"rows"`
+				} `json:"data"`
+			}
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.expectedAction, response.Data.Rows[0].Action)
+		})
+	}
+}
+
+func TestMenuHandler_ExportMenu(t *testing.T) {
+	tests := []struct {
+		name                string
+		url                 string
+		accept              string
+		expectedContentType string
+		expectedBody        string
+	}{
+		{
+			name:                "exports CSV when asked by the Accept header",
+			url:                 "/menu/export",
+			accept:              "text/csv",
+			expectedContentType: "text/csv; charset=utf-8",
+			expectedBody:        "sku,name,description,image,value,category\nLANCHE-1,Lanche 1,,,15.90,Lanche\n",
+		},
+		{
+			name:                "exports JSON by default",
+			url:                 "/menu/export",
+			expectedContentType: "application/json; charset=utf-8",
+			expectedBody:        `"sku": "LANCHE-1"`,
+		},
+		{
+			name:                "exports the format in the query",
+			url:                 "/menu/export?format=csv",
+			accept:              "application/json",
+			expectedContentType: "text/csv; charset=utf-8",
+			expectedBody:        "LANCHE-1,Lanche 1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			mockExportMenu := &MockExportMenuUseCase{}
+			mockExportMenu.On("Execute", mock.Anything).Return(entity.Menu{
+				Categories: []string{"Lanche"},
+				Items:      []entity.MenuItem{{Sku: "LANCHE-1", Name: "Lanche 1", Value: 15.9, Category: "Lanche"}},
+			}, nil)
+			handler := NewMenuHandler(controllers.NewMenuController(&MockImportMenuUseCase{}, mockExportMenu))
+			r := setupMenuTestRouter(&handler)
+
+			// Execute
+			req, _ := http.NewRequest("GET", tt.url, nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, tt.expectedContentType, w.Header().Get("Content-Type"))
+			assert.Contains(t, w.Body.String(), tt.expectedBody)
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+	"post-tech-challenge-10soat/internal/controllers"
+	interfaces "post-tech-challenge-10soat/internal/interfaces/gateways"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// unmatchedRoute labels requests that hit no route, so scanners probing
+// random paths cannot blow up the number of series.
+const unmatchedRoute = "unmatched"
+
+type MetricsHandler struct {
+	metricsController controllers.MetricsController
+	metricsGateway    interfaces.MetricsGateway
+	exposition        http.Handler
+}
+
+func NewMetricsHandler(metricsController controllers.MetricsController, metricsGateway interfaces.MetricsGateway, exposition http.Handler) MetricsHandler {
+	return MetricsHandler{
+		metricsController,
+		metricsGateway,
+		exposition,
+	}
+}
+
+// Observe records the duration of every request by method, route template
+// and status.
+func (handler *MetricsHandler) Observe(ctx *gin.Context) {
+	start := time.Now()
+	ctx.Next()
+	route := ctx.FullPath()
+	if route == "" {
+		route = unmatchedRoute
+	}
+	handler.metricsGateway.ObserveHttpRequest(ctx.Request.Method, route, ctx.Writer.Status(), time.Since(start))
+}
+
+// Metrics godoc
+//
+//	@Summary     Métricas no formato Prometheus
+//	@Description Expõe as métricas HTTP, do pool do Postgres e de pedidos e pagamentos no formato texto do Prometheus
+//	@Tags        Metrics
+//	@Produce		plain
+//	@Success		200	{string}  string	"Métricas"
+//	@Router		/metrics [get]
+func (handler *MetricsHandler) Metrics(ctx *gin.Context) {
+	// A failed count keeps the last values, so the scrape still carries
+	// the HTTP and pool metrics.
+	if err := handler.metricsController.RefreshOrdersByStatus(ctx); err != nil {
+		slog.WarnContext(ctx, "Error refreshing orders by status", "error", err)
+	}
+	handler.exposition.ServeHTTP(ctx.Writer, ctx.Request)
+}
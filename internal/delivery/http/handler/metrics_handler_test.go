@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"post-tech-challenge-10soat/internal/controllers"
+	entity "post-tech-challenge-10soat/internal/entities"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockRefreshOrdersByStatusUseCase struct {
+	mock.Mock
+}
+
+func (m *MockRefreshOrdersByStatusUseCase) Execute(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+type MockMetricsGateway struct {
+	mock.Mock
+}
+
+func (m *MockMetricsGateway) ObserveHttpRequest(method string, route string, status int, duration time.Duration) {
+	m.Called(method, route, status, duration)
+}
+
+func (m *MockMetricsGateway) OrderCreated() {
+	m.Called()
+}
+
+func (m *MockMetricsGateway) OrderStatusChanged(from entity.OrderStatus, to entity.OrderStatus) {
+	m.Called(from, to)
+}
+
+func (m *MockMetricsGateway) PaymentFinished(approved bool) {
+	m.Called(approved)
+}
+
+func (m *MockMetricsGateway) SetOrdersByStatus(counts map[entity.OrderStatus]int) {
+	m.Called(counts)
+}
+
+func setupMetricsTestRouter(handler *MetricsHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(handler.Observe)
+	r.GET("/metrics", handler.Metrics)
+	r.GET("/v1/orders/:id", func(ctx *gin.Context) {
+		ctx.Status(http.StatusNotFound)
+	})
+	return r
+}
+
+func TestMetricsHandler_Observe(t *testing.T) {
+	tests := []struct {
+		name          string
+		path          string
+		expectedRoute string
+		expectedCode  int
+	}{
+		{
+			name:          "labels with the route template",
+			path:          "/v1/orders/ed6ac028-8016-4cbd-aeee-c3a155cdb2a4",
+			expectedRoute: "/v1/orders/:id",
+			expectedCode:  http.StatusNotFound,
+		},
+		{
+			name:          "labels unknown paths as unmatched",
+			path:          "/wp-login.php",
+			expectedRoute: "unmatched",
+			expectedCode:  http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			mockMetricsGateway := &MockMetricsGateway{}
+			mockMetricsGateway.On("ObserveHttpRequest", "GET", tt.expectedRoute, tt.expectedCode, mock.Anything).Return()
+			handler := NewMetricsHandler(controllers.NewMetricsController(&MockRefreshOrdersByStatusUseCase{}), mockMetricsGateway, http.NotFoundHandler())
+			r := setupMetricsTestRouter(&handler)
+
+			// Execute
+			req, _ := http.NewRequest("GET", tt.path, nil)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedCode, w.Code)
+			mockMetricsGateway.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMetricsHandler_Metrics(t *testing.T) {
+	tests := []struct {
+		name       string
+		refreshErr error
+	}{
+		{
+			name: "refreshes orders by status before serving",
+		},
+		{
+			name:       "serves the metrics when the refresh fails",
+			refreshErr: errors.New("connection refused"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			mockRefresh := &MockRefreshOrdersByStatusUseCase{}
+			mockRefresh.On("Execute", mock.Anything).Return(tt.refreshErr)
+			mockMetricsGateway := &MockMetricsGateway{}
+			mockMetricsGateway.On("ObserveHttpRequest", "GET", "/metrics", http.StatusOK, mock.Anything).Return()
+			exposition := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte("pos_orders_created_total 1\n"))
+			})
+			handler := NewMetricsHandler(controllers.NewMetricsController(mockRefresh), mockMetricsGateway, exposition)
+			r := setupMetricsTestRouter(&handler)
+
+			// Execute
+			req, _ := http.NewRequest("GET", "/metrics", nil)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, "pos_orders_created_total 1\n", w.Body.String())
+			mockRefresh.AssertExpectations(t)
+		})
+	}
+}
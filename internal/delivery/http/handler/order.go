@@ -37,9 +37,12 @@ type createOrderRequest struct {
 //	@Accept			json
 //	@Produce		json
 //	@Param			createOrderRequest	body		createOrderRequest	true	"Criar ordem body"
+//	@Param			Idempotency-Key		header		string				false	"Chave para repetir a requisição com segurança"
 //	@Success		200					{object}	om.OrderResponse		"Ordem criada"
-//	@Failure		400					{object}	ErrorResponse		"Erro de validação"
-//	@Failure		500					{object}	ErrorResponse		"Erro interno"
+//	@Header			200					{string}	ETag				"Versão do pedido"
+//	@Failure		400					{object}	Problem		"Erro de validação"
+//	@Failure		409					{object}	Problem		"Chave de idempotência usada com outro body"
+//	@Failure		500					{object}	Problem		"Erro interno"
 //	@Router			/orders [post]
 //	@Security		BearerAuth
 func (h *OrderHandler) CreateOrder(ctx *gin.Context) {
@@ -65,12 +68,13 @@ func (h *OrderHandler) CreateOrder(ctx *gin.Context) {
 		handleError(ctx, err)
 		return
 	}
+	setETag(ctx, o.Version)
 	response := om.NewOrderResponse(o)
 	handleSuccess(ctx, response)
 }
 
 type listOrdersRequest struct {
-	Limit uint64 `form:"limit" binding:"required,min=1" example:5""`
+	Limit uint64 `form:"limit" binding:"required,min=1" example:"5"`
 }
 
 // ListOrders godoc
@@ -82,9 +86,10 @@ type listOrdersRequest struct {
 //	@Produce		json
 //	@Param			limit	query		int			true	"Limite de pedidos"
 //	@Success		200			{object}	om.ListOrdersResponse			"Pedidos listados"
-//	@Failure		400			{object}	ErrorResponse	"Erro de validação"
-//	@Failure		500			{object}	ErrorResponse	"Erro interno"
+//	@Failure		400			{object}	Problem	"Erro de validação"
+//	@Failure		500			{object}	Problem	"Erro interno"
 //	@Router			/orders [get]
+//	@Security	BearerAuth
 func (h *OrderHandler) ListOrders(ctx *gin.Context) {
 	var request listOrdersRequest
 	if err := ctx.ShouldBindQuery(&request); err != nil {
@@ -113,9 +118,10 @@ type getOrderPaymentStatusRequest struct {
 //	    @Produce		json
 //		@Param	    id	path		string				true	"ID"
 //	    @Success		200	{object}    om.OrderPaymentStatusResponse	"Status do pagamento"
-//	    @Failure		400	{object}    ErrorResponse	"Erro de validação"
-//		@Failure		404	{object}	ErrorResponse   "Pedido não encontrado"
+//	    @Failure		400	{object}    Problem	"Erro de validação"
+//		@Failure		404	{object}	Problem   "Pedido não encontrado"
 //	    @Router		/orders/{id}/payment-status [get]
+//	    @Security	BearerAuth
 func (h *OrderHandler) GetOrderPaymentStatus(ctx *gin.Context) {
 	var request getOrderPaymentStatusRequest
 	if err := ctx.ShouldBindUri(&request); err != nil {
@@ -148,10 +154,16 @@ type updateOrderStatusQuery struct {
 //	    @Produce		json
 //		@Param	    id	path		string				true	"ID"
 //	    @Param			status	query		string	true	"Status do pedido" Enums(preparing, ready, completed)
+//	    @Param			If-Match	header	string	true	"ETag da versão que está sendo alterada"
 //	    @Success		200	{object}    om.UpdateOrderStatusResponse	"Status do pagamento"
-//	    @Failure		400	{object}    ErrorResponse	"Erro de validação"
-//		@Failure		404	{object}	ErrorResponse   "Pedido não encontrado"
+//	    @Header			200	{string}	ETag	"Nova versão do pedido"
+//	    @Failure		400	{object}    Problem	"Erro de validação"
+//		@Failure		404	{object}	Problem   "Pedido não encontrado"
+//	    @Failure		409	{object}	Problem   "Transição de status inválida"
+//	    @Failure		412	{object}	Problem   "Pedido alterado por outra requisição"
+//	    @Failure		428	{object}	Problem   "Header If-Match ausente"
 //	    @Router		/orders/{id}/status [patch]
+//	    @Security	BearerAuth
 func (h *OrderHandler) UpdateOrderStatus(ctx *gin.Context) {
 	var request updateOrderStatusRequest
 	var query updateOrderStatusQuery
@@ -163,11 +175,19 @@ func (h *OrderHandler) UpdateOrderStatus(ctx *gin.Context) {
 		validationError(ctx, err)
 		return
 	}
-	orderPaymentStatus, err := h.orderController.UpdateOrderStatus(ctx, request.Id, query.Status)
+	version, err := ifMatchVersion(ctx)
 	if err != nil {
 		handleError(ctx, err)
 		return
 	}
+	// gin recycles its context once the handler returns, and the status change
+	// notifies the customer after that.
+	orderPaymentStatus, err := h.orderController.UpdateOrderStatus(ctx.Request.Context(), request.Id, query.Status, version)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	setETag(ctx, orderPaymentStatus.Version)
 	response := om.NewOrderUpdateStatusResponse(orderPaymentStatus)
 	handleSuccess(ctx, response)
 }
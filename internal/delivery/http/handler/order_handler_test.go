@@ -52,8 +52,8 @@ type MockUpdateOrderStatusUseCase struct {
 	mock.Mock
 }
 
-func (m *MockUpdateOrderStatusUseCase) Execute(ctx context.Context, id string, status string) (entity.Order, error) {
-	args := m.Called(ctx, id, status)
+func (m *MockUpdateOrderStatusUseCase) Execute(ctx context.Context, id string, status string, version int) (entity.Order, error) {
+	args := m.Called(ctx, id, status, version)
 	return args.Get(0).(entity.Order), args.Error(1)
 }
 
@@ -69,6 +69,8 @@ func setupTestController() (*controllers.OrderController, *MockCreateOrderUseCas
 		mockListOrders,
 		mockGetOrderPaymentStatus,
 		mockUpdateOrderStatus,
+		nil,
+		nil,
 	)
 
 	return controller, mockCreateOrder, mockListOrders, mockGetOrderPaymentStatus, mockUpdateOrderStatus
@@ -233,13 +235,15 @@ func TestOrderHandler_UpdateOrderStatus_Success(t *testing.T) {
 	expectedOrder := entity.Order{
 		Id:        orderID,
 		Status:    "preparing",
+		Version:   2,
 		UpdatedAt: time.Now(),
 	}
 
-	mockUpdateOrderStatus.On("Execute", mock.Anything, orderID, status).Return(expectedOrder, nil)
+	mockUpdateOrderStatus.On("Execute", mock.Anything, orderID, status, 1).Return(expectedOrder, nil)
 
 	// Test request
 	req, _ := http.NewRequest("PATCH", "/orders/"+orderID+"/status?status="+status, nil)
+	req.Header.Set("If-Match", `"1"`)
 
 	// Execute
 	w := httptest.NewRecorder()
@@ -247,18 +251,63 @@ func TestOrderHandler_UpdateOrderStatus_Success(t *testing.T) {
 
 	// Assert
 	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `"2"`, w.Header().Get("ETag"))
 
 	var response struct {
 		Data struct {
-			ID     string `json:"id"`
-			Status string `json:"status"`
+			ID      string `json:"id"`
+			Status  string `json:"status"`
+			Version int    `json:"version"`
 		} `json:"data"`
 	}
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	assert.NoError(t, err)
 	assert.Equal(t, orderID, response.Data.ID)
 	assert.Equal(t, status, response.Data.Status)
+	assert.Equal(t, 2, response.Data.Version)
 
 	// Verify mock was called
 	mockUpdateOrderStatus.AssertExpectations(t)
 }
+
+func TestOrderHandler_UpdateOrderStatus_RequiresIfMatch(t *testing.T) {
+	// Setup
+	controller, _, _, _, mockUpdateOrderStatus := setupTestController()
+	handler := &OrderHandler{
+		orderController: *controller,
+	}
+	r := setupOrderTestRouter(handler)
+	req, _ := http.NewRequest("PATCH", "/orders/"+uuid.NewString()+"/status?status=ready", nil)
+
+	// Act
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusPreconditionRequired, w.Code)
+	mockUpdateOrderStatus.AssertNotCalled(t, "Execute")
+}
+
+func TestOrderHandler_UpdateOrderStatus_StaleVersion(t *testing.T) {
+	// Setup
+	controller, _, _, _, mockUpdateOrderStatus := setupTestController()
+	handler := &OrderHandler{
+		orderController: *controller,
+	}
+	r := setupOrderTestRouter(handler)
+	orderID := uuid.NewString()
+	mockUpdateOrderStatus.On("Execute", mock.Anything, orderID, "ready", 3).Return(entity.Order{}, entity.ErrVersionMismatch)
+	req, _ := http.NewRequest("PATCH", "/orders/"+orderID+"/status?status=ready", nil)
+	req.Header.Set("If-Match", `"3"`)
+
+	// Act
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+	var problem Problem
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+	assert.Equal(t, entity.ErrCodeVersionMismatch, problem.Code)
+	mockUpdateOrderStatus.AssertExpectations(t)
+}
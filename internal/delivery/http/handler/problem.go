@@ -0,0 +1,143 @@
+package handler
+
+import (
+	"net/http"
+	entity "post-tech-challenge-10soat/internal/entities"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/gin-gonic/gin/render"
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	problemContentType = "application/problem+json"
+	legacyContentType  = "application/json"
+)
+
+// Problem is an RFC 7807 problem details document. Code is stable and meant
+// for clients, Detail is meant for people.
+type Problem struct {
+	Type      string           `json:"type" example:"about:blank"`
+	Title     string           `json:"title" example:"Conflict"`
+	Status    int              `json:"status" example:"409"`
+	Detail    string           `json:"detail,omitempty" example:"cannot update order status for 'ready' to 'preparing'"`
+	Instance  string           `json:"instance,omitempty" example:"/v1/orders/6f1c2a8e-3b4d-4e5f-9a0b-1c2d3e4f5a6b/status"`
+	Code      entity.ErrorCode `json:"code" example:"ORDER_INVALID_TRANSITION"`
+	RequestId string           `json:"request_id,omitempty" example:"6f1c2a8e-3b4d-4e5f-9a0b-1c2d3e4f5a6b"`
+	Errors    []FieldError     `json:"errors,omitempty"`
+}
+
+// FieldError describes one field rejected by validation. Code is the rule
+// that failed, such as "required" or "min".
+type FieldError struct {
+	Field   string `json:"field" example:"products[0].quantity"`
+	Code    string `json:"code" example:"min"`
+	Param   string `json:"param,omitempty" example:"1"`
+	Message string `json:"message" example:"must be at least 1"`
+}
+
+func newProblem(ctx *gin.Context, status int, code entity.ErrorCode, detail string) Problem {
+	problem := Problem{
+		Type:      "about:blank",
+		Title:     http.StatusText(status),
+		Status:    status,
+		Detail:    detail,
+		Code:      code,
+		RequestId: requestId(ctx),
+	}
+	if ctx.Request != nil {
+		problem.Instance = ctx.Request.URL.Path
+	}
+	return problem
+}
+
+func writeProblem(ctx *gin.Context, problem Problem) {
+	ctx.Render(problem.Status, problemRender{problem})
+}
+
+// wantsLegacyErrors keeps the old envelope for clients that ask for
+// application/json without accepting problem details.
+func wantsLegacyErrors(ctx *gin.Context) bool {
+	if ctx.Request == nil {
+		return false
+	}
+	return ctx.NegotiateFormat(problemContentType, legacyContentType) == legacyContentType
+}
+
+func newFieldErrors(validationErrs validator.ValidationErrors) []FieldError {
+	fieldErrs := make([]FieldError, 0, len(validationErrs))
+	for _, fieldErr := range validationErrs {
+		fieldErrs = append(fieldErrs, FieldError{
+			Field:   fieldPath(fieldErr),
+			Code:    fieldErr.Tag(),
+			Param:   fieldErr.Param(),
+			Message: fieldMessage(fieldErr),
+		})
+	}
+	return fieldErrs
+}
+
+// fieldPath drops the request struct from the namespace, leaving the path the
+// client sent, such as "products[0].quantity".
+func fieldPath(fieldErr validator.FieldError) string {
+	_, path, found := strings.Cut(fieldErr.Namespace(), ".")
+	if !found {
+		return fieldErr.Field()
+	}
+	return path
+}
+
+func fieldMessage(fieldErr validator.FieldError) string {
+	switch fieldErr.Tag() {
+	case "required":
+		return "is required"
+	case "min":
+		return "must be at least " + fieldErr.Param()
+	case "max":
+		return "must be at most " + fieldErr.Param()
+	case "oneof":
+		return "must be one of " + fieldErr.Param()
+	case "email":
+		return "must be a valid email"
+	case "uuid", "uuid4":
+		return "must be a valid uuid"
+	}
+	return "failed the '" + fieldErr.Tag() + "' rule"
+}
+
+// UseRequestFieldNames makes validation errors name fields as they appear in
+// the request, by their json or form tag, instead of the Go field name.
+func UseRequestFieldNames() {
+	validate, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		for _, tag := range []string{"json", "form", "uri"} {
+			name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+			if name == "-" {
+				return ""
+			}
+			if name != "" {
+				return name
+			}
+		}
+		return field.Name
+	})
+}
+
+type problemRender struct {
+	problem Problem
+}
+
+func (r problemRender) Render(w http.ResponseWriter) error {
+	r.WriteContentType(w)
+	return render.JSON{Data: r.problem}.Render(w)
+}
+
+func (r problemRender) WriteContentType(w http.ResponseWriter) {
+	w.Header()["Content-Type"] = []string{problemContentType}
+}
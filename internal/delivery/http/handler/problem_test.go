@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	entity "post-tech-challenge-10soat/internal/entities"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandleError_Problem(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+		expectedCode   entity.ErrorCode
+	}{
+		{
+			name:           "domain error",
+			err:            entity.NewDomainError(entity.ErrConflictingData, entity.ErrCodeOrderInvalidTransition, "cannot update order status for 'ready' to 'preparing'"),
+			expectedStatus: http.StatusConflict,
+			expectedCode:   entity.ErrCodeOrderInvalidTransition,
+		},
+		{
+			name:           "wrapped domain error",
+			err:            fmt.Errorf("cannot create order - %w", entity.ErrProductNotFound),
+			expectedStatus: http.StatusNotFound,
+			expectedCode:   entity.ErrCodeProductNotFound,
+		},
+		{
+			name:           "sentinel error",
+			err:            fmt.Errorf("%w: role 'customer' is not allowed", entity.ErrForbidden),
+			expectedStatus: http.StatusForbidden,
+			expectedCode:   entity.ErrCodeForbidden,
+		},
+		{
+			// The first sentinel listed wins, whatever the wrapping order.
+			name:           "error wrapping two sentinels",
+			err:            fmt.Errorf("%w: %w", entity.ErrConflictingData, entity.ErrDataNotFound),
+			expectedStatus: http.StatusNotFound,
+			expectedCode:   entity.ErrCodeNotFound,
+		},
+		{
+			name:           "unknown error",
+			err:            errors.New("connection refused"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedCode:   entity.ErrCodeInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = httptest.NewRequest("PATCH", "/v1/orders/1/status", nil)
+
+			// Act
+			handleError(ctx, tt.err)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+			var problem Problem
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+			assert.Equal(t, tt.expectedCode, problem.Code)
+			assert.Equal(t, tt.expectedStatus, problem.Status)
+			assert.Equal(t, http.StatusText(tt.expectedStatus), problem.Title)
+			assert.Equal(t, tt.err.Error(), problem.Detail)
+			assert.Equal(t, "/v1/orders/1/status", problem.Instance)
+		})
+	}
+}
+
+func TestHandleError_NegotiatesFormat(t *testing.T) {
+	tests := map[string]bool{
+		"":                                  false,
+		"*/*":                               false,
+		"application/problem+json":          false,
+		"application/problem+json, */*":     false,
+		"application/json":                  true,
+		"application/json, text/plain, */*": true,
+	}
+
+	for accept, legacy := range tests {
+		t.Run(accept, func(t *testing.T) {
+			// Setup
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = httptest.NewRequest("GET", "/", nil)
+			ctx.Request.Header.Set("Accept", accept)
+
+			// Act
+			handleError(ctx, entity.ErrOrderNotFound)
+
+			// Assert
+			var body map[string]any
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			if legacy {
+				assert.Contains(t, body, "messages")
+			} else {
+				assert.Equal(t, string(entity.ErrCodeOrderNotFound), body["code"])
+			}
+		})
+	}
+}
+
+func TestValidationError_ListsFields(t *testing.T) {
+	// Setup
+	type item struct {
+		ProductId string `json:"product_id" binding:"required"`
+		Quantity  int    `json:"quantity" binding:"required,min=1"`
+	}
+	type request struct {
+		Products []item `json:"products" binding:"required,dive"`
+	}
+	UseRequestFieldNames()
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/orders", func(ctx *gin.Context) {
+		var req request
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			validationError(ctx, err)
+		}
+	})
+	req := httptest.NewRequest("POST", "/orders", bytes.NewBufferString(`{"products":[{"product_id":"p1","quantity":-1},{"quantity":2}]}`))
+	w := httptest.NewRecorder()
+
+	// Act
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var problem Problem
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+	assert.Equal(t, entity.ErrCodeValidationFailed, problem.Code)
+	assert.Equal(t, []FieldError{
+		{Field: "products[0].quantity", Code: "min", Param: "1", Message: "must be at least 1"},
+		{Field: "products[1].product_id", Code: "required", Message: "is required"},
+	}, problem.Errors)
+}
@@ -21,7 +21,7 @@ func NewProductHandler(productController controllers.ProductController) ProductH
 }
 
 type listProductsRequest struct {
-	CategoryID string `form:"category_id" binding:"omitempty,min=1" example:ed6ac028-8016-4cbd-aeee-c3a155cdb2a4""`
+	CategoryID string `form:"category_id" binding:"omitempty,min=1" example:"ed6ac028-8016-4cbd-aeee-c3a155cdb2a4"`
 }
 
 // ListProducts godoc
@@ -33,9 +33,10 @@ type listProductsRequest struct {
 //	@Produce		json
 //	@Param			category_id	query		string			false	"Id da categoria"
 //	@Success		200			{array}	pm.ProductResponse			"Produtos listados"
-//	@Failure		400			{object}	ErrorResponse	"Erro de validação"
-//	@Failure		500			{object}	ErrorResponse	"Erro interno"
+//	@Failure		400			{object}	Problem	"Erro de validação"
+//	@Failure		500			{object}	Problem	"Erro interno"
 //	@Router			/products [get]
+//	@Security	BearerAuth
 func (h *ProductHandler) ListProducts(ctx *gin.Context) {
 	var request listProductsRequest
 	var productsList []pm.ProductResponse
@@ -72,8 +73,10 @@ type createProductRequest struct {
 //	@Produce		json
 //	@Param	    createProductRequest	body createProductRequest true "Registrar novo produto body"
 //	@Success		200	{object} pm.ProductResponse	"Produto registrado"
-//	@Failure		400	{object} ErrorResponse	"Erro de validação"
+//	@Header			200	{string} ETag	"Versão do produto"
+//	@Failure		400	{object} Problem	"Erro de validação"
 //	@Router		/products [post]
+//	@Security	BearerAuth
 func (h *ProductHandler) CreateProduct(ctx *gin.Context) {
 	var request createProductRequest
 	if err := ctx.ShouldBindJSON(&request); err != nil {
@@ -97,6 +100,7 @@ func (h *ProductHandler) CreateProduct(ctx *gin.Context) {
 		handleError(ctx, err)
 		return
 	}
+	setETag(ctx, product.Version)
 	handleSuccess(ctx, pm.NewProductResponse(product))
 }
 
@@ -117,10 +121,15 @@ type updateProductRequest struct {
 //	@Produce		json
 //	@Param			id						path		string					true	"Id do produto"
 //	@Param			updateProductRequest	body		updateProductRequest	true	"Atualizar produto body"
+//	@Param			If-Match				header		string					true	"ETag da versão que está sendo alterada"
 //	@Success		200	{object} pm.ProductResponse	"Produto atualizado"
-//	@Failure		404						{object}	ErrorResponse			"Produto nao encontrado"
-//	@Failure		400	{object} ErrorResponse	"Erro de validação"
+//	@Header			200	{string} ETag	"Nova versão do produto"
+//	@Failure		404						{object}	Problem			"Produto nao encontrado"
+//	@Failure		400	{object} Problem	"Erro de validação"
+//	@Failure		412	{object} Problem	"Produto alterado por outra requisição"
+//	@Failure		428	{object} Problem	"Header If-Match ausente"
 //	@Router		/products/{id} [put]
+//	@Security	BearerAuth
 func (h *ProductHandler) UpdateProduct(ctx *gin.Context) {
 	var request updateProductRequest
 	if err := ctx.ShouldBindJSON(&request); err != nil {
@@ -137,6 +146,11 @@ func (h *ProductHandler) UpdateProduct(ctx *gin.Context) {
 		handleError(ctx, fmt.Errorf("invalid product id"))
 		return
 	}
+	version, err := ifMatchVersion(ctx)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
 	updateProduct := dto.UpdateProductDTO{
 		Id:          productId.String(),
 		Name:        request.Name,
@@ -144,12 +158,14 @@ func (h *ProductHandler) UpdateProduct(ctx *gin.Context) {
 		Image:       request.Image,
 		Value:       request.Value,
 		CategoryId:  categoryId.String(),
+		Version:     version,
 	}
 	product, err := h.productController.UpdateProduct(ctx, updateProduct)
 	if err != nil {
 		handleError(ctx, err)
 		return
 	}
+	setETag(ctx, product.Version)
 	handleSuccess(ctx, pm.NewProductResponse(product))
 }
 
@@ -166,9 +182,10 @@ type deleteProductRequest struct {
 //	@Produce		json
 //	@Param			id						path		string					true	"Id do produto"
 //	@Success		200	{object} pm.ProductResponse	"Produto removido"
-//	@Failure		404						{object}	ErrorResponse			"Produto nao encontrado"
-//	@Failure		400	{object} ErrorResponse	"Erro de validação"
+//	@Failure		404						{object}	Problem			"Produto nao encontrado"
+//	@Failure		400	{object} Problem	"Erro de validação"
 //	@Router		/products/{id} [delete]
+//	@Security	BearerAuth
 func (h *ProductHandler) DeleteProduct(ctx *gin.Context) {
 	var request deleteProductRequest
 	if err := ctx.ShouldBindUri(&request); err != nil {
@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+	entity "post-tech-challenge-10soat/internal/entities"
+	interfaces "post-tech-challenge-10soat/internal/interfaces/gateways"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultRateLimitGroup holds the limit used by groups without their own.
+const DefaultRateLimitGroup = "default"
+
+type RateLimitMiddleware struct {
+	rateLimitGateway interfaces.RateLimitGateway
+	limits           map[string]entity.RateLimit
+}
+
+func NewRateLimitMiddleware(rateLimitGateway interfaces.RateLimitGateway, limits map[string]entity.RateLimit) RateLimitMiddleware {
+	return RateLimitMiddleware{
+		rateLimitGateway,
+		limits,
+	}
+}
+
+// Limit applies the group's token bucket to each caller, identified by API
+// key, session subject or IP, and answers 429 once the bucket is empty. The
+// quota is advertised with the RateLimit-* headers. Requests go through when
+// the store fails, so an outage does not take the API down with it.
+func (m *RateLimitMiddleware) Limit(group string) gin.HandlerFunc {
+	limit, ok := m.limits[group]
+	if !ok {
+		limit = m.limits[DefaultRateLimitGroup]
+	}
+	return func(ctx *gin.Context) {
+		if !limit.IsEnabled() {
+			ctx.Next()
+			return
+		}
+		decision, err := m.rateLimitGateway.Take(ctx, group+":"+requestPrincipal(ctx), limit)
+		if err != nil {
+			slog.ErrorContext(ctx, "Error applying rate limit", "group", group, "error", err)
+			ctx.Next()
+			return
+		}
+		ctx.Header("RateLimit-Policy", fmt.Sprintf("%d;w=%d", limit.Requests, seconds(limit.Period)))
+		ctx.Header("RateLimit-Limit", strconv.Itoa(limit.Requests))
+		ctx.Header("RateLimit-Remaining", strconv.Itoa(decision.Remaining))
+		ctx.Header("RateLimit-Reset", strconv.Itoa(seconds(decision.Reset)))
+		if !decision.Allowed {
+			ctx.Header("Retry-After", strconv.Itoa(seconds(decision.RetryAfter)))
+			handleError(ctx, fmt.Errorf("%w: retry in %d seconds", entity.ErrRateLimited, seconds(decision.RetryAfter)))
+			ctx.Abort()
+			return
+		}
+		ctx.Next()
+	}
+}
+
+// seconds rounds up, so a client waiting that long is never rejected again.
+func seconds(duration time.Duration) int {
+	return int(math.Ceil(duration.Seconds()))
+}
@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	entity "post-tech-challenge-10soat/internal/entities"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeRateLimitGateway keeps the buckets in memory with a frozen clock.
+type fakeRateLimitGateway struct {
+	buckets map[string]entity.TokenBucket
+	now     time.Time
+	err     error
+}
+
+func (f *fakeRateLimitGateway) Take(ctx context.Context, key string, limit entity.RateLimit) (entity.RateLimitDecision, error) {
+	if f.err != nil {
+		return entity.RateLimitDecision{}, f.err
+	}
+	bucket, decision := f.buckets[key].Take(limit, f.now)
+	f.buckets[key] = bucket
+	return decision, nil
+}
+
+func setupRateLimitTestRouter(gateway *fakeRateLimitGateway) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	middleware := NewRateLimitMiddleware(gateway, map[string]entity.RateLimit{
+		DefaultRateLimitGroup: {Requests: 100, Period: time.Minute},
+		"auth":                {Requests: 2, Period: time.Minute},
+		"health":              {Requests: 0, Period: time.Minute},
+	})
+	r := gin.New()
+	ok := func(ctx *gin.Context) { ctx.Status(http.StatusOK) }
+	r.POST("/auth/token", middleware.Limit("auth"), ok)
+	r.GET("/products", middleware.Limit("menu"), ok)
+	r.GET("/health", middleware.Limit("health"), ok)
+	return r
+}
+
+func sendRateLimited(r *gin.Engine, method string, path string, ip string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, path, nil)
+	req.RemoteAddr = ip + ":1234"
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestRateLimitMiddleware_Limit_RejectsWhenBucketIsEmpty(t *testing.T) {
+	// Setup
+	gateway := &fakeRateLimitGateway{buckets: map[string]entity.TokenBucket{}, now: time.Now()}
+	r := setupRateLimitTestRouter(gateway)
+
+	// Act
+	first := sendRateLimited(r, "POST", "/auth/token", "10.0.0.1")
+	second := sendRateLimited(r, "POST", "/auth/token", "10.0.0.1")
+	third := sendRateLimited(r, "POST", "/auth/token", "10.0.0.1")
+
+	// Assert
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.Equal(t, "2", first.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "1", first.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "2;w=60", first.Header().Get("RateLimit-Policy"))
+	assert.Equal(t, http.StatusOK, second.Code)
+	assert.Equal(t, "0", second.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "60", second.Header().Get("RateLimit-Reset"))
+	assert.Equal(t, http.StatusTooManyRequests, third.Code)
+	assert.Equal(t, "30", third.Header().Get("Retry-After"))
+}
+
+func TestRateLimitMiddleware_Limit_RefillsOverTime(t *testing.T) {
+	// Setup
+	gateway := &fakeRateLimitGateway{buckets: map[string]entity.TokenBucket{}, now: time.Now()}
+	r := setupRateLimitTestRouter(gateway)
+	sendRateLimited(r, "POST", "/auth/token", "10.0.0.1")
+	sendRateLimited(r, "POST", "/auth/token", "10.0.0.1")
+
+	// Act
+	gateway.now = gateway.now.Add(30 * time.Second)
+	w := sendRateLimited(r, "POST", "/auth/token", "10.0.0.1")
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "0", w.Header().Get("RateLimit-Remaining"))
+}
+
+func TestRateLimitMiddleware_Limit_KeysByCaller(t *testing.T) {
+	// Setup
+	gateway := &fakeRateLimitGateway{buckets: map[string]entity.TokenBucket{}, now: time.Now()}
+	r := setupRateLimitTestRouter(gateway)
+	sendRateLimited(r, "POST", "/auth/token", "10.0.0.1")
+	sendRateLimited(r, "POST", "/auth/token", "10.0.0.1")
+
+	// Act
+	w := sendRateLimited(r, "POST", "/auth/token", "10.0.0.2")
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, gateway.buckets, "auth:anonymous:10.0.0.2")
+}
+
+func TestRateLimitMiddleware_Limit_UsesDefaultLimit(t *testing.T) {
+	// Setup
+	gateway := &fakeRateLimitGateway{buckets: map[string]entity.TokenBucket{}, now: time.Now()}
+	r := setupRateLimitTestRouter(gateway)
+
+	// Act
+	w := sendRateLimited(r, "GET", "/products", "10.0.0.1")
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "100", w.Header().Get("RateLimit-Limit"))
+}
+
+func TestRateLimitMiddleware_Limit_SkipsDisabledGroupsAndStoreErrors(t *testing.T) {
+	// Setup
+	gateway := &fakeRateLimitGateway{buckets: map[string]entity.TokenBucket{}, now: time.Now()}
+	r := setupRateLimitTestRouter(gateway)
+
+	// Act
+	health := sendRateLimited(r, "GET", "/health", "10.0.0.1")
+	gateway.err = errors.New("store unavailable")
+	products := sendRateLimited(r, "GET", "/products", "10.0.0.1")
+
+	// Assert
+	assert.Equal(t, http.StatusOK, health.Code)
+	assert.Empty(t, health.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, http.StatusOK, products.Code)
+	assert.Empty(t, products.Header().Get("RateLimit-Limit"))
+}
@@ -0,0 +1,27 @@
+package handler
+
+import (
+	"post-tech-challenge-10soat/internal/infrastructure/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// RequestId accepts the caller's X-Request-ID or generates one, stores it in
+// the request context for the logs and echoes it in the response.
+func RequestId() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		requestId := ctx.GetHeader(logger.RequestIdHeader)
+		if !logger.IsValidRequestId(requestId) {
+			requestId = uuid.NewString()
+		}
+		ctx.Request = ctx.Request.WithContext(logger.ContextWithRequestId(ctx.Request.Context(), requestId))
+		ctx.Header(logger.RequestIdHeader, requestId)
+		ctx.Next()
+	}
+}
+
+func requestId(ctx *gin.Context) string {
+	requestId, _ := logger.RequestIdFromContext(ctx)
+	return requestId
+}
@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"post-tech-challenge-10soat/internal/infrastructure/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupRequestIdTestRouter(seen *string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.ContextWithFallback = true
+	r.Use(RequestId())
+	r.GET("/ok", func(ctx *gin.Context) {
+		*seen, _ = logger.RequestIdFromContext(ctx.Request.Context())
+		ctx.Status(http.StatusOK)
+	})
+	r.GET("/fail", func(ctx *gin.Context) {
+		handleError(ctx, errors.New("connection refused"))
+	})
+	return r
+}
+
+func TestRequestId_AcceptsCallerId(t *testing.T) {
+	// Setup
+	var seen string
+	r := setupRequestIdTestRouter(&seen)
+	req, _ := http.NewRequest("GET", "/ok", nil)
+	req.Header.Set("X-Request-ID", "kiosk-7:42")
+	w := httptest.NewRecorder()
+
+	// Act
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, "kiosk-7:42", seen)
+	assert.Equal(t, "kiosk-7:42", w.Header().Get("X-Request-ID"))
+}
+
+func TestRequestId_GeneratesIdForMissingOrInvalidHeader(t *testing.T) {
+	for name, header := range map[string]string{"missing": "", "invalid": "bad id\nwith newline"} {
+		t.Run(name, func(t *testing.T) {
+			// Setup
+			var seen string
+			r := setupRequestIdTestRouter(&seen)
+			req, _ := http.NewRequest("GET", "/ok", nil)
+			req.Header.Set("X-Request-ID", header)
+			w := httptest.NewRecorder()
+
+			// Act
+			r.ServeHTTP(w, req)
+
+			// Assert
+			_, err := uuid.Parse(seen)
+			assert.NoError(t, err)
+			assert.Equal(t, seen, w.Header().Get("X-Request-ID"))
+		})
+	}
+}
+
+func TestRequestId_ReturnedInErrorResponse(t *testing.T) {
+	// Setup
+	var seen string
+	r := setupRequestIdTestRouter(&seen)
+	req, _ := http.NewRequest("GET", "/fail", nil)
+	req.Header.Set("X-Request-ID", "req-1")
+	w := httptest.NewRecorder()
+
+	// Act
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	var response ErrorResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "req-1", response.RequestId)
+}
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"errors"
+	"log/slog"
 	"net/http"
 	entity "post-tech-challenge-10soat/internal/entities"
 
@@ -9,22 +10,46 @@ import (
 	"github.com/go-playground/validator/v10"
 )
 
-var errorStatusMap = map[error]int{
-	entity.ErrInternal:        http.StatusInternalServerError,
-	entity.ErrDataNotFound:    http.StatusNotFound,
-	entity.ErrConflictingData: http.StatusConflict,
-	entity.ErrForbidden:       http.StatusForbidden,
+// errorStatuses is a slice so an error wrapping several sentinels always
+// gets the status of the first one listed.
+var errorStatuses = []struct {
+	target error
+	status int
+}{
+	{entity.ErrInternal, http.StatusInternalServerError},
+	{entity.ErrInvalidData, http.StatusBadRequest},
+	{entity.ErrDataNotFound, http.StatusNotFound},
+	{entity.ErrConflictingData, http.StatusConflict},
+	{entity.ErrUnauthorized, http.StatusUnauthorized},
+	{entity.ErrForbidden, http.StatusForbidden},
+	{entity.ErrNoUpdatedData, http.StatusBadRequest},
+	{entity.ErrRateLimited, http.StatusTooManyRequests},
+	{entity.ErrPreconditionFailed, http.StatusPreconditionFailed},
+	{entity.ErrPreconditionRequired, http.StatusPreconditionRequired},
 }
 
 func handleError(ctx *gin.Context, err error) {
-	statusCode, ok := errorStatusMap[err]
-	if !ok {
-		statusCode = http.StatusInternalServerError
+	statusCode := http.StatusInternalServerError
+	for _, errorStatus := range errorStatuses {
+		if errors.Is(err, errorStatus.target) {
+			statusCode = errorStatus.status
+			break
+		}
+	}
+
+	// Clients only see the message, the log line carries the full error and
+	// the request id returned to them.
+	if statusCode >= http.StatusInternalServerError {
+		slog.ErrorContext(ctx, "Error handling request", "error", err)
 	}
 
-	errMsg := parseError(err)
-	errRsp := newErrorResponse(errMsg)
-	ctx.JSON(statusCode, errRsp)
+	if wantsLegacyErrors(ctx) {
+		errMsg := parseError(err)
+		errRsp := newErrorResponse(requestId(ctx), errMsg)
+		ctx.JSON(statusCode, errRsp)
+		return
+	}
+	writeProblem(ctx, newProblem(ctx, statusCode, entity.ErrorCodeOf(err), err.Error()))
 }
 
 func handleSuccess(ctx *gin.Context, data any) {
@@ -33,9 +58,20 @@ func handleSuccess(ctx *gin.Context, data any) {
 }
 
 func validationError(ctx *gin.Context, err error) {
-	errMsgs := parseError(err)
-	errRsp := newErrorResponse(errMsgs)
-	ctx.JSON(http.StatusBadRequest, errRsp)
+	if wantsLegacyErrors(ctx) {
+		errMsgs := parseError(err)
+		errRsp := newErrorResponse(requestId(ctx), errMsgs)
+		ctx.JSON(http.StatusBadRequest, errRsp)
+		return
+	}
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		writeProblem(ctx, newProblem(ctx, http.StatusBadRequest, entity.ErrCodeInvalidData, err.Error()))
+		return
+	}
+	problem := newProblem(ctx, http.StatusBadRequest, entity.ErrCodeValidationFailed, "request has invalid fields")
+	problem.Errors = newFieldErrors(validationErrs)
+	writeProblem(ctx, problem)
 }
 
 func parseError(err error) []string {
@@ -66,14 +102,18 @@ func newResponse(success bool, message string, data any) response {
 	}
 }
 
+// ErrorResponse is the error envelope used before problem details, still
+// returned to clients that ask for application/json.
 type ErrorResponse struct {
-	Success  bool     `json:"success" example:"false"`
-	Messages []string `json:"messages" example:"Error message 1, Error message 2"`
+	Success   bool     `json:"success" example:"false"`
+	Messages  []string `json:"messages" example:"Error message 1, Error message 2"`
+	RequestId string   `json:"request_id,omitempty" example:"6f1c2a8e-3b4d-4e5f-9a0b-1c2d3e4f5a6b"`
 }
 
-func newErrorResponse(errMsgs []string) ErrorResponse {
+func newErrorResponse(requestId string, errMsgs []string) ErrorResponse {
 	return ErrorResponse{
-		Success:  false,
-		Messages: errMsgs,
+		Success:   false,
+		Messages:  errMsgs,
+		RequestId: requestId,
 	}
 }
@@ -3,6 +3,7 @@ package handler
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -13,7 +14,8 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
-// TestHandleError tests the handleError function with different error types
+// TestHandleError tests the legacy envelope, still returned to clients that
+// ask for application/json, with different error types
 func TestHandleError(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -45,6 +47,18 @@ func TestHandleError(t *testing.T) {
 			expectedStatus: http.StatusForbidden,
 			expectedMsg:    entity.ErrForbidden.Error(),
 		},
+		{
+			name:           "wrapped not found error",
+			err:            fmt.Errorf("failed to get client by cpf - %w", entity.ErrDataNotFound),
+			expectedStatus: http.StatusNotFound,
+			expectedMsg:    "failed to get client by cpf - " + entity.ErrDataNotFound.Error(),
+		},
+		{
+			name:           "wrapped conflict error",
+			err:            fmt.Errorf("%w: clients_cpf_key", entity.ErrConflictingData),
+			expectedStatus: http.StatusConflict,
+			expectedMsg:    entity.ErrConflictingData.Error() + ": clients_cpf_key",
+		},
 		{
 			name:           "unknown error",
 			err:            errors.New("some unknown error"),
@@ -58,6 +72,8 @@ func TestHandleError(t *testing.T) {
 			// Setup
 			w := httptest.NewRecorder()
 			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = httptest.NewRequest("GET", "/", nil)
+			ctx.Request.Header.Set("Accept", "application/json")
 
 			// Execute
 			handleError(ctx, tt.err)
@@ -114,8 +130,9 @@ func TestNewResponse(t *testing.T) {
 // TestNewErrorResponse tests the newErrorResponse function
 func TestNewErrorResponse(t *testing.T) {
 	errMsgs := []string{"error 1", "error 2"}
-	errRsp := newErrorResponse(errMsgs)
+	errRsp := newErrorResponse("request-1", errMsgs)
 
 	assert.False(t, errRsp.Success)
 	assert.Equal(t, errMsgs, errRsp.Messages)
+	assert.Equal(t, "request-1", errRsp.RequestId)
 }
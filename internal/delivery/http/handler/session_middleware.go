@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"fmt"
+	entity "post-tech-challenge-10soat/internal/entities"
+	interfaces "post-tech-challenge-10soat/internal/interfaces/gateways"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SessionMiddleware struct {
+	tokenGateway interfaces.TokenGateway
+}
+
+func NewSessionMiddleware(tokenGateway interfaces.TokenGateway) SessionMiddleware {
+	return SessionMiddleware{
+		tokenGateway,
+	}
+}
+
+// Authenticate resolves an optional bearer token into the request context.
+// Requests without a token go through anonymously and the use cases decide
+// whether a session is required.
+func (m *SessionMiddleware) Authenticate(ctx *gin.Context) {
+	header := ctx.GetHeader("Authorization")
+	if header == "" {
+		ctx.Next()
+		return
+	}
+	scheme, token, found := strings.Cut(header, " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		handleError(ctx, fmt.Errorf("%w: malformed authorization header", entity.ErrUnauthorized))
+		ctx.Abort()
+		return
+	}
+	session, err := m.tokenGateway.ParseToken(ctx, token)
+	if err != nil {
+		handleError(ctx, err)
+		ctx.Abort()
+		return
+	}
+	ctx.Request = ctx.Request.WithContext(entity.ContextWithSession(ctx.Request.Context(), session))
+	ctx.Next()
+}
@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	entity "post-tech-challenge-10soat/internal/entities"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockTokenGateway struct {
+	sessions map[string]entity.Session
+}
+
+func (m mockTokenGateway) IssueToken(ctx context.Context, session entity.Session) (entity.Token, error) {
+	return entity.Token{}, nil
+}
+
+func (m mockTokenGateway) ParseToken(ctx context.Context, token string) (entity.Session, error) {
+	session, ok := m.sessions[token]
+	if !ok {
+		return entity.Session{}, entity.ErrUnauthorized
+	}
+	return session, nil
+}
+
+func setupSessionTestRouter(middleware *SessionMiddleware) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.ContextWithFallback = true
+	r.GET("/whoami", middleware.Authenticate, func(ctx *gin.Context) {
+		session, ok := entity.SessionFromContext(ctx)
+		if !ok {
+			ctx.String(http.StatusOK, "anonymous")
+			return
+		}
+		ctx.String(http.StatusOK, session.Subject)
+	})
+	return r
+}
+
+func TestSessionMiddleware_Authenticate(t *testing.T) {
+	middleware := NewSessionMiddleware(mockTokenGateway{
+		sessions: map[string]entity.Session{
+			"valid": {Subject: "client-1", Role: entity.RoleCustomer, ExpiresAt: time.Now().Add(time.Minute)},
+		},
+	})
+	r := setupSessionTestRouter(&middleware)
+
+	tests := []struct {
+		name           string
+		header         string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{name: "anonymous", header: "", expectedStatus: http.StatusOK, expectedBody: "anonymous"},
+		{name: "valid token", header: "Bearer valid", expectedStatus: http.StatusOK, expectedBody: "client-1"},
+		{name: "invalid token", header: "Bearer invalid", expectedStatus: http.StatusUnauthorized},
+		{name: "malformed header", header: "Basic abc", expectedStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", "/whoami", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedBody != "" {
+				assert.Equal(t, tt.expectedBody, w.Body.String())
+			}
+		})
+	}
+}
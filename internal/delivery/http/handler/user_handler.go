@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"post-tech-challenge-10soat/internal/controllers"
+	cm "post-tech-challenge-10soat/internal/delivery/http/mapper"
+	dto "post-tech-challenge-10soat/internal/dto/user"
+
+	"github.com/gin-gonic/gin"
+)
+
+type UserHandler struct {
+	userController controllers.UserController
+}
+
+func NewUserHandler(userController controllers.UserController) UserHandler {
+	return UserHandler{
+		userController: userController,
+	}
+}
+
+type createUserRequest struct {
+	Username string `json:"username" binding:"required,min=3" example:"cozinha-01"`
+	Password string `json:"password" binding:"required,min=8,max=72" example:"s3nh4-f0rt3"`
+	Role     string `json:"role" binding:"required,oneof=kiosk kitchen admin" example:"kitchen"`
+}
+
+// CreateUser godoc
+//
+//	@Summary     Registra um usuário da equipe
+//	@Description Registra um usuário de totem, cozinha ou administração
+//	@Tags        Users
+//	@Accept      json
+//	@Produce		json
+//	@Security    BearerAuth
+//	@Param	    createUserRequest	body createUserRequest true "Registrar usuário request"
+//	@Success		200	{object} cm.UserResponse	"Usuário registrado"
+//	@Failure		400	{object} Problem	"Erro de validação"
+//	@Failure		401	{object} Problem	"Token ausente ou inválido"
+//	@Failure		403	{object} Problem	"Perfil sem permissão"
+//	@Failure		409	{object} Problem	"Usuário já existe"
+//	@Router		/users [post]
+func (h *UserHandler) CreateUser(ctx *gin.Context) {
+	var request createUserRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		validationError(ctx, err)
+		return
+	}
+	newUser := dto.CreateUserDTO{
+		Username: request.Username,
+		Password: request.Password,
+		Role:     request.Role,
+	}
+	createdUser, err := h.userController.CreateUser(ctx, newUser)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	response := cm.NewUserResponse(createdUser)
+	handleSuccess(ctx, response)
+}
+
+type issueTokenRequest struct {
+	Username string `json:"username" binding:"required" example:"cozinha-01"`
+	Password string `json:"password" binding:"required" example:"s3nh4-f0rt3"`
+}
+
+// IssueToken godoc
+//
+//	@Summary     Gera um token de acesso da equipe
+//	@Description Autentica um usuário da equipe e retorna um token JWT com o seu perfil
+//	@Tags        Auth
+//	@Accept      json
+//	@Produce		json
+//	@Param	    issueTokenRequest	body issueTokenRequest true "Credenciais"
+//	@Success		200	{object} cm.TokenResponse	"Token de acesso"
+//	@Failure		400	{object} Problem	"Erro de validação"
+//	@Failure		401	{object} Problem	"Credenciais inválidas"
+//	@Failure		403	{object} Problem	"Usuário desativado"
+//	@Router		/auth/token [post]
+func (h *UserHandler) IssueToken(ctx *gin.Context) {
+	var request issueTokenRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		validationError(ctx, err)
+		return
+	}
+	token, err := h.userController.IssueToken(ctx, request.Username, request.Password)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	response := cm.NewTokenResponse(token)
+	handleSuccess(ctx, response)
+}
@@ -0,0 +1,169 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"post-tech-challenge-10soat/internal/controllers"
+	dto "post-tech-challenge-10soat/internal/dto/user"
+	entity "post-tech-challenge-10soat/internal/entities"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockUserController is a mock of UserController interface
+type MockUserController struct {
+	mock.Mock
+}
+
+var _ controllers.UserController = (*MockUserController)(nil)
+
+func (m *MockUserController) CreateUser(ctx context.Context, createUser dto.CreateUserDTO) (entity.User, error) {
+	args := m.Called(ctx, createUser)
+	return args.Get(0).(entity.User), args.Error(1)
+}
+
+func (m *MockUserController) IssueToken(ctx context.Context, username string, password string) (entity.Token, error) {
+	args := m.Called(ctx, username, password)
+	return args.Get(0).(entity.Token), args.Error(1)
+}
+
+func setupUserTestRouter(handler *UserHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.POST("/users", handler.CreateUser)
+	r.POST("/auth/token", handler.IssueToken)
+	return r
+}
+
+func TestUserHandler_CreateUser_Success(t *testing.T) {
+	// Setup
+	mockCtrl := &MockUserController{}
+	handler := &UserHandler{
+		userController: mockCtrl,
+	}
+	r := setupUserTestRouter(handler)
+
+	expectedUser := dto.CreateUserDTO{Username: "cozinha-01", Password: "s3nh4-f0rt3", Role: "kitchen"}
+	mockCtrl.On("CreateUser", mock.Anything, expectedUser).
+		Return(entity.User{Id: "u1", Username: "cozinha-01", Role: entity.RoleKitchen, Active: true}, nil)
+
+	// Test request
+	jsonValue, _ := json.Marshal(map[string]string{"username": "cozinha-01", "password": "s3nh4-f0rt3", "role": "kitchen"})
+	req, _ := http.NewRequest("POST", "/users", bytes.NewBuffer(jsonValue))
+	req.Header.Set("Content-Type", "application/json")
+
+	// Act
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Data struct {
+			ID       string `json:"id"`
+			Role     string `json:"role"`
+			Password string `json:"password"`
+		} `json:"data"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "u1", response.Data.ID)
+	assert.Equal(t, "kitchen", response.Data.Role)
+	assert.Empty(t, response.Data.Password)
+
+	mockCtrl.AssertExpectations(t)
+}
+
+func TestUserHandler_CreateUser_InvalidRole(t *testing.T) {
+	// Setup
+	mockCtrl := &MockUserController{}
+	handler := &UserHandler{
+		userController: mockCtrl,
+	}
+	r := setupUserTestRouter(handler)
+
+	// Test request
+	jsonValue, _ := json.Marshal(map[string]string{"username": "cliente", "password": "s3nh4-f0rt3", "role": "customer"})
+	req, _ := http.NewRequest("POST", "/users", bytes.NewBuffer(jsonValue))
+	req.Header.Set("Content-Type", "application/json")
+
+	// Act
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockCtrl.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything)
+}
+
+func TestUserHandler_IssueToken_Success(t *testing.T) {
+	// Setup
+	mockCtrl := &MockUserController{}
+	handler := &UserHandler{
+		userController: mockCtrl,
+	}
+	r := setupUserTestRouter(handler)
+
+	mockCtrl.On("IssueToken", mock.Anything, "cozinha-01", "s3nh4-f0rt3").
+		Return(entity.Token{AccessToken: "token", ExpiresAt: time.Now().Add(8 * time.Hour)}, nil)
+
+	// Test request
+	jsonValue, _ := json.Marshal(map[string]string{"username": "cozinha-01", "password": "s3nh4-f0rt3"})
+	req, _ := http.NewRequest("POST", "/auth/token", bytes.NewBuffer(jsonValue))
+	req.Header.Set("Content-Type", "application/json")
+
+	// Act
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Data struct {
+			AccessToken string `json:"access_token"`
+			TokenType   string `json:"token_type"`
+		} `json:"data"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "token", response.Data.AccessToken)
+	assert.Equal(t, "Bearer", response.Data.TokenType)
+
+	mockCtrl.AssertExpectations(t)
+}
+
+func TestUserHandler_IssueToken_InvalidCredentials(t *testing.T) {
+	// Setup
+	mockCtrl := &MockUserController{}
+	handler := &UserHandler{
+		userController: mockCtrl,
+	}
+	r := setupUserTestRouter(handler)
+
+	mockCtrl.On("IssueToken", mock.Anything, "cozinha-01", "errada").
+		Return(entity.Token{}, fmt.Errorf("%w: invalid credentials", entity.ErrUnauthorized))
+
+	// Test request
+	jsonValue, _ := json.Marshal(map[string]string{"username": "cozinha-01", "password": "errada"})
+	req, _ := http.NewRequest("POST", "/auth/token", bytes.NewBuffer(jsonValue))
+	req.Header.Set("Content-Type", "application/json")
+
+	// Act
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	mockCtrl.AssertExpectations(t)
+}
@@ -0,0 +1,51 @@
+package mapper
+
+import (
+	entity "post-tech-challenge-10soat/internal/entities"
+	"time"
+)
+
+type ApiKeyResponse struct {
+	ID         string     `json:"id" example:"ed6ac028-8016-4cbd-aeee-c3a155cdb2a4"`
+	Name       string     `json:"name" example:"totem-loja-01"`
+	Prefix     string     `json:"prefix" example:"3f9a1c2b7d4e"`
+	Scopes     []string   `json:"scopes" example:"catalog:read,orders:write"`
+	Key        string     `json:"key,omitempty" example:"ptk_3f9a1c2b7d4e_..."`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty" example:"1970-01-01T00:00:00Z"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty" example:"1970-01-01T00:00:00Z"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty" example:"1970-01-01T00:00:00Z"`
+	CreatedAt  time.Time  `json:"created_at" example:"1970-01-01T00:00:00Z"`
+}
+
+func NewApiKeyResponse(apiKey entity.ApiKey) ApiKeyResponse {
+	scopes := make([]string, 0, len(apiKey.Scopes))
+	for _, scope := range apiKey.Scopes {
+		scopes = append(scopes, string(scope))
+	}
+	return ApiKeyResponse{
+		ID:         apiKey.Id,
+		Name:       apiKey.Name,
+		Prefix:     apiKey.Prefix,
+		Scopes:     scopes,
+		Key:        apiKey.Secret,
+		ExpiresAt:  optionalTime(apiKey.ExpiresAt),
+		LastUsedAt: optionalTime(apiKey.LastUsedAt),
+		RevokedAt:  optionalTime(apiKey.RevokedAt),
+		CreatedAt:  apiKey.CreatedAt,
+	}
+}
+
+func NewApiKeysResponse(apiKeys []entity.ApiKey) []ApiKeyResponse {
+	response := make([]ApiKeyResponse, 0, len(apiKeys))
+	for _, apiKey := range apiKeys {
+		response = append(response, NewApiKeyResponse(apiKey))
+	}
+	return response
+}
+
+func optionalTime(value time.Time) *time.Time {
+	if value.IsZero() {
+		return nil
+	}
+	return &value
+}
@@ -2,21 +2,73 @@ package mapper
 
 import (
 	entity "post-tech-challenge-10soat/internal/entities"
-	"post-tech-challenge-10soat/internal/utils"
-
-	"github.com/google/uuid"
+	"time"
 )
 
 type ClientResponse struct {
-	ID    uuid.UUID `json:"id" example:"ed6ac028-8016-4cbd-aeee-c3a155cdb2a4"`
-	Name  string    `json:"name" example:"John Doe"`
-	Email string    `json:"email" example:"john-doe@email.com"`
+	ID    string `json:"id" example:"6650b3f1e4b0a1c2d3e4f567"`
+	Name  string `json:"name" example:"John Doe"`
+	Email string `json:"email" example:"john-doe@email.com"`
 }
 
 func NewClientResponse(client entity.Client) ClientResponse {
 	return ClientResponse{
-		ID:    utils.StringToUuid(client.Id),
+		ID:    client.Id,
 		Name:  client.Name,
 		Email: client.Email,
 	}
 }
+
+type ClientIdentificationResponse struct {
+	ID          string    `json:"identification_id" example:"ed6ac028-8016-4cbd-aeee-c3a155cdb2a4"`
+	Destination string    `json:"destination" example:"j***@email.com"`
+	ExpiresAt   time.Time `json:"expires_at" example:"1970-01-01T00:00:00Z"`
+}
+
+func NewClientIdentificationResponse(identification entity.ClientIdentification) ClientIdentificationResponse {
+	return ClientIdentificationResponse{
+		ID:          identification.Id,
+		Destination: identification.Destination,
+		ExpiresAt:   identification.ExpiresAt,
+	}
+}
+
+type ClientSessionResponse struct {
+	AccessToken string    `json:"access_token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	TokenType   string    `json:"token_type" example:"Bearer"`
+	ExpiresAt   time.Time `json:"expires_at" example:"1970-01-01T00:00:00Z"`
+}
+
+func NewClientSessionResponse(token entity.Token) ClientSessionResponse {
+	return ClientSessionResponse{
+		AccessToken: token.AccessToken,
+		TokenType:   "Bearer",
+		ExpiresAt:   token.ExpiresAt,
+	}
+}
+
+type ClientConsentResponse struct {
+	Purpose       string    `json:"purpose" example:"marketing_email"`
+	Granted       bool      `json:"granted" example:"true"`
+	Source        string    `json:"source" example:"kiosk"`
+	PolicyVersion string    `json:"policy_version" example:"2024-01"`
+	UpdatedAt     time.Time `json:"updated_at" example:"1970-01-01T00:00:00Z"`
+}
+
+func NewClientConsentResponse(consent entity.ClientConsent) ClientConsentResponse {
+	return ClientConsentResponse{
+		Purpose:       string(consent.Purpose),
+		Granted:       consent.Granted,
+		Source:        consent.Source,
+		PolicyVersion: consent.PolicyVersion,
+		UpdatedAt:     consent.CreatedAt,
+	}
+}
+
+func NewClientConsentsResponse(consents []entity.ClientConsent) []ClientConsentResponse {
+	response := make([]ClientConsentResponse, 0, len(consents))
+	for _, consent := range consents {
+		response = append(response, NewClientConsentResponse(consent))
+	}
+	return response
+}
@@ -0,0 +1,34 @@
+package mapper
+
+import (
+	entity "post-tech-challenge-10soat/internal/entities"
+)
+
+type DependencyHealthResponse struct {
+	Name      string              `json:"name" example:"postgres"`
+	Required  bool                `json:"required" example:"true"`
+	Status    entity.HealthStatus `json:"status" example:"up"`
+	LatencyMs float64             `json:"latency_ms" example:"1.25"`
+	Error     string              `json:"error,omitempty" example:"context deadline exceeded"`
+}
+
+type HealthResponse struct {
+	Status       entity.HealthStatus        `json:"status" example:"up"`
+	Dependencies []DependencyHealthResponse `json:"dependencies,omitempty"`
+}
+
+func NewHealthResponse(report entity.HealthReport) HealthResponse {
+	response := HealthResponse{
+		Status: report.Status,
+	}
+	for _, dependency := range report.Dependencies {
+		response.Dependencies = append(response.Dependencies, DependencyHealthResponse{
+			Name:      dependency.Name,
+			Required:  dependency.Required,
+			Status:    dependency.Status,
+			LatencyMs: float64(dependency.Latency.Microseconds()) / 1000,
+			Error:     dependency.Error,
+		})
+	}
+	return response
+}
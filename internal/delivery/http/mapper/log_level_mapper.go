@@ -0,0 +1,11 @@
+package mapper
+
+type LogLevelResponse struct {
+	Level string `json:"level" example:"info"`
+}
+
+func NewLogLevelResponse(level string) LogLevelResponse {
+	return LogLevelResponse{
+		Level: level,
+	}
+}
@@ -0,0 +1,46 @@
+package mapper
+
+import (
+	entity "post-tech-challenge-10soat/internal/entities"
+)
+
+type MenuImportRowResponse struct {
+	Row    int                     `json:"row" example:"2"`
+	Sku    string                  `json:"sku" example:"LANCHE-BACON"`
+	Action entity.MenuImportAction `json:"action" example:"create"`
+	Errors []string                `json:"errors,omitempty" example:"value must be greater than 0"`
+}
+
+type MenuImportResponse struct {
+	DryRun            bool                    `json:"dry_run" example:"false"`
+	Applied           bool                    `json:"applied" example:"true"`
+	CreatedCategories []string                `json:"created_categories" example:"Sobremesa"`
+	Created           int                     `json:"created" example:"3"`
+	Updated           int                     `json:"updated" example:"1"`
+	Unchanged         int                     `json:"unchanged" example:"8"`
+	Invalid           int                     `json:"invalid" example:"0"`
+	Rows              []MenuImportRowResponse `json:"rows"`
+}
+
+func NewMenuImportResponse(report entity.MenuImport) MenuImportResponse {
+	response := MenuImportResponse{
+		DryRun:            report.DryRun,
+		Applied:           report.Applied,
+		CreatedCategories: []string{},
+		Created:           report.Created,
+		Updated:           report.Updated,
+		Unchanged:         report.Unchanged,
+		Invalid:           report.Invalid,
+		Rows:              make([]MenuImportRowResponse, 0, len(report.Rows)),
+	}
+	response.CreatedCategories = append(response.CreatedCategories, report.CreatedCategories...)
+	for _, row := range report.Rows {
+		response.Rows = append(response.Rows, MenuImportRowResponse{
+			Row:    row.Row,
+			Sku:    row.Sku,
+			Action: row.Action,
+			Errors: row.Errors,
+		})
+	}
+	return response
+}
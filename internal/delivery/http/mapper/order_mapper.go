@@ -12,9 +12,10 @@ import (
 type OrderResponse struct {
 	Id        uuid.UUID          `json:"id" example:"ed6ac028-8016-4cbd-aeee-c3a155cdb2a4"`
 	Number    int                `json:"number" example:"123"`
-	ClientId  uuid.UUID          `json:"client_id" example:"ed6ac028-8016-4cbd-aeee-c3a155cdb2a4"`
+	ClientId  string             `json:"client_id,omitempty" example:"6650b3f1e4b0a1c2d3e4f567"`
 	Total     float64            `json:"total" example:"100.90"`
 	Status    entity.OrderStatus `json:"status" example:"received"`
+	Version   int                `json:"version" example:"1"`
 	CreatedAt time.Time          `json:"created_at" example:"1970-01-01T00:00:00Z"`
 	UpdatedAt time.Time          `json:"updated_at" example:"1970-01-01T00:00:00Z"`
 }
@@ -23,14 +24,13 @@ func NewOrderResponse(order entity.Order) OrderResponse {
 	orderResponse := OrderResponse{
 		Id:        utils.StringToUuid(order.Id),
 		Number:    order.Number,
+		ClientId:  order.ClientId,
 		Total:     order.Total,
 		Status:    order.Status,
+		Version:   order.Version,
 		CreatedAt: order.CreatedAt,
 		UpdatedAt: order.UpdatedAt,
 	}
-	if order.ClientId != "" {
-		orderResponse.ClientId = utils.StringToUuid(order.ClientId)
-	}
 	return orderResponse
 }
 
@@ -60,6 +60,7 @@ func NewOrderPaymentStatusResponse(order order.OrderPaymentStatus) OrderPaymentS
 type UpdateOrderStatusResponse struct {
 	Id        uuid.UUID          `json:"id" example:"ed6ac028-8016-4cbd-aeee-c3a155cdb2a4"`
 	Status    entity.OrderStatus `json:"status" example:"received"`
+	Version   int                `json:"version" example:"1"`
 	CreatedAt time.Time          `json:"created_at" example:"1970-01-01T00:00:00Z"`
 	UpdatedAt time.Time          `json:"updated_at" example:"1970-01-01T00:00:00Z"`
 }
@@ -68,6 +69,7 @@ func NewOrderUpdateStatusResponse(order entity.Order) UpdateOrderStatusResponse
 	orderResponse := UpdateOrderStatusResponse{
 		Id:        utils.StringToUuid(order.Id),
 		Status:    order.Status,
+		Version:   order.Version,
 		CreatedAt: order.CreatedAt,
 		UpdatedAt: order.UpdatedAt,
 	}
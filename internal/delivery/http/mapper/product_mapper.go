@@ -10,11 +10,13 @@ import (
 
 type ProductResponse struct {
 	ID          uuid.UUID        `json:"id" example:"ed6ac028-8016-4cbd-aeee-c3a155cdb2a4"`
+	Sku         string           `json:"sku" example:"LANCHE-BACON"`
 	Name        string           `json:"name" example:"Lanche 1"`
 	Description string           `json:"description" example:"Lanche com bacon"`
 	Image       string           `json:"image" example:"https://"`
 	Value       float64          `json:"value" example:"10.90"`
 	Category    CategoryResponse `json:"category"`
+	Version     int              `json:"version" example:"1"`
 	CreatedAt   time.Time        `json:"created_at" example:"1970-01-01T00:00:00Z"`
 	UpdatedAt   time.Time        `json:"updated_at" example:"1970-01-01T00:00:00Z"`
 }
@@ -22,11 +24,13 @@ type ProductResponse struct {
 func NewProductResponse(product entity.Product) ProductResponse {
 	return ProductResponse{
 		ID:          utils.StringToUuid(product.Id),
+		Sku:         product.Sku,
 		Name:        product.Name,
 		Description: product.Description,
 		Image:       product.Image,
 		Value:       product.Value,
 		Category:    NewCategoryResponse(product.Category),
+		Version:     product.Version,
 		CreatedAt:   product.CreatedAt,
 		UpdatedAt:   product.UpdatedAt,
 	}
@@ -0,0 +1,38 @@
+package mapper
+
+import (
+	entity "post-tech-challenge-10soat/internal/entities"
+	"time"
+)
+
+type UserResponse struct {
+	ID        string    `json:"id" example:"ed6ac028-8016-4cbd-aeee-c3a155cdb2a4"`
+	Username  string    `json:"username" example:"cozinha-01"`
+	Role      string    `json:"role" example:"kitchen"`
+	Active    bool      `json:"active" example:"true"`
+	CreatedAt time.Time `json:"created_at" example:"1970-01-01T00:00:00Z"`
+}
+
+func NewUserResponse(user entity.User) UserResponse {
+	return UserResponse{
+		ID:        user.Id,
+		Username:  user.Username,
+		Role:      string(user.Role),
+		Active:    user.Active,
+		CreatedAt: user.CreatedAt,
+	}
+}
+
+type TokenResponse struct {
+	AccessToken string    `json:"access_token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	TokenType   string    `json:"token_type" example:"Bearer"`
+	ExpiresAt   time.Time `json:"expires_at" example:"1970-01-01T00:00:00Z"`
+}
+
+func NewTokenResponse(token entity.Token) TokenResponse {
+	return TokenResponse{
+		AccessToken: token.AccessToken,
+		TokenType:   "Bearer",
+		ExpiresAt:   token.ExpiresAt,
+	}
+}
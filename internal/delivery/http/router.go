@@ -1,16 +1,22 @@
 package handler
 
 import (
+	"fmt"
 	"log/slog"
+	"net/http"
 	"os"
 	"path/filepath"
+	"post-tech-challenge-10soat/internal/delivery/graph"
 	handler "post-tech-challenge-10soat/internal/delivery/http/handler"
+	entity "post-tech-challenge-10soat/internal/entities"
 	"post-tech-challenge-10soat/internal/infrastructure/config"
+	"post-tech-challenge-10soat/internal/infrastructure/logger"
 	"strings"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	sloggin "github.com/samber/slog-gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
@@ -26,6 +32,16 @@ func NewRouter(
 	clientHandler handler.ClientHandler,
 	productHandler handler.ProductHandler,
 	orderHandler handler.OrderHandler,
+	userHandler handler.UserHandler,
+	apiKeyHandler handler.ApiKeyHandler,
+	sessionMiddleware handler.SessionMiddleware,
+	apiKeyMiddleware handler.ApiKeyMiddleware,
+	idempotencyMiddleware handler.IdempotencyMiddleware,
+	rateLimitMiddleware handler.RateLimitMiddleware,
+	metricsHandler handler.MetricsHandler,
+	logLevelHandler handler.LogLevelHandler,
+	menuHandler handler.MenuHandler,
+	graphHandler graph.Handler,
 ) (*Router, error) {
 	if config.Env == "production" {
 		gin.SetMode(gin.ReleaseMode)
@@ -34,9 +50,28 @@ func NewRouter(
 	allowedOrigins := config.AllowedOrigins
 	originsList := strings.Split(allowedOrigins, ",")
 	ginConfig.AllowOrigins = originsList
+	ginConfig.AllowHeaders = append(ginConfig.AllowHeaders, "Authorization", "X-API-Key", logger.RequestIdHeader, "If-Match", "Idempotency-Key")
+	ginConfig.ExposeHeaders = []string{logger.RequestIdHeader, "ETag", "Idempotent-Replayed", "Retry-After", "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "RateLimit-Policy"}
+
+	handler.UseRequestFieldNames()
 
 	router := gin.New()
-	router.Use(sloggin.New(slog.Default()), gin.Recovery(), cors.New(ginConfig))
+	// Without trusted proxies anyone could pick the IP that anonymous
+	// callers are rate limited by with X-Forwarded-For.
+	if err := router.SetTrustedProxies(trustedProxies(config.TrustedProxies)); err != nil {
+		return nil, fmt.Errorf("invalid HTTP_TRUSTED_PROXIES - %w", err)
+	}
+	// Lets use cases read values stored in the request context, such as the session.
+	router.ContextWithFallback = true
+	// The request id comes first so the access log and every record written
+	// while serving the request carry it. The span starts right after, so
+	// queries made by controllers and use cases with the request context
+	// are nested under it.
+	router.Use(handler.RequestId(), otelgin.Middleware("", otelgin.WithFilter(traced)), metricsHandler.Observe, sloggin.NewWithConfig(slog.Default(), sloggin.Config{
+		DefaultLevel:     slog.LevelInfo,
+		ClientErrorLevel: slog.LevelWarn,
+		ServerErrorLevel: slog.LevelError,
+	}), gin.Recovery(), cors.New(ginConfig))
 
 	wd, err := os.Getwd()
 	if err != nil {
@@ -49,30 +84,93 @@ func NewRouter(
 
 	router.GET("/docs/*any", ginSwagger.WrapHandler(swaggerFiles.Handler, ginSwagger.URL("/swagger.json")))
 
-	v1 := router.Group("/v1")
+	// Probes for the orchestrator, outside /v1 so they never depend on
+	// credentials or rate limits.
+	probes := router.Group("/health")
 	{
+		probes.GET("/live", healthHandler.Live)
+		probes.GET("/ready", healthHandler.Ready)
+	}
+	router.GET("/metrics", metricsHandler.Metrics)
+
+	// Resolvers authorize each field themselves, with the same roles and
+	// scopes as the matching REST routes.
+	router.POST("/graphql", sessionMiddleware.Authenticate, apiKeyMiddleware.Authenticate, rateLimitMiddleware.Limit("graphql"), graphHandler.Query)
+
+	// Every group declares the roles, and the API key scope when devices may
+	// call it. Groups without a requirement are public. Groups are rate
+	// limited by name, falling back to the default limit, except health
+	// checks. The order and catalog groups honour the Idempotency-Key header
+	// once the caller is authorized and within its limit. It stays off the
+	// groups issuing credentials, so tokens and API keys are never stored
+	// with the responses.
+	v1 := router.Group("/v1", sessionMiddleware.Authenticate, apiKeyMiddleware.Authenticate)
+	{
+		// Kept for clients of the old route, answering like the readiness probe.
 		health := v1.Group("/health")
 		{
-			health.GET("/", healthHandler.HealthCheck)
+			health.GET("/", healthHandler.Ready)
+		}
+		auth := v1.Group("/auth", rateLimitMiddleware.Limit("auth"))
+		{
+			auth.POST("/token", userHandler.IssueToken)
+		}
+		user := v1.Group("/users", rateLimitMiddleware.Limit("users"), handler.RequireRoles(entity.RoleAdmin))
+		{
+			user.POST("/", userHandler.CreateUser)
+		}
+		apiKey := v1.Group("/api-keys", rateLimitMiddleware.Limit("api-keys"), handler.RequireRoles(entity.RoleAdmin))
+		{
+			apiKey.POST("/", apiKeyHandler.CreateApiKey)
+			apiKey.GET("/", apiKeyHandler.ListApiKeys)
+			apiKey.POST("/:id/rotate", apiKeyHandler.RotateApiKey)
+			apiKey.DELETE("/:id", apiKeyHandler.RevokeApiKey)
+		}
+		admin := v1.Group("/admin", rateLimitMiddleware.Limit("admin"), handler.RequireRoles(entity.RoleAdmin))
+		{
+			admin.GET("/log-level", logLevelHandler.GetLogLevel)
+			admin.PUT("/log-level", logLevelHandler.SetLogLevel)
 		}
-		client := v1.Group("/clients")
+		client := v1.Group("/clients", rateLimitMiddleware.Limit("clients"), handler.RequireScopeOrRoles(entity.ApiKeyScopeClientsWrite, entity.RoleKiosk, entity.RoleAdmin))
 		{
 			client.POST("/", clientHandler.CreateClient)
-			client.GET("/:cpf", clientHandler.GetClientByCpf)
+			// Only served when the codes can be sent by email.
+			if clientHandler.IdentificationEnabled() {
+				client.POST("/identification", clientHandler.RequestIdentificationCode)
+				client.POST("/identification/verify", clientHandler.VerifyIdentificationCode)
+			}
 		}
-		product := v1.Group("/products")
+		customer := v1.Group("/clients", rateLimitMiddleware.Limit("customer"), handler.RequireRoles(entity.RoleCustomer))
 		{
-			product.GET("/", productHandler.ListProducts)
-			product.POST("/", productHandler.CreateProduct)
-			product.PUT("/:id", productHandler.UpdateProduct)
-			product.DELETE("/:id", productHandler.DeleteProduct)
+			customer.GET("/:cpf", clientHandler.GetClientByCpf)
+			customer.GET("/me/consents", clientHandler.ListConsents)
+			customer.PUT("/me/consents/:purpose", clientHandler.GrantConsent)
+			customer.DELETE("/me/consents/:purpose", clientHandler.RevokeConsent)
 		}
-		order := v1.Group("/orders")
+		menu := v1.Group("/products", rateLimitMiddleware.Limit("menu"), handler.RequireScopeOrRoles(entity.ApiKeyScopeCatalogRead, entity.RoleCustomer, entity.RoleKiosk, entity.RoleKitchen, entity.RoleAdmin))
+		{
+			menu.GET("/", productHandler.ListProducts)
+		}
+		catalog := v1.Group("/products", rateLimitMiddleware.Limit("catalog"), handler.RequireScopeOrRoles(entity.ApiKeyScopeCatalogWrite, entity.RoleAdmin), idempotencyMiddleware.Handle)
+		{
+			catalog.POST("/", productHandler.CreateProduct)
+			catalog.PUT("/:id", productHandler.UpdateProduct)
+			catalog.DELETE("/:id", productHandler.DeleteProduct)
+		}
+		menuFile := v1.Group("/menu", rateLimitMiddleware.Limit("catalog"), handler.RequireScopeOrRoles(entity.ApiKeyScopeCatalogWrite, entity.RoleAdmin), idempotencyMiddleware.Handle)
+		{
+			menuFile.POST("/import", menuHandler.ImportMenu)
+			menuFile.GET("/export", menuHandler.ExportMenu)
+		}
+		order := v1.Group("/orders", rateLimitMiddleware.Limit("orders"), handler.RequireScopeOrRoles(entity.ApiKeyScopeOrdersWrite, entity.RoleCustomer, entity.RoleKiosk, entity.RoleAdmin), idempotencyMiddleware.Handle)
 		{
 			order.POST("/", orderHandler.CreateOrder)
-			order.GET("/", orderHandler.ListOrders)
 			order.GET("/:id/payment-status", orderHandler.GetOrderPaymentStatus)
-			order.PATCH("/:id/status", orderHandler.UpdateOrderStatus)
+		}
+		kitchen := v1.Group("/orders", rateLimitMiddleware.Limit("kitchen"), handler.RequireScopeOrRoles(entity.ApiKeyScopeOrdersManage, entity.RoleKitchen, entity.RoleAdmin), idempotencyMiddleware.Handle)
+		{
+			kitchen.GET("/", orderHandler.ListOrders)
+			kitchen.PATCH("/:id/status", orderHandler.UpdateOrderStatus)
 		}
 	}
 
@@ -80,3 +178,20 @@ func NewRouter(
 		router,
 	}, nil
 }
+
+func trustedProxies(proxies string) []string {
+	var trusted []string
+	for _, proxy := range strings.Split(proxies, ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			trusted = append(trusted, proxy)
+		}
+	}
+	return trusted
+}
+
+// traced leaves the probes and the metrics scrape out of the traces, as they
+// run every few seconds and would drown the requests worth looking at.
+func traced(request *http.Request) bool {
+	path := request.URL.Path
+	return path != "/metrics" && !strings.HasPrefix(path, "/health/")
+}
@@ -0,0 +1,241 @@
+// Package menufile reads and writes menus in the JSON and CSV formats taken
+// by the import and produced by the export, both through the API and the
+// command line.
+package menufile
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	entity "post-tech-challenge-10soat/internal/entities"
+	"strconv"
+	"strings"
+)
+
+const (
+	FormatJSON = "json"
+	FormatCSV  = "csv"
+)
+
+// csvColumns are written in this order. Imports find them by name in the
+// header, ignoring any other column, and only description and image may be
+// left out.
+var csvColumns = []string{"sku", "name", "description", "image", "value", "category"}
+
+var optionalColumns = map[string]bool{"description": true, "image": true}
+
+type document struct {
+	Categories []category `json:"categories"`
+	Products   []product  `json:"products"`
+}
+
+type category struct {
+	Name string `json:"name"`
+}
+
+type product struct {
+	Sku         string  `json:"sku"`
+	Name        string  `json:"name"`
+	Description string  `json:"description,omitempty"`
+	Image       string  `json:"image,omitempty"`
+	Value       float64 `json:"value"`
+	Category    string  `json:"category"`
+}
+
+// FormatOf picks the format named by a content type or a file name, such as
+// "text/csv" or "menu.csv", defaulting to JSON.
+func FormatOf(name string) string {
+	if strings.Contains(strings.ToLower(name), FormatCSV) {
+		return FormatCSV
+	}
+	return FormatJSON
+}
+
+// ContentType is the media type of the format.
+func ContentType(format string) string {
+	if format == FormatCSV {
+		return "text/csv; charset=utf-8"
+	}
+	return "application/json; charset=utf-8"
+}
+
+// Decode reads a menu. Problems with a single product are kept in its
+// Errors, anything that prevents reading the file as a whole is returned as
+// entity.ErrInvalidData.
+func Decode(r io.Reader, format string) (entity.Menu, error) {
+	if format == FormatCSV {
+		return decodeCSV(r)
+	}
+	return decodeJSON(r)
+}
+
+// Encode writes a menu. CSV has no room for categories without products,
+// which are left out.
+func Encode(w io.Writer, format string, menu entity.Menu) error {
+	if format == FormatCSV {
+		return encodeCSV(w, menu)
+	}
+	return encodeJSON(w, menu)
+}
+
+// decodeJSON numbers the products from 1, in the order of the list.
+func decodeJSON(r io.Reader) (entity.Menu, error) {
+	var doc document
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return entity.Menu{}, fmt.Errorf("%w: the menu is not valid JSON - %s", entity.ErrInvalidData, err)
+	}
+	menu := entity.Menu{
+		Categories: make([]string, 0, len(doc.Categories)),
+		Items:      make([]entity.MenuItem, 0, len(doc.Products)),
+	}
+	for i, category := range doc.Categories {
+		name := strings.TrimSpace(category.Name)
+		if name == "" {
+			return entity.Menu{}, fmt.Errorf("%w: categories[%d] has no name", entity.ErrInvalidData, i)
+		}
+		menu.Categories = append(menu.Categories, name)
+	}
+	for i, product := range doc.Products {
+		menu.Items = append(menu.Items, entity.MenuItem{
+			Row:         i + 1,
+			Sku:         strings.TrimSpace(product.Sku),
+			Name:        strings.TrimSpace(product.Name),
+			Description: strings.TrimSpace(product.Description),
+			Image:       strings.TrimSpace(product.Image),
+			Value:       product.Value,
+			Category:    strings.TrimSpace(product.Category),
+		})
+	}
+	return menu, nil
+}
+
+func encodeJSON(w io.Writer, menu entity.Menu) error {
+	doc := document{
+		Categories: make([]category, 0, len(menu.Categories)),
+		Products:   make([]product, 0, len(menu.Items)),
+	}
+	for _, name := range menu.Categories {
+		doc.Categories = append(doc.Categories, category{Name: name})
+	}
+	for _, item := range menu.Items {
+		doc.Products = append(doc.Products, product{
+			Sku:         item.Sku,
+			Name:        item.Name,
+			Description: item.Description,
+			Image:       item.Image,
+			Value:       item.Value,
+			Category:    item.Category,
+		})
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(doc)
+}
+
+// decodeCSV numbers the products by their line in the file, the header
+// being line 1, as spreadsheets show them. Files saved by spreadsheets set
+// to Portuguese, with ";" between columns and "," before the cents, are
+// read as well.
+func decodeCSV(r io.Reader) (entity.Menu, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return entity.Menu{}, fmt.Errorf("%w: cannot read the menu - %s", entity.ErrInvalidData, err)
+	}
+	// Spreadsheets may start the file with a byte order mark.
+	data = bytes.TrimPrefix(data, []byte("\ufeff"))
+	reader := csv.NewReader(bytes.NewReader(data))
+	header, _, _ := bytes.Cut(data, []byte("\n"))
+	if bytes.Count(header, []byte(";")) > bytes.Count(header, []byte(",")) {
+		reader.Comma = ';'
+	}
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	columns, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return entity.Menu{}, fmt.Errorf("%w: the menu is empty", entity.ErrInvalidData)
+	}
+	if err != nil {
+		return entity.Menu{}, fmt.Errorf("%w: the menu is not valid CSV - %s", entity.ErrInvalidData, err)
+	}
+	positions := make(map[string]int, len(columns))
+	for i, column := range columns {
+		positions[strings.ToLower(strings.TrimSpace(column))] = i
+	}
+	for _, column := range csvColumns {
+		if _, found := positions[column]; !found && !optionalColumns[column] {
+			return entity.Menu{}, fmt.Errorf("%w: the menu has no %s column", entity.ErrInvalidData, column)
+		}
+	}
+
+	var menu entity.Menu
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return menu, nil
+		}
+		if err != nil {
+			return entity.Menu{}, fmt.Errorf("%w: the menu is not valid CSV - %s", entity.ErrInvalidData, err)
+		}
+		line, _ := reader.FieldPos(0)
+		field := func(column string) string {
+			position, found := positions[column]
+			if !found || position >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[position])
+		}
+		item := entity.MenuItem{
+			Row:         line,
+			Sku:         field("sku"),
+			Name:        field("name"),
+			Description: field("description"),
+			Image:       field("image"),
+			Category:    field("category"),
+		}
+		if len(record) < len(columns) {
+			item.Errors = append(item.Errors, fmt.Sprintf("row has %d columns, the header has %d", len(record), len(columns)))
+		}
+		if value := field("value"); value != "" {
+			item.Value, err = parseValue(value)
+			if err != nil {
+				item.Errors = append(item.Errors, fmt.Sprintf("value %q is not a number", value))
+			}
+		}
+		menu.Items = append(menu.Items, item)
+	}
+}
+
+// parseValue takes "15.90" as well as "15,90" and "1.015,90".
+func parseValue(value string) (float64, error) {
+	if strings.Contains(value, ",") {
+		value = strings.ReplaceAll(value, ".", "")
+		value = strings.Replace(value, ",", ".", 1)
+	}
+	return strconv.ParseFloat(value, 64)
+}
+
+func encodeCSV(w io.Writer, menu entity.Menu) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvColumns); err != nil {
+		return err
+	}
+	for _, item := range menu.Items {
+		err := writer.Write([]string{
+			item.Sku,
+			item.Name,
+			item.Description,
+			item.Image,
+			strconv.FormatFloat(item.Value, 'f', 2, 64),
+			item.Category,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
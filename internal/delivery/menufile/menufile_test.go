@@ -0,0 +1,125 @@
+package menufile
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	entity "post-tech-challenge-10soat/internal/entities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestMenu() entity.Menu {
+	return entity.Menu{
+		Categories: []string{"Bebida", "Lanche"},
+		Items: []entity.MenuItem{
+			{Sku: "BEBIDA-1", Name: "Suco", Description: "Suco de laranja", Value: 12.9, Category: "Bebida"},
+			{Sku: "LANCHE-1", Name: "Lanche, com bacon", Image: "https://img/lanche.webp", Value: 15.9, Category: "Lanche"},
+		},
+	}
+}
+
+func TestEncodeDecode_RoundTrip(t *testing.T) {
+	for _, format := range []string{FormatJSON, FormatCSV} {
+		t.Run(format, func(t *testing.T) {
+			// Setup
+			var buffer bytes.Buffer
+			require.NoError(t, Encode(&buffer, format, newTestMenu()))
+
+			// Act
+			menu, err := Decode(&buffer, format)
+
+			// Assert
+			require.NoError(t, err)
+			expected := newTestMenu()
+			if format == FormatCSV {
+				// CSV only carries the categories of the products.
+				expected.Categories = nil
+			}
+			for i := range expected.Items {
+				expected.Items[i].Row = menu.Items[i].Row
+			}
+			assert.Equal(t, expected, menu)
+		})
+	}
+}
+
+func TestDecode_CSV(t *testing.T) {
+	tests := []struct {
+		name          string
+		file          string
+		expectedItems []entity.MenuItem
+		expectedErr   string
+	}{
+		{
+			name: "numbers rows by line and ignores unknown columns",
+			file: "sku,name,value,category,id\nLANCHE-1,Lanche 1,15.90,Lanche,42\n\nBEBIDA-1,Bebida 1,12.90,Bebida,43\n",
+			expectedItems: []entity.MenuItem{
+				{Row: 2, Sku: "LANCHE-1", Name: "Lanche 1", Value: 15.9, Category: "Lanche"},
+				{Row: 4, Sku: "BEBIDA-1", Name: "Bebida 1", Value: 12.9, Category: "Bebida"},
+			},
+		},
+		{
+			name: "reads files saved by spreadsheets in Portuguese",
+			file: "\ufeffSKU;Name;Description;Value;Category\nLANCHE-1;Lanche 1;Com bacon;1.015,90;Lanche\n",
+			expectedItems: []entity.MenuItem{
+				{Row: 2, Sku: "LANCHE-1", Name: "Lanche 1", Description: "Com bacon", Value: 1015.9, Category: "Lanche"},
+			},
+		},
+		{
+			name: "keeps the errors of each row",
+			file: "sku,name,value,category\nLANCHE-1,Lanche 1,quinze,Lanche\nBEBIDA-1,Bebida 1\n",
+			expectedItems: []entity.MenuItem{
+				{Row: 2, Sku: "LANCHE-1", Name: "Lanche 1", Category: "Lanche", Errors: []string{`value "quinze" is not a number`}},
+				{Row: 3, Sku: "BEBIDA-1", Name: "Bebida 1", Errors: []string{"row has 2 columns, the header has 4"}},
+			},
+		},
+		{
+			name:        "requires the sku, name, value and category columns",
+			file:        "sku,name,category\nLANCHE-1,Lanche 1,Lanche\n",
+			expectedErr: "data is invalid: the menu has no value column",
+		},
+		{
+			name:        "rejects an empty file",
+			file:        "",
+			expectedErr: "data is invalid: the menu is empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			menu, err := Decode(strings.NewReader(tt.file), FormatCSV)
+
+			// Assert
+			if tt.expectedErr != "" {
+				assert.ErrorIs(t, err, entity.ErrInvalidData)
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedItems, menu.Items)
+		})
+	}
+}
+
+func TestDecode_JSON(t *testing.T) {
+	// Act
+	menu, err := Decode(strings.NewReader(`{"categories":[{"name":" Lanche "}],"products":[{"sku":"LANCHE-1","name":"Lanche 1","value":15.9,"category":"Lanche"}]}`), FormatJSON)
+	_, invalidErr := Decode(strings.NewReader(`{"products":[{"sku":"LANCHE-1","value":"15,90"}]}`), FormatJSON)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Lanche"}, menu.Categories)
+	assert.Equal(t, []entity.MenuItem{{Row: 1, Sku: "LANCHE-1", Name: "Lanche 1", Value: 15.9, Category: "Lanche"}}, menu.Items)
+	assert.ErrorIs(t, invalidErr, entity.ErrInvalidData)
+}
+
+func TestFormatOf(t *testing.T) {
+	assert.Equal(t, FormatCSV, FormatOf("text/csv"))
+	assert.Equal(t, FormatCSV, FormatOf("cardapio.CSV"))
+	assert.Equal(t, FormatJSON, FormatOf("application/json"))
+	assert.Equal(t, FormatJSON, FormatOf(""))
+}
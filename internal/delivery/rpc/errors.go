@@ -0,0 +1,62 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	entity "post-tech-challenge-10soat/internal/entities"
+	"post-tech-challenge-10soat/internal/infrastructure/logger"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errorDomain identifies the API in the ErrorInfo attached to every error.
+const errorDomain = "pos.v1"
+
+var errorCodeMap = map[error]codes.Code{
+	entity.ErrInternal:             codes.Internal,
+	entity.ErrInvalidData:          codes.InvalidArgument,
+	entity.ErrDataNotFound:         codes.NotFound,
+	entity.ErrConflictingData:      codes.FailedPrecondition,
+	entity.ErrUnauthorized:         codes.Unauthenticated,
+	entity.ErrForbidden:            codes.PermissionDenied,
+	entity.ErrNoUpdatedData:        codes.InvalidArgument,
+	entity.ErrRateLimited:          codes.ResourceExhausted,
+	entity.ErrPreconditionFailed:   codes.Aborted,
+	entity.ErrPreconditionRequired: codes.FailedPrecondition,
+}
+
+// toStatus translates use case errors into a gRPC status. The stable error
+// code, the same returned by the REST API, goes in the ErrorInfo reason.
+func toStatus(ctx context.Context, err error) error {
+	code := codes.Internal
+	for target, grpcCode := range errorCodeMap {
+		if errors.Is(err, target) {
+			code = grpcCode
+			break
+		}
+	}
+	// Clients only see the message, the log line carries the full error and
+	// the request id returned to them.
+	if code == codes.Internal {
+		slog.ErrorContext(ctx, "Error handling rpc", "error", err)
+	}
+	info := &errdetails.ErrorInfo{
+		Reason: string(entity.ErrorCodeOf(err)),
+		Domain: errorDomain,
+	}
+	if requestId, ok := logger.RequestIdFromContext(ctx); ok {
+		info.Metadata = map[string]string{"request_id": requestId}
+	}
+	st, detailsErr := status.New(code, err.Error()).WithDetails(info)
+	if detailsErr != nil {
+		return status.Error(code, err.Error())
+	}
+	return st.Err()
+}
+
+func invalidArgument(ctx context.Context, format string, args ...any) error {
+	return toStatus(ctx, entity.NewDomainError(entity.ErrInvalidData, entity.ErrCodeValidationFailed, format, args...))
+}
@@ -0,0 +1,120 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"post-tech-challenge-10soat/internal/controllers"
+	entity "post-tech-challenge-10soat/internal/entities"
+	"post-tech-challenge-10soat/internal/infrastructure/logger"
+	interfaces "post-tech-challenge-10soat/internal/interfaces/gateways"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Metadata keys mirror the HTTP headers, lowercased as gRPC requires.
+const (
+	requestIdKey     = "x-request-id"
+	authorizationKey = "authorization"
+	apiKeyKey        = "x-api-key"
+)
+
+// healthMethodPrefix and reflectionMethodPrefix are served without
+// credentials, like the HTTP health check.
+const (
+	healthMethodPrefix     = "/grpc.health.v1.Health/"
+	reflectionMethodPrefix = "/grpc.reflection."
+)
+
+type interceptors struct {
+	tokenGateway     interfaces.TokenGateway
+	apiKeyController controllers.ApiKeyController
+}
+
+func (i interceptors) unary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	start := time.Now()
+	ctx = withRequestId(ctx)
+	ctx, err := i.authenticate(ctx, info.FullMethod)
+	if err != nil {
+		logRpc(ctx, info.FullMethod, start, err)
+		return nil, err
+	}
+	res, err := handler(ctx, req)
+	logRpc(ctx, info.FullMethod, start, err)
+	return res, err
+}
+
+func (i interceptors) stream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	ctx := withRequestId(ss.Context())
+	ctx, err := i.authenticate(ctx, info.FullMethod)
+	if err != nil {
+		logRpc(ctx, info.FullMethod, start, err)
+		return err
+	}
+	err = handler(srv, contextStream{ss, ctx})
+	logRpc(ctx, info.FullMethod, start, err)
+	return err
+}
+
+// withRequestId accepts the caller's x-request-id or generates one, and sends
+// it back in the response header.
+func withRequestId(ctx context.Context) context.Context {
+	var requestId string
+	if values := metadata.ValueFromIncomingContext(ctx, requestIdKey); len(values) > 0 {
+		requestId = values[0]
+	}
+	if !logger.IsValidRequestId(requestId) {
+		requestId = uuid.NewString()
+	}
+	_ = grpc.SetHeader(ctx, metadata.Pairs(requestIdKey, requestId))
+	return logger.ContextWithRequestId(ctx, requestId)
+}
+
+// authenticate resolves the optional bearer token and API key into the
+// context, as the HTTP middlewares do. Each method authorizes the caller.
+func (i interceptors) authenticate(ctx context.Context, method string) (context.Context, error) {
+	if strings.HasPrefix(method, healthMethodPrefix) || strings.HasPrefix(method, reflectionMethodPrefix) {
+		return ctx, nil
+	}
+	if values := metadata.ValueFromIncomingContext(ctx, authorizationKey); len(values) > 0 {
+		scheme, token, found := strings.Cut(values[0], " ")
+		if !found || !strings.EqualFold(scheme, "Bearer") || token == "" {
+			return ctx, toStatus(ctx, fmt.Errorf("%w: malformed authorization metadata", entity.ErrUnauthorized))
+		}
+		session, err := i.tokenGateway.ParseToken(ctx, token)
+		if err != nil {
+			return ctx, toStatus(ctx, err)
+		}
+		ctx = entity.ContextWithSession(ctx, session)
+	}
+	if values := metadata.ValueFromIncomingContext(ctx, apiKeyKey); len(values) > 0 && values[0] != "" {
+		device, err := i.apiKeyController.AuthenticateApiKey(ctx, values[0])
+		if err != nil {
+			return ctx, toStatus(ctx, err)
+		}
+		ctx = entity.ContextWithDevice(ctx, device)
+	}
+	return ctx, nil
+}
+
+func logRpc(ctx context.Context, method string, start time.Time, err error) {
+	code := status.Code(err)
+	slog.InfoContext(ctx, "Handled rpc", "method", method, "code", code.String(), "latency", time.Since(start))
+}
+
+// contextStream replaces the context of a server stream with one carrying
+// the request id and the credentials.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s contextStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,38 @@
+package rpc
+
+import (
+	"post-tech-challenge-10soat/internal/delivery/rpc/pb"
+	entity "post-tech-challenge-10soat/internal/entities"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func newProduct(product entity.Product) *pb.Product {
+	return &pb.Product{
+		Id:          product.Id,
+		Name:        product.Name,
+		Description: product.Description,
+		Image:       product.Image,
+		Value:       product.Value,
+		Category: &pb.Category{
+			Id:   product.Category.Id,
+			Name: product.Category.Name,
+		},
+		Version:   int32(product.Version),
+		CreatedAt: timestamppb.New(product.CreatedAt),
+		UpdatedAt: timestamppb.New(product.UpdatedAt),
+	}
+}
+
+func newOrder(order entity.Order) *pb.Order {
+	return &pb.Order{
+		Id:        order.Id,
+		Number:    int32(order.Number),
+		Status:    string(order.Status),
+		ClientId:  order.ClientId,
+		Total:     order.Total,
+		Version:   int32(order.Version),
+		CreatedAt: timestamppb.New(order.CreatedAt),
+		UpdatedAt: timestamppb.New(order.UpdatedAt),
+	}
+}
@@ -0,0 +1,107 @@
+package rpc
+
+import (
+	"context"
+	"post-tech-challenge-10soat/internal/controllers"
+	"post-tech-challenge-10soat/internal/delivery/rpc/pb"
+	dto "post-tech-challenge-10soat/internal/dto/order"
+	entity "post-tech-challenge-10soat/internal/entities"
+)
+
+var (
+	orderRoles   = []entity.Role{entity.RoleCustomer, entity.RoleKiosk, entity.RoleAdmin}
+	kitchenRoles = []entity.Role{entity.RoleKitchen, entity.RoleAdmin}
+)
+
+type orderService struct {
+	pb.UnimplementedOrderServiceServer
+	orderController controllers.OrderController
+}
+
+func (s *orderService) CreateOrder(ctx context.Context, req *pb.CreateOrderRequest) (*pb.Order, error) {
+	if err := entity.Authorize(ctx, entity.ApiKeyScopeOrdersWrite, orderRoles); err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	if len(req.GetProducts()) == 0 {
+		return nil, invalidArgument(ctx, "products are required")
+	}
+	createOrderDTO := dto.CreateOrderDTO{ClientId: req.GetClientId()}
+	for _, product := range req.GetProducts() {
+		if product.GetQuantity() < 1 {
+			return nil, invalidArgument(ctx, "quantity must be at least 1")
+		}
+		createOrderDTO.Products = append(createOrderDTO.Products, dto.CreateOrderProduct{
+			ProductId:   product.GetProductId(),
+			Quantity:    int(product.GetQuantity()),
+			Observation: product.GetObservation(),
+		})
+	}
+	order, err := s.orderController.CreateOrder(ctx, createOrderDTO)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return newOrder(order), nil
+}
+
+func (s *orderService) ListOrders(ctx context.Context, req *pb.ListOrdersRequest) (*pb.ListOrdersResponse, error) {
+	if err := entity.Authorize(ctx, entity.ApiKeyScopeOrdersManage, kitchenRoles); err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	if req.GetLimit() < 1 {
+		return nil, invalidArgument(ctx, "limit must be at least 1")
+	}
+	orders, err := s.orderController.ListOrders(ctx, req.GetLimit())
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	response := &pb.ListOrdersResponse{Orders: make([]*pb.Order, 0, len(orders))}
+	for _, order := range orders {
+		response.Orders = append(response.Orders, newOrder(order))
+	}
+	return response, nil
+}
+
+func (s *orderService) GetOrderPaymentStatus(ctx context.Context, req *pb.GetOrderPaymentStatusRequest) (*pb.GetOrderPaymentStatusResponse, error) {
+	if err := entity.Authorize(ctx, entity.ApiKeyScopeOrdersWrite, orderRoles); err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	if req.GetId() == "" {
+		return nil, invalidArgument(ctx, "id is required")
+	}
+	paymentStatus, err := s.orderController.GetOrderPaymentStatus(ctx, req.GetId())
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return &pb.GetOrderPaymentStatusResponse{PaymentStatus: string(paymentStatus.PaymentStatus)}, nil
+}
+
+func (s *orderService) UpdateOrderStatus(ctx context.Context, req *pb.UpdateOrderStatusRequest) (*pb.Order, error) {
+	if err := entity.Authorize(ctx, entity.ApiKeyScopeOrdersManage, kitchenRoles); err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	if req.GetId() == "" || req.GetStatus() == "" {
+		return nil, invalidArgument(ctx, "id and status are required")
+	}
+	order, err := s.orderController.UpdateOrderStatus(ctx, req.GetId(), req.GetStatus(), int(req.GetVersion()))
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return newOrder(order), nil
+}
+
+func (s *orderService) WatchOrderStatus(req *pb.WatchOrderStatusRequest, stream pb.OrderService_WatchOrderStatusServer) error {
+	ctx := stream.Context()
+	if err := entity.Authorize(ctx, entity.ApiKeyScopeOrdersManage, kitchenRoles); err != nil {
+		return toStatus(ctx, err)
+	}
+	orders, err := s.orderController.WatchOrderStatus(ctx, req.GetOrderId())
+	if err != nil {
+		return toStatus(ctx, err)
+	}
+	for order := range orders {
+		if err := stream.Send(newOrder(order)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package rpc
+
+import (
+	"context"
+	"post-tech-challenge-10soat/internal/controllers"
+	"post-tech-challenge-10soat/internal/delivery/rpc/pb"
+	dto "post-tech-challenge-10soat/internal/dto/product"
+	entity "post-tech-challenge-10soat/internal/entities"
+
+	"github.com/google/uuid"
+)
+
+// Methods check the same roles and API key scopes as the matching REST routes.
+var (
+	menuRoles    = []entity.Role{entity.RoleCustomer, entity.RoleKiosk, entity.RoleKitchen, entity.RoleAdmin}
+	catalogRoles = []entity.Role{entity.RoleAdmin}
+)
+
+type productService struct {
+	pb.UnimplementedProductServiceServer
+	productController controllers.ProductController
+}
+
+func (s *productService) ListProducts(ctx context.Context, req *pb.ListProductsRequest) (*pb.ListProductsResponse, error) {
+	if err := entity.Authorize(ctx, entity.ApiKeyScopeCatalogRead, menuRoles); err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	products, err := s.productController.ListProducts(ctx, req.GetCategoryId())
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	response := &pb.ListProductsResponse{Products: make([]*pb.Product, 0, len(products))}
+	for _, product := range products {
+		response.Products = append(response.Products, newProduct(product))
+	}
+	return response, nil
+}
+
+func (s *productService) CreateProduct(ctx context.Context, req *pb.CreateProductRequest) (*pb.Product, error) {
+	if err := entity.Authorize(ctx, entity.ApiKeyScopeCatalogWrite, catalogRoles); err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	if req.GetName() == "" {
+		return nil, invalidArgument(ctx, "name is required")
+	}
+	if req.GetValue() <= 0 {
+		return nil, invalidArgument(ctx, "value must be greater than 0")
+	}
+	if err := uuid.Validate(req.GetCategoryId()); err != nil {
+		return nil, invalidArgument(ctx, "invalid category id")
+	}
+	product, err := s.productController.CreateProduct(ctx, dto.CreateProductDTO{
+		Name:        req.GetName(),
+		Description: req.GetDescription(),
+		Image:       req.GetImage(),
+		Value:       req.GetValue(),
+		CategoryId:  req.GetCategoryId(),
+	})
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return newProduct(product), nil
+}
+
+func (s *productService) UpdateProduct(ctx context.Context, req *pb.UpdateProductRequest) (*pb.Product, error) {
+	if err := entity.Authorize(ctx, entity.ApiKeyScopeCatalogWrite, catalogRoles); err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	if err := uuid.Validate(req.GetId()); err != nil {
+		return nil, invalidArgument(ctx, "invalid product id")
+	}
+	if err := uuid.Validate(req.GetCategoryId()); err != nil {
+		return nil, invalidArgument(ctx, "invalid category id")
+	}
+	product, err := s.productController.UpdateProduct(ctx, dto.UpdateProductDTO{
+		Id:          req.GetId(),
+		Name:        req.GetName(),
+		Description: req.GetDescription(),
+		Image:       req.GetImage(),
+		Value:       req.GetValue(),
+		CategoryId:  req.GetCategoryId(),
+		Version:     int(req.GetVersion()),
+	})
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return newProduct(product), nil
+}
+
+func (s *productService) DeleteProduct(ctx context.Context, req *pb.DeleteProductRequest) (*pb.DeleteProductResponse, error) {
+	if err := entity.Authorize(ctx, entity.ApiKeyScopeCatalogWrite, catalogRoles); err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	if err := uuid.Validate(req.GetId()); err != nil {
+		return nil, invalidArgument(ctx, "invalid product id")
+	}
+	if err := s.productController.DeleteProduct(ctx, req.GetId()); err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return &pb.DeleteProductResponse{}, nil
+}
@@ -0,0 +1,40 @@
+package rpc
+
+import (
+	"post-tech-challenge-10soat/internal/controllers"
+	"post-tech-challenge-10soat/internal/delivery/rpc/pb"
+	interfaces "post-tech-challenge-10soat/internal/interfaces/gateways"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+// NewServer exposes the product and order controllers over gRPC, next to the
+// standard health service and server reflection. Callers authenticate with
+// the same bearer tokens and API keys as the HTTP API, sent as metadata.
+func NewServer(
+	productController controllers.ProductController,
+	orderController controllers.OrderController,
+	apiKeyController controllers.ApiKeyController,
+	tokenGateway interfaces.TokenGateway,
+) *grpc.Server {
+	i := interceptors{
+		tokenGateway,
+		apiKeyController,
+	}
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(i.unary),
+		grpc.ChainStreamInterceptor(i.stream),
+	)
+	pb.RegisterProductServiceServer(server, &productService{productController: productController})
+	pb.RegisterOrderServiceServer(server, &orderService{orderController: orderController})
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	healthServer.SetServingStatus(pb.ProductService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	healthServer.SetServingStatus(pb.OrderService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(server, healthServer)
+	reflection.Register(server)
+	return server
+}
@@ -0,0 +1,184 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"post-tech-challenge-10soat/internal/controllers"
+	"post-tech-challenge-10soat/internal/delivery/rpc/pb"
+	entity "post-tech-challenge-10soat/internal/entities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type MockTokenGateway struct {
+	mock.Mock
+}
+
+func (m *MockTokenGateway) IssueToken(ctx context.Context, session entity.Session) (entity.Token, error) {
+	args := m.Called(ctx, session)
+	return args.Get(0).(entity.Token), args.Error(1)
+}
+
+func (m *MockTokenGateway) ParseToken(ctx context.Context, token string) (entity.Session, error) {
+	args := m.Called(ctx, token)
+	return args.Get(0).(entity.Session), args.Error(1)
+}
+
+type MockListOrdersUseCase struct {
+	mock.Mock
+}
+
+func (m *MockListOrdersUseCase) Execute(ctx context.Context, limit uint64) ([]entity.Order, error) {
+	args := m.Called(ctx, limit)
+	return args.Get(0).([]entity.Order), args.Error(1)
+}
+
+type MockWatchOrderStatusUseCase struct {
+	mock.Mock
+}
+
+func (m *MockWatchOrderStatusUseCase) Execute(ctx context.Context, orderId string) (<-chan entity.Order, error) {
+	args := m.Called(ctx, orderId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(chan entity.Order), args.Error(1)
+}
+
+func setupTestServer(t *testing.T) (*grpc.ClientConn, *MockTokenGateway, *MockListOrdersUseCase, *MockWatchOrderStatusUseCase) {
+	mockTokenGateway := &MockTokenGateway{}
+	mockListOrders := &MockListOrdersUseCase{}
+	mockWatchOrderStatus := &MockWatchOrderStatusUseCase{}
+	orderController := controllers.NewOrderController(nil, mockListOrders, nil, nil, nil, mockWatchOrderStatus)
+	productController := controllers.NewProductController(nil, nil, nil, nil, nil)
+	server := NewServer(*productController, *orderController, nil, mockTokenGateway)
+
+	listener := bufconn.Listen(1024 * 1024)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	assert.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn, mockTokenGateway, mockListOrders, mockWatchOrderStatus
+}
+
+func withToken(token string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), authorizationKey, "Bearer "+token)
+}
+
+func TestServer_ListOrders(t *testing.T) {
+	// Setup
+	conn, mockTokenGateway, mockListOrders, _ := setupTestServer(t)
+	mockTokenGateway.On("ParseToken", mock.Anything, "kitchen-token").Return(entity.Session{Subject: "user-1", Role: entity.RoleKitchen}, nil)
+	mockListOrders.On("Execute", mock.Anything, uint64(5)).Return([]entity.Order{
+		{Id: "order-1", Number: 1, Status: entity.OrderStatusReady, Version: 3},
+	}, nil)
+
+	// Act
+	var header metadata.MD
+	res, err := pb.NewOrderServiceClient(conn).ListOrders(withToken("kitchen-token"), &pb.ListOrdersRequest{Limit: 5}, grpc.Header(&header))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, res.GetOrders(), 1)
+	assert.Equal(t, "order-1", res.GetOrders()[0].GetId())
+	assert.Equal(t, int32(3), res.GetOrders()[0].GetVersion())
+	assert.NotEmpty(t, header.Get(requestIdKey))
+}
+
+func TestServer_ListOrders_ForbiddenRole(t *testing.T) {
+	// Setup
+	conn, mockTokenGateway, mockListOrders, _ := setupTestServer(t)
+	mockTokenGateway.On("ParseToken", mock.Anything, "kiosk-token").Return(entity.Session{Subject: "user-2", Role: entity.RoleKiosk}, nil)
+
+	// Act
+	_, err := pb.NewOrderServiceClient(conn).ListOrders(withToken("kiosk-token"), &pb.ListOrdersRequest{Limit: 5})
+
+	// Assert
+	st := status.Convert(err)
+	assert.Equal(t, codes.PermissionDenied, st.Code())
+	assert.Len(t, st.Details(), 1)
+	info, ok := st.Details()[0].(*errdetails.ErrorInfo)
+	assert.True(t, ok)
+	assert.Equal(t, string(entity.ErrCodeForbidden), info.GetReason())
+	mockListOrders.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+}
+
+func TestServer_ListOrders_RequiresCredentials(t *testing.T) {
+	// Setup
+	conn, _, _, _ := setupTestServer(t)
+
+	// Act
+	_, err := pb.NewOrderServiceClient(conn).ListOrders(context.Background(), &pb.ListOrdersRequest{Limit: 5})
+
+	// Assert
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestServer_WatchOrderStatus(t *testing.T) {
+	// Setup
+	conn, mockTokenGateway, _, mockWatchOrderStatus := setupTestServer(t)
+	mockTokenGateway.On("ParseToken", mock.Anything, "kitchen-token").Return(entity.Session{Subject: "user-1", Role: entity.RoleKitchen}, nil)
+	orders := make(chan entity.Order, 2)
+	orders <- entity.Order{Id: "order-1", Status: entity.OrderStatusPreparing, Version: 2}
+	orders <- entity.Order{Id: "order-1", Status: entity.OrderStatusReady, Version: 3}
+	close(orders)
+	mockWatchOrderStatus.On("Execute", mock.Anything, "order-1").Return(orders, nil)
+
+	// Act
+	stream, err := pb.NewOrderServiceClient(conn).WatchOrderStatus(withToken("kitchen-token"), &pb.WatchOrderStatusRequest{OrderId: "order-1"})
+	assert.NoError(t, err)
+	first, err := stream.Recv()
+	assert.NoError(t, err)
+	second, err := stream.Recv()
+	assert.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, string(entity.OrderStatusPreparing), first.GetStatus())
+	assert.Equal(t, string(entity.OrderStatusReady), second.GetStatus())
+	assert.Equal(t, int32(3), second.GetVersion())
+}
+
+func TestServer_WatchOrderStatus_OrderNotFound(t *testing.T) {
+	// Setup
+	conn, mockTokenGateway, _, mockWatchOrderStatus := setupTestServer(t)
+	mockTokenGateway.On("ParseToken", mock.Anything, "kitchen-token").Return(entity.Session{Subject: "user-1", Role: entity.RoleKitchen}, nil)
+	mockWatchOrderStatus.On("Execute", mock.Anything, "missing").Return(nil, entity.ErrOrderNotFound)
+
+	// Act
+	stream, err := pb.NewOrderServiceClient(conn).WatchOrderStatus(withToken("kitchen-token"), &pb.WatchOrderStatusRequest{OrderId: "missing"})
+	assert.NoError(t, err)
+	_, err = stream.Recv()
+
+	// Assert
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestServer_Health(t *testing.T) {
+	// Setup
+	conn, _, _, _ := setupTestServer(t)
+
+	// Act
+	res, err := healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{Service: pb.OrderService_ServiceDesc.ServiceName})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, res.GetStatus())
+}
@@ -0,0 +1,36 @@
+package dto
+
+import (
+	entity "post-tech-challenge-10soat/internal/entities"
+	"time"
+)
+
+type ApiKeyDTO struct {
+	Id         string
+	Name       string
+	Prefix     string
+	KeyHash    string
+	Scopes     []string
+	ExpiresAt  time.Time
+	LastUsedAt time.Time
+	RevokedAt  time.Time
+	CreatedAt  time.Time
+}
+
+func (d ApiKeyDTO) ToEntity() entity.ApiKey {
+	scopes := make([]entity.ApiKeyScope, 0, len(d.Scopes))
+	for _, scope := range d.Scopes {
+		scopes = append(scopes, entity.ApiKeyScope(scope))
+	}
+	return entity.ApiKey{
+		Id:         d.Id,
+		Name:       d.Name,
+		Prefix:     d.Prefix,
+		KeyHash:    d.KeyHash,
+		Scopes:     scopes,
+		ExpiresAt:  d.ExpiresAt,
+		LastUsedAt: d.LastUsedAt,
+		RevokedAt:  d.RevokedAt,
+		CreatedAt:  d.CreatedAt,
+	}
+}
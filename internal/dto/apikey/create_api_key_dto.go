@@ -0,0 +1,11 @@
+package dto
+
+import (
+	"time"
+)
+
+type CreateApiKeyDTO struct {
+	Name      string
+	Scopes    []string
+	ExpiresAt time.Time
+}
@@ -0,0 +1,13 @@
+package dto
+
+import (
+	"time"
+)
+
+type StoreApiKeyDTO struct {
+	Name      string
+	Prefix    string
+	KeyHash   string
+	Scopes    []string
+	ExpiresAt time.Time
+}
@@ -10,6 +10,7 @@ type ClientDTO struct {
 	Cpf       string
 	Name      string
 	Email     string
+	Language  string
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
@@ -20,6 +21,7 @@ func (d ClientDTO) ToEntity() entity.Client {
 		Cpf:       d.Cpf,
 		Name:      d.Name,
 		Email:     d.Email,
+		Language:  d.Language,
 		CreatedAt: d.CreatedAt,
 		UpdatedAt: d.UpdatedAt,
 	}
@@ -31,6 +33,7 @@ func (d ClientDTO) FromEntity(client entity.Client) ClientDTO {
 		Cpf:       client.Cpf,
 		Name:      client.Name,
 		Email:     client.Email,
+		Language:  client.Language,
 		CreatedAt: client.CreatedAt,
 		UpdatedAt: client.UpdatedAt,
 	}
@@ -0,0 +1,30 @@
+package dto
+
+import (
+	entity "post-tech-challenge-10soat/internal/entities"
+	"time"
+)
+
+type ClientIdentificationDTO struct {
+	Id          string
+	ClientId    string
+	CodeHash    string
+	Attempts    int
+	MaxAttempts int
+	ExpiresAt   time.Time
+	VerifiedAt  time.Time
+	CreatedAt   time.Time
+}
+
+func (d ClientIdentificationDTO) ToEntity() entity.ClientIdentification {
+	return entity.ClientIdentification{
+		Id:          d.Id,
+		ClientId:    d.ClientId,
+		CodeHash:    d.CodeHash,
+		Attempts:    d.Attempts,
+		MaxAttempts: d.MaxAttempts,
+		ExpiresAt:   d.ExpiresAt,
+		VerifiedAt:  d.VerifiedAt,
+		CreatedAt:   d.CreatedAt,
+	}
+}
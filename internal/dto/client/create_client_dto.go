@@ -1,7 +1,8 @@
 package dto
 
 type CreateClientDTO struct {
-	Cpf   string
-	Name  string
-	Email string
+	Cpf      string
+	Name     string
+	Email    string
+	Language string
 }
@@ -0,0 +1,12 @@
+package dto
+
+import (
+	"time"
+)
+
+type CreateClientIdentificationDTO struct {
+	ClientId    string
+	CodeHash    string
+	MaxAttempts int
+	ExpiresAt   time.Time
+}
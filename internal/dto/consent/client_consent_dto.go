@@ -0,0 +1,28 @@
+package dto
+
+import (
+	entity "post-tech-challenge-10soat/internal/entities"
+	"time"
+)
+
+type ClientConsentDTO struct {
+	Id            string
+	ClientId      string
+	Purpose       string
+	Granted       bool
+	Source        string
+	PolicyVersion string
+	CreatedAt     time.Time
+}
+
+func (d ClientConsentDTO) ToEntity() entity.ClientConsent {
+	return entity.ClientConsent{
+		Id:            d.Id,
+		ClientId:      d.ClientId,
+		Purpose:       entity.ConsentPurpose(d.Purpose),
+		Granted:       d.Granted,
+		Source:        d.Source,
+		PolicyVersion: d.PolicyVersion,
+		CreatedAt:     d.CreatedAt,
+	}
+}
@@ -0,0 +1,9 @@
+package dto
+
+type CreateClientConsentDTO struct {
+	ClientId      string
+	Purpose       string
+	Granted       bool
+	Source        string
+	PolicyVersion string
+}
@@ -0,0 +1,7 @@
+package dto
+
+type UpdateClientConsentDTO struct {
+	Purpose       string
+	Source        string
+	PolicyVersion string
+}
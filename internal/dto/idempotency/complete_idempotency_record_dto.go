@@ -0,0 +1,8 @@
+package dto
+
+type CompleteIdempotencyRecordDTO struct {
+	Id           string
+	StatusCode   int
+	ResponseBody []byte
+	ContentType  string
+}
@@ -0,0 +1,12 @@
+package dto
+
+import (
+	"time"
+)
+
+type CreateIdempotencyRecordDTO struct {
+	Principal   string
+	Key         string
+	RequestHash string
+	ExpiresAt   time.Time
+}
@@ -0,0 +1,34 @@
+package dto
+
+import (
+	entity "post-tech-challenge-10soat/internal/entities"
+	"time"
+)
+
+type IdempotencyRecordDTO struct {
+	Id           string
+	Principal    string
+	Key          string
+	RequestHash  string
+	StatusCode   int
+	ResponseBody []byte
+	ContentType  string
+	CreatedAt    time.Time
+	CompletedAt  time.Time
+	ExpiresAt    time.Time
+}
+
+func (d IdempotencyRecordDTO) ToEntity() entity.IdempotencyRecord {
+	return entity.IdempotencyRecord{
+		Id:           d.Id,
+		Principal:    d.Principal,
+		Key:          d.Key,
+		RequestHash:  d.RequestHash,
+		StatusCode:   d.StatusCode,
+		ResponseBody: d.ResponseBody,
+		ContentType:  d.ContentType,
+		CreatedAt:    d.CreatedAt,
+		CompletedAt:  d.CompletedAt,
+		ExpiresAt:    d.ExpiresAt,
+	}
+}
@@ -0,0 +1,11 @@
+package dto
+
+type CreateNotificationDeliveryDTO struct {
+	OrderId   string
+	ClientId  string
+	Event     string
+	Channel   string
+	Recipient string
+	Status    string
+	Error     string
+}
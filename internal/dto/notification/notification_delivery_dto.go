@@ -0,0 +1,32 @@
+package dto
+
+import (
+	entity "post-tech-challenge-10soat/internal/entities"
+	"time"
+)
+
+type NotificationDeliveryDTO struct {
+	Id        string
+	OrderId   string
+	ClientId  string
+	Event     string
+	Channel   string
+	Recipient string
+	Status    string
+	Error     string
+	CreatedAt time.Time
+}
+
+func (d NotificationDeliveryDTO) ToEntity() entity.NotificationDelivery {
+	return entity.NotificationDelivery{
+		Id:        d.Id,
+		OrderId:   d.OrderId,
+		ClientId:  d.ClientId,
+		Event:     entity.NotificationEvent(d.Event),
+		Channel:   d.Channel,
+		Recipient: d.Recipient,
+		Status:    entity.NotificationDeliveryStatus(d.Status),
+		Error:     d.Error,
+		CreatedAt: d.CreatedAt,
+	}
+}
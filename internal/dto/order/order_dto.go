@@ -21,6 +21,7 @@ type OrderDTO struct {
 	ClientId  string
 	PaymentId string
 	Total     float64
+	Version   int
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
@@ -33,6 +34,7 @@ func (d OrderDTO) ToEntity() entity.Order {
 		ClientId:  d.ClientId,
 		PaymentId: d.PaymentId,
 		Total:     d.Total,
+		Version:   d.Version,
 		CreatedAt: d.CreatedAt,
 		UpdatedAt: d.UpdatedAt,
 	}
@@ -3,6 +3,8 @@ package dto
 import dto "post-tech-challenge-10soat/internal/dto/category"
 
 type CreateProductDTO struct {
+	// Sku defaults to the id of the product.
+	Sku         string
 	Name        string
 	Description string
 	Image       string
@@ -0,0 +1,12 @@
+package dto
+
+// ImportProductDTO creates or updates the product with the SKU, in the
+// category with the name.
+type ImportProductDTO struct {
+	Sku         string
+	Name        string
+	Description string
+	Image       string
+	Value       float64
+	Category    string
+}
@@ -8,12 +8,14 @@ import (
 
 type ProductDTO struct {
 	Id          string
+	Sku         string
 	Name        string
 	Description string
 	Image       string
 	Value       float64
 	CategoryId  string
 	CategoryDTO dto.CategoryDTO
+	Version     int
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 }
@@ -21,12 +23,14 @@ type ProductDTO struct {
 func (d ProductDTO) ToEntity() entity.Product {
 	return entity.Product{
 		Id:          d.Id,
+		Sku:         d.Sku,
 		Name:        d.Name,
 		Description: d.Description,
 		Image:       d.Image,
 		Value:       d.Value,
 		CategoryId:  d.CategoryId,
 		Category:    d.CategoryDTO.ToEntity(),
+		Version:     d.Version,
 		CreatedAt:   d.CreatedAt,
 		UpdatedAt:   d.UpdatedAt,
 	}
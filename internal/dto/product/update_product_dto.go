@@ -7,4 +7,5 @@ type UpdateProductDTO struct {
 	Image       string
 	Value       float64
 	CategoryId  string
+	Version     int
 }
@@ -0,0 +1,7 @@
+package dto
+
+type CreateUserDTO struct {
+	Username string
+	Password string
+	Role     string
+}
@@ -0,0 +1,7 @@
+package dto
+
+type StoreUserDTO struct {
+	Username     string
+	PasswordHash string
+	Role         string
+}
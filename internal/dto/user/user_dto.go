@@ -0,0 +1,28 @@
+package dto
+
+import (
+	entity "post-tech-challenge-10soat/internal/entities"
+	"time"
+)
+
+type UserDTO struct {
+	Id           string
+	Username     string
+	PasswordHash string
+	Role         string
+	Active       bool
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+func (d UserDTO) ToEntity() entity.User {
+	return entity.User{
+		Id:           d.Id,
+		Username:     d.Username,
+		PasswordHash: d.PasswordHash,
+		Role:         entity.Role(d.Role),
+		Active:       d.Active,
+		CreatedAt:    d.CreatedAt,
+		UpdatedAt:    d.UpdatedAt,
+	}
+}
@@ -0,0 +1,74 @@
+package entity
+
+import (
+	"context"
+	"slices"
+	"time"
+)
+
+type ApiKeyScope string
+
+const (
+	ApiKeyScopeCatalogRead  ApiKeyScope = "catalog:read"
+	ApiKeyScopeCatalogWrite ApiKeyScope = "catalog:write"
+	ApiKeyScopeClientsWrite ApiKeyScope = "clients:write"
+	ApiKeyScopeOrdersWrite  ApiKeyScope = "orders:write"
+	ApiKeyScopeOrdersManage ApiKeyScope = "orders:manage"
+)
+
+func (s ApiKeyScope) IsValid() bool {
+	switch s {
+	case ApiKeyScopeCatalogRead, ApiKeyScopeCatalogWrite, ApiKeyScopeClientsWrite, ApiKeyScopeOrdersWrite, ApiKeyScopeOrdersManage:
+		return true
+	}
+	return false
+}
+
+// ApiKey authenticates a machine client. Only the hash of the key is stored,
+// Secret is filled once, when the key is created or rotated.
+type ApiKey struct {
+	Id         string
+	Name       string
+	Prefix     string
+	KeyHash    string
+	Scopes     []ApiKeyScope
+	ExpiresAt  time.Time
+	LastUsedAt time.Time
+	RevokedAt  time.Time
+	CreatedAt  time.Time
+	Secret     string
+}
+
+func (k ApiKey) IsRevoked() bool {
+	return !k.RevokedAt.IsZero()
+}
+
+func (k ApiKey) IsExpired(now time.Time) bool {
+	return !k.ExpiresAt.IsZero() && !now.Before(k.ExpiresAt)
+}
+
+func (k ApiKey) HasScope(scope ApiKeyScope) bool {
+	return slices.Contains(k.Scopes, scope)
+}
+
+// Device is the machine client behind the API key of the current request.
+type Device struct {
+	ApiKeyId string
+	Name     string
+	Scopes   []ApiKeyScope
+}
+
+func (d Device) HasScope(scope ApiKeyScope) bool {
+	return slices.Contains(d.Scopes, scope)
+}
+
+type deviceContextKey struct{}
+
+func ContextWithDevice(ctx context.Context, device Device) context.Context {
+	return context.WithValue(ctx, deviceContextKey{}, device)
+}
+
+func DeviceFromContext(ctx context.Context) (Device, bool) {
+	device, ok := ctx.Value(deviceContextKey{}).(Device)
+	return device, ok
+}
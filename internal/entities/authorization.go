@@ -0,0 +1,31 @@
+package entity
+
+import (
+	"context"
+	"fmt"
+	"slices"
+)
+
+// Authorize checks the credentials stored in the context, letting sessions
+// with one of the roles through, as well as API keys holding the scope. An
+// empty scope rejects every API key.
+func Authorize(ctx context.Context, scope ApiKeyScope, roles []Role) error {
+	session, hasSession := SessionFromContext(ctx)
+	if hasSession && slices.Contains(roles, session.Role) {
+		return nil
+	}
+	device, hasDevice := DeviceFromContext(ctx)
+	if hasDevice && scope != "" && device.HasScope(scope) {
+		return nil
+	}
+	switch {
+	case hasSession:
+		return fmt.Errorf("%w: role '%s' is not allowed", ErrForbidden, session.Role)
+	case hasDevice && scope == "":
+		return fmt.Errorf("%w: api keys are not accepted", ErrForbidden)
+	case hasDevice:
+		return fmt.Errorf("%w: api key lacks the '%s' scope", ErrForbidden, scope)
+	default:
+		return fmt.Errorf("%w: missing credentials", ErrUnauthorized)
+	}
+}
@@ -9,6 +9,7 @@ type Client struct {
 	Cpf       string // TODO - pode se alterar para um objeto de valor (valueObject) inclusive outros campos
 	Name      string
 	Email     string
+	Language  string
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
@@ -0,0 +1,29 @@
+package entity
+
+import (
+	"time"
+)
+
+type ClientIdentification struct {
+	Id          string
+	ClientId    string
+	CodeHash    string
+	Attempts    int
+	MaxAttempts int
+	ExpiresAt   time.Time
+	VerifiedAt  time.Time
+	CreatedAt   time.Time
+	Destination string
+}
+
+func (i ClientIdentification) IsExpired(now time.Time) bool {
+	return !now.Before(i.ExpiresAt)
+}
+
+func (i ClientIdentification) IsVerified() bool {
+	return !i.VerifiedAt.IsZero()
+}
+
+func (i ClientIdentification) HasAttemptsLeft() bool {
+	return i.Attempts < i.MaxAttempts
+}
@@ -0,0 +1,32 @@
+package entity
+
+import (
+	"time"
+)
+
+type ConsentPurpose string
+
+const (
+	ConsentPurposeMarketingEmail     ConsentPurpose = "marketing_email"
+	ConsentPurposeOrderNotifications ConsentPurpose = "order_notifications"
+)
+
+func (p ConsentPurpose) IsValid() bool {
+	switch p {
+	case ConsentPurposeMarketingEmail, ConsentPurposeOrderNotifications:
+		return true
+	}
+	return false
+}
+
+// ClientConsent is one grant or revocation made by a client. Records are never
+// updated, the most recent one for a purpose is the client's current choice.
+type ClientConsent struct {
+	Id            string
+	ClientId      string
+	Purpose       ConsentPurpose
+	Granted       bool
+	Source        string
+	PolicyVersion string
+	CreatedAt     time.Time
+}
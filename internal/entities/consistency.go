@@ -0,0 +1,17 @@
+package entity
+
+import "context"
+
+type readYourWritesContextKey struct{}
+
+// ContextWithReadYourWrites makes the reads done with the context see every
+// write done before them, for a path that reads back what it just wrote.
+// Without it lists and reports may come from a replica a little behind.
+func ContextWithReadYourWrites(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readYourWritesContextKey{}, true)
+}
+
+func ReadYourWritesFromContext(ctx context.Context) bool {
+	readYourWrites, _ := ctx.Value(readYourWritesContextKey{}).(bool)
+	return readYourWrites
+}
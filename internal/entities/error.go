@@ -2,12 +2,115 @@ package entity
 
 import (
 	"errors"
+	"fmt"
 )
 
 var (
-	ErrInternal        = errors.New("internal error")
-	ErrDataNotFound    = errors.New("data not found")
-	ErrConflictingData = errors.New("data conflicts with existing data in unique column")
-	ErrForbidden       = errors.New("user is forbidden to access the resource")
-	ErrNoUpdatedData   = errors.New("no data to update")
+	ErrInternal            = errors.New("internal error")
+	ErrInvalidData         = errors.New("data is invalid")
+	ErrDataNotFound        = errors.New("data not found")
+	ErrConflictingData     = errors.New("data conflicts with existing data in unique column")
+	ErrUnauthorized        = errors.New("authentication is required to access the resource")
+	ErrForbidden           = errors.New("user is forbidden to access the resource")
+	ErrNoUpdatedData       = errors.New("no data to update")
+	ErrNotificationSkipped = errors.New("notification skipped")
+	ErrRateLimited         = errors.New("too many requests")
+	// ErrPreconditionFailed and ErrPreconditionRequired guard concurrent
+	// updates, which must name the version they were based on.
+	ErrPreconditionFailed   = errors.New("precondition failed")
+	ErrPreconditionRequired = errors.New("precondition required")
 )
+
+// ErrorCode is a stable, machine readable identifier for an error, so clients
+// do not have to match messages.
+type ErrorCode string
+
+const (
+	ErrCodeInternal               ErrorCode = "INTERNAL_ERROR"
+	ErrCodeInvalidData            ErrorCode = "INVALID_DATA"
+	ErrCodeValidationFailed       ErrorCode = "VALIDATION_FAILED"
+	ErrCodeNotFound               ErrorCode = "NOT_FOUND"
+	ErrCodeConflict               ErrorCode = "CONFLICT"
+	ErrCodeUnauthorized           ErrorCode = "UNAUTHORIZED"
+	ErrCodeForbidden              ErrorCode = "FORBIDDEN"
+	ErrCodeNoUpdatedData          ErrorCode = "NO_UPDATED_DATA"
+	ErrCodeRateLimited            ErrorCode = "RATE_LIMITED"
+	ErrCodePreconditionFailed     ErrorCode = "PRECONDITION_FAILED"
+	ErrCodePreconditionRequired   ErrorCode = "PRECONDITION_REQUIRED"
+	ErrCodeVersionMismatch        ErrorCode = "VERSION_MISMATCH"
+	ErrCodeProductNotFound        ErrorCode = "PRODUCT_NOT_FOUND"
+	ErrCodeCategoryNotFound       ErrorCode = "CATEGORY_NOT_FOUND"
+	ErrCodeClientNotFound         ErrorCode = "CLIENT_NOT_FOUND"
+	ErrCodeOrderNotFound          ErrorCode = "ORDER_NOT_FOUND"
+	ErrCodeOrderInvalidTransition ErrorCode = "ORDER_INVALID_TRANSITION"
+	ErrCodeInvalidCredentials     ErrorCode = "INVALID_CREDENTIALS"
+	ErrCodeInvalidApiKey          ErrorCode = "INVALID_API_KEY"
+	ErrCodeIdempotencyKeyReused   ErrorCode = "IDEMPOTENCY_KEY_REUSED"
+	ErrCodeIdempotencyKeyInUse    ErrorCode = "IDEMPOTENCY_KEY_IN_USE"
+)
+
+// kindCodes gives a code to errors that only wrap the sentinels above. It is
+// a slice so an error wrapping several of them always gets the first one's.
+var kindCodes = []struct {
+	kind error
+	code ErrorCode
+}{
+	{ErrInternal, ErrCodeInternal},
+	{ErrInvalidData, ErrCodeInvalidData},
+	{ErrDataNotFound, ErrCodeNotFound},
+	{ErrConflictingData, ErrCodeConflict},
+	{ErrUnauthorized, ErrCodeUnauthorized},
+	{ErrForbidden, ErrCodeForbidden},
+	{ErrNoUpdatedData, ErrCodeNoUpdatedData},
+	{ErrRateLimited, ErrCodeRateLimited},
+	{ErrPreconditionFailed, ErrCodePreconditionFailed},
+	{ErrPreconditionRequired, ErrCodePreconditionRequired},
+}
+
+// DomainError is an error with its own code. It unwraps to its kind, one of
+// the sentinels above, so errors.Is keeps working for callers that only care
+// about the kind.
+type DomainError struct {
+	Kind    error
+	Code    ErrorCode
+	Message string
+}
+
+func NewDomainError(kind error, code ErrorCode, format string, args ...any) *DomainError {
+	return &DomainError{
+		Kind:    kind,
+		Code:    code,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+func (e *DomainError) Error() string {
+	return e.Message
+}
+
+func (e *DomainError) Unwrap() error {
+	return e.Kind
+}
+
+var (
+	ErrProductNotFound  = NewDomainError(ErrDataNotFound, ErrCodeProductNotFound, "product not found")
+	ErrCategoryNotFound = NewDomainError(ErrDataNotFound, ErrCodeCategoryNotFound, "category not found")
+	ErrClientNotFound   = NewDomainError(ErrDataNotFound, ErrCodeClientNotFound, "client not found")
+	ErrOrderNotFound    = NewDomainError(ErrDataNotFound, ErrCodeOrderNotFound, "order not found")
+	ErrVersionMismatch  = NewDomainError(ErrPreconditionFailed, ErrCodeVersionMismatch, "resource was changed by another request")
+)
+
+// ErrorCodeOf returns the code of the first DomainError in the chain, falling
+// back to the code of its first kind and then to ErrCodeInternal.
+func ErrorCodeOf(err error) ErrorCode {
+	var domainErr *DomainError
+	if errors.As(err, &domainErr) {
+		return domainErr.Code
+	}
+	for _, kindCode := range kindCodes {
+		if errors.Is(err, kindCode.kind) {
+			return kindCode.code
+		}
+	}
+	return ErrCodeInternal
+}
@@ -0,0 +1,38 @@
+package entity
+
+import "time"
+
+type HealthStatus string
+
+const (
+	HealthStatusUp       HealthStatus = "up"
+	HealthStatusDegraded HealthStatus = "degraded"
+	HealthStatusDown     HealthStatus = "down"
+)
+
+// DependencyHealth is the outcome of checking one dependency. Error is only
+// set when the dependency is down.
+type DependencyHealth struct {
+	Name     string
+	Required bool
+	Status   HealthStatus
+	Latency  time.Duration
+	Error    string
+}
+
+// HealthReport is up when every dependency is up, degraded when only
+// dependencies that are not required are down, and down otherwise.
+type HealthReport struct {
+	Status       HealthStatus
+	Dependencies []DependencyHealth
+}
+
+func (r HealthReport) IsUp() bool {
+	return r.Status == HealthStatusUp
+}
+
+// IsReady tells whether the instance can take traffic, which it still can
+// while degraded.
+func (r HealthReport) IsReady() bool {
+	return r.Status != HealthStatusDown
+}
@@ -0,0 +1,25 @@
+package entity
+
+import (
+	"time"
+)
+
+// IdempotencyRecord remembers the response of a mutating request so a retry
+// with the same Idempotency-Key gets it back instead of repeating the effect.
+// Keys are scoped by principal so callers cannot see each other's responses.
+type IdempotencyRecord struct {
+	Id           string
+	Principal    string
+	Key          string
+	RequestHash  string
+	StatusCode   int
+	ResponseBody []byte
+	ContentType  string
+	CreatedAt    time.Time
+	CompletedAt  time.Time
+	ExpiresAt    time.Time
+}
+
+func (r IdempotencyRecord) IsCompleted() bool {
+	return !r.CompletedAt.IsZero()
+}
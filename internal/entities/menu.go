@@ -0,0 +1,54 @@
+package entity
+
+// Menu is the catalog as imported and exported between stores. Products are
+// identified by their SKU and refer to their category by name, as ids differ
+// from one store to the other.
+type Menu struct {
+	Categories []string
+	Items      []MenuItem
+}
+
+// MenuItem is one product of an imported or exported menu. Row is its
+// position in the imported file and Errors the problems found while reading
+// it, such as a value that is not a number.
+type MenuItem struct {
+	Row         int
+	Sku         string
+	Name        string
+	Description string
+	Image       string
+	Value       float64
+	Category    string
+	Errors      []string
+}
+
+type MenuImportAction string
+
+const (
+	MenuImportActionCreate    MenuImportAction = "create"
+	MenuImportActionUpdate    MenuImportAction = "update"
+	MenuImportActionUnchanged MenuImportAction = "unchanged"
+	MenuImportActionInvalid   MenuImportAction = "invalid"
+)
+
+// MenuImportRow tells what the import does, or would do on a dry run, with
+// one row. Errors is only set for invalid rows.
+type MenuImportRow struct {
+	Row    int
+	Sku    string
+	Action MenuImportAction
+	Errors []string
+}
+
+// MenuImport reports an import. A menu with any invalid row is not applied
+// at all, so it can be fixed and sent again as a whole.
+type MenuImport struct {
+	DryRun            bool
+	Applied           bool
+	CreatedCategories []string
+	Created           int
+	Updated           int
+	Unchanged         int
+	Invalid           int
+	Rows              []MenuImportRow
+}
@@ -0,0 +1,62 @@
+package entity
+
+import (
+	"time"
+)
+
+type NotificationEvent string
+
+const (
+	NotificationEventOrderPreparing     NotificationEvent = "order_preparing"
+	NotificationEventOrderReady         NotificationEvent = "order_ready"
+	NotificationEventIdentificationCode NotificationEvent = "identification_code"
+)
+
+// consentPurposes maps the events that need the client's consent before being
+// sent. Identification codes are requested by the client and need none.
+var consentPurposes = map[NotificationEvent]ConsentPurpose{
+	NotificationEventOrderPreparing: ConsentPurposeOrderNotifications,
+	NotificationEventOrderReady:     ConsentPurposeOrderNotifications,
+}
+
+func (e NotificationEvent) ConsentPurpose() (ConsentPurpose, bool) {
+	purpose, ok := consentPurposes[e]
+	return purpose, ok
+}
+
+const (
+	NotificationChannelEmail   = "email"
+	NotificationChannelWebhook = "webhook"
+	NotificationChannelLog     = "log"
+)
+
+type NotificationDeliveryStatus string
+
+const (
+	NotificationDeliverySent    NotificationDeliveryStatus = "sent"
+	NotificationDeliveryFailed  NotificationDeliveryStatus = "failed"
+	NotificationDeliverySkipped NotificationDeliveryStatus = "skipped"
+)
+
+type Notification struct {
+	Event       NotificationEvent
+	OrderId     string
+	OrderNumber int
+	Client      Client
+	Language    string
+	Subject     string
+	Body        string
+	OccurredAt  time.Time
+}
+
+type NotificationDelivery struct {
+	Id        string
+	OrderId   string
+	ClientId  string
+	Event     NotificationEvent
+	Channel   string
+	Recipient string
+	Status    NotificationDeliveryStatus
+	Error     string
+	CreatedAt time.Time
+}
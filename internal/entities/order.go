@@ -14,6 +14,15 @@ const (
 	OrderStatusCompleted      OrderStatus = "completed"
 )
 
+// OrderStatuses lists every status in the order an order goes through them.
+var OrderStatuses = []OrderStatus{
+	OrderStatusPaymentPending,
+	OrderStatusReceived,
+	OrderStatusPreparing,
+	OrderStatusReady,
+	OrderStatusCompleted,
+}
+
 type Order struct {
 	Id        string
 	Number    int
@@ -21,6 +30,7 @@ type Order struct {
 	ClientId  string
 	PaymentId string
 	Total     float64
+	Version   int
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
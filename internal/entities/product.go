@@ -6,12 +6,14 @@ import (
 
 type Product struct {
 	Id          string
+	Sku         string
 	Name        string
 	Description string
 	Image       string
 	Value       float64
 	CategoryId  string
 	Category    Category
+	Version     int
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 }
@@ -0,0 +1,65 @@
+package entity
+
+import (
+	"math"
+	"time"
+)
+
+// RateLimit allows Requests per Period, with bursts of up to Requests.
+type RateLimit struct {
+	Requests int
+	Period   time.Duration
+}
+
+func (l RateLimit) IsEnabled() bool {
+	return l.Requests > 0 && l.Period > 0
+}
+
+// refillRate is the number of tokens added back per second.
+func (l RateLimit) refillRate() float64 {
+	return float64(l.Requests) / l.Period.Seconds()
+}
+
+// TokenBucket is the state kept per rate limited key. A zero bucket is full.
+type TokenBucket struct {
+	Tokens    float64
+	UpdatedAt time.Time
+}
+
+// FullAt is when the bucket holds Requests tokens again, after which it can be
+// forgotten without changing any decision.
+func (b TokenBucket) FullAt(limit RateLimit) time.Time {
+	missing := float64(limit.Requests) - b.Tokens
+	return b.UpdatedAt.Add(time.Duration(missing / limit.refillRate() * float64(time.Second)))
+}
+
+// Take refills the bucket up to now and spends one token when available.
+func (b TokenBucket) Take(limit RateLimit, now time.Time) (TokenBucket, RateLimitDecision) {
+	capacity := float64(limit.Requests)
+	tokens := capacity
+	if !b.UpdatedAt.IsZero() {
+		elapsed := now.Sub(b.UpdatedAt).Seconds()
+		tokens = math.Min(capacity, b.Tokens+math.Max(elapsed, 0)*limit.refillRate())
+	}
+	decision := RateLimitDecision{Limit: limit}
+	if tokens >= 1 {
+		tokens--
+		decision.Allowed = true
+	} else {
+		decision.RetryAfter = time.Duration((1 - tokens) / limit.refillRate() * float64(time.Second))
+	}
+	bucket := TokenBucket{Tokens: tokens, UpdatedAt: now}
+	decision.Remaining = int(math.Floor(tokens))
+	decision.Reset = bucket.FullAt(limit).Sub(now)
+	return bucket, decision
+}
+
+// RateLimitDecision tells whether a request may go through and how the
+// caller's quota looks afterwards.
+type RateLimitDecision struct {
+	Allowed    bool
+	Limit      RateLimit
+	Remaining  int
+	Reset      time.Duration
+	RetryAfter time.Duration
+}
@@ -0,0 +1,47 @@
+package entity
+
+import (
+	"context"
+	"time"
+)
+
+type Role string
+
+const (
+	RoleCustomer Role = "customer"
+	RoleKiosk    Role = "kiosk"
+	RoleKitchen  Role = "kitchen"
+	RoleAdmin    Role = "admin"
+)
+
+// IsStaff reports whether the role belongs to a user stored in the database
+// rather than to an identified client.
+func (r Role) IsStaff() bool {
+	switch r {
+	case RoleKiosk, RoleKitchen, RoleAdmin:
+		return true
+	}
+	return false
+}
+
+type Session struct {
+	Subject   string
+	Role      Role
+	ExpiresAt time.Time
+}
+
+type Token struct {
+	AccessToken string
+	ExpiresAt   time.Time
+}
+
+type sessionContextKey struct{}
+
+func ContextWithSession(ctx context.Context, session Session) context.Context {
+	return context.WithValue(ctx, sessionContextKey{}, session)
+}
+
+func SessionFromContext(ctx context.Context) (Session, bool) {
+	session, ok := ctx.Value(sessionContextKey{}).(Session)
+	return session, ok
+}
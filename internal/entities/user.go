@@ -0,0 +1,15 @@
+package entity
+
+import (
+	"time"
+)
+
+type User struct {
+	Id           string
+	Username     string
+	PasswordHash string
+	Role         Role
+	Active       bool
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
@@ -0,0 +1,52 @@
+package events
+
+import (
+	"context"
+	"log/slog"
+	entity "post-tech-challenge-10soat/internal/entities"
+	"sync"
+)
+
+// subscriberBuffer absorbs bursts of status changes. A subscriber that falls
+// further behind misses events instead of slowing down the publisher.
+const subscriberBuffer = 32
+
+// MemoryOrderEventGatewayImpl fans out events inside the process, so
+// subscribers only see changes made through the same instance.
+type MemoryOrderEventGatewayImpl struct {
+	mu          sync.Mutex
+	subscribers map[chan entity.Order]struct{}
+}
+
+func NewMemoryOrderEventGatewayImpl() *MemoryOrderEventGatewayImpl {
+	return &MemoryOrderEventGatewayImpl{
+		subscribers: map[chan entity.Order]struct{}{},
+	}
+}
+
+func (g *MemoryOrderEventGatewayImpl) PublishOrderStatus(ctx context.Context, order entity.Order) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for subscriber := range g.subscribers {
+		select {
+		case subscriber <- order:
+		default:
+			slog.WarnContext(ctx, "Dropping order status event for a slow subscriber", "order_id", order.Id, "status", order.Status)
+		}
+	}
+}
+
+func (g *MemoryOrderEventGatewayImpl) SubscribeOrderStatus(ctx context.Context) <-chan entity.Order {
+	subscriber := make(chan entity.Order, subscriberBuffer)
+	g.mu.Lock()
+	g.subscribers[subscriber] = struct{}{}
+	g.mu.Unlock()
+	go func() {
+		<-ctx.Done()
+		g.mu.Lock()
+		delete(g.subscribers, subscriber)
+		close(subscriber)
+		g.mu.Unlock()
+	}()
+	return subscriber
+}
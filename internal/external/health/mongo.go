@@ -0,0 +1,45 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"post-tech-challenge-10soat/internal/external/mongo"
+
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+// MongoHealthCheckGatewayImpl pings the client and, like the Postgres check,
+// accepts a database that has at least the last migration of this build.
+// A failed Mongo migration is simply not recorded, so there is no dirty state.
+type MongoHealthCheckGatewayImpl struct {
+	mongo           *mongo.MONGO
+	expectedVersion uint
+}
+
+func NewMongoHealthCheckGatewayImpl(mongoDb *mongo.MONGO) *MongoHealthCheckGatewayImpl {
+	return &MongoHealthCheckGatewayImpl{
+		mongoDb,
+		mongo.LatestMigrationVersion(),
+	}
+}
+
+func (g *MongoHealthCheckGatewayImpl) Name() string {
+	return "mongo"
+}
+
+// Required is false because only clients live in Mongo. Without it the menu
+// and anonymous orders keep working, and the kitchen keeps its queue.
+func (g *MongoHealthCheckGatewayImpl) Required() bool {
+	return false
+}
+
+func (g *MongoHealthCheckGatewayImpl) Check(ctx context.Context) error {
+	if err := g.mongo.Client.Ping(ctx, readpref.Primary()); err != nil {
+		return err
+	}
+	version, err := g.mongo.Version(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read migration version - %w", err)
+	}
+	return checkMigrationVersion(version, false, g.expectedVersion)
+}
@@ -0,0 +1,56 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"post-tech-challenge-10soat/internal/external/mongo"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestMongoHealthCheckGatewayImpl_Check(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	latest := mongo.LatestMigrationVersion()
+	migrated := func(mt *mtest.T, version uint) bson.D {
+		return mtest.CreateCursorResponse(0, mt.DB.Name()+".schema_migrations", mtest.FirstBatch, bson.D{{Key: "_id", Value: int64(version)}})
+	}
+
+	mt.Run("migrated", func(mt *mtest.T) {
+		// Setup
+		gateway := NewMongoHealthCheckGatewayImpl(&mongo.MONGO{Client: mt.Client, Database: mt.DB})
+		mt.AddMockResponses(mtest.CreateSuccessResponse(), migrated(mt, latest))
+
+		// Execute
+		err := gateway.Check(context.Background())
+
+		// Assert
+		assert.NoError(t, err)
+	})
+
+	mt.Run("behind the build", func(mt *mtest.T) {
+		// Setup
+		gateway := NewMongoHealthCheckGatewayImpl(&mongo.MONGO{Client: mt.Client, Database: mt.DB})
+		mt.AddMockResponses(mtest.CreateSuccessResponse(), migrated(mt, latest-1))
+
+		// Execute
+		err := gateway.Check(context.Background())
+
+		// Assert
+		assert.EqualError(t, err, fmt.Sprintf("migration version is %d, expected at least %d", latest-1, latest))
+	})
+
+	mt.Run("never migrated", func(mt *mtest.T) {
+		// Setup
+		gateway := NewMongoHealthCheckGatewayImpl(&mongo.MONGO{Client: mt.Client, Database: mt.DB})
+		mt.AddMockResponses(mtest.CreateSuccessResponse(), mtest.CreateCursorResponse(0, mt.DB.Name()+".schema_migrations", mtest.FirstBatch))
+
+		// Execute
+		err := gateway.Check(context.Background())
+
+		// Assert
+		assert.EqualError(t, err, fmt.Sprintf("migration version is 0, expected at least %d", latest))
+	})
+}
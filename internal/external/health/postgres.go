@@ -0,0 +1,57 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"post-tech-challenge-10soat/internal/external/postgres"
+)
+
+// PostgresHealthCheckGatewayImpl pings the pool and checks that the database
+// has at least the last migration embedded in this build, so a pod never
+// serves traffic against a schema older than it expects. A newer schema is
+// fine: during a rolling deploy the new pods migrate first and the old ones
+// must keep serving until they are replaced.
+type PostgresHealthCheckGatewayImpl struct {
+	db              *postgres.DB
+	expectedVersion uint
+}
+
+func NewPostgresHealthCheckGatewayImpl(db *postgres.DB) (*PostgresHealthCheckGatewayImpl, error) {
+	expectedVersion, err := postgres.LatestMigrationVersion()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read embedded migrations - %w", err)
+	}
+	return &PostgresHealthCheckGatewayImpl{
+		db,
+		expectedVersion,
+	}, nil
+}
+
+func (g *PostgresHealthCheckGatewayImpl) Name() string {
+	return "postgres"
+}
+
+func (g *PostgresHealthCheckGatewayImpl) Required() bool {
+	return true
+}
+
+func (g *PostgresHealthCheckGatewayImpl) Check(ctx context.Context) error {
+	if err := g.db.Ping(ctx); err != nil {
+		return err
+	}
+	version, dirty, err := g.db.MigrationVersion(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read migration version - %w", err)
+	}
+	return checkMigrationVersion(version, dirty, g.expectedVersion)
+}
+
+func checkMigrationVersion(version uint, dirty bool, expectedVersion uint) error {
+	if dirty {
+		return fmt.Errorf("migration %d is dirty", version)
+	}
+	if version < expectedVersion {
+		return fmt.Errorf("migration version is %d, expected at least %d", version, expectedVersion)
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package health
+
+import (
+	"context"
+	"post-tech-challenge-10soat/internal/external/postgres"
+)
+
+// PostgresReplicaHealthCheckGatewayImpl pings the replica. Migrations reach
+// it through the primary, so its version is not checked.
+type PostgresReplicaHealthCheckGatewayImpl struct {
+	db *postgres.DB
+}
+
+func NewPostgresReplicaHealthCheckGatewayImpl(db *postgres.DB) *PostgresReplicaHealthCheckGatewayImpl {
+	return &PostgresReplicaHealthCheckGatewayImpl{
+		db,
+	}
+}
+
+func (g *PostgresReplicaHealthCheckGatewayImpl) Name() string {
+	return "postgres-replica"
+}
+
+// Required is false because orders are still taken on the primary while
+// the replica is away. Only lists and reports fail.
+func (g *PostgresReplicaHealthCheckGatewayImpl) Required() bool {
+	return false
+}
+
+func (g *PostgresReplicaHealthCheckGatewayImpl) Check(ctx context.Context) error {
+	return g.db.Replica().Ping(ctx)
+}
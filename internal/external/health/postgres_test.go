@@ -0,0 +1,15 @@
+package health
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckMigrationVersion(t *testing.T) {
+	assert.NoError(t, checkMigrationVersion(19, false, 19))
+	// A newer pod already migrated during a rolling deploy.
+	assert.NoError(t, checkMigrationVersion(20, false, 19))
+	assert.EqualError(t, checkMigrationVersion(18, false, 19), "migration version is 18, expected at least 19")
+	assert.EqualError(t, checkMigrationVersion(20, true, 19), "migration 20 is dirty")
+}
@@ -0,0 +1,66 @@
+package metrics
+
+import (
+	"post-tech-challenge-10soat/internal/external/postgres"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// pgxPoolCollector reads the pool statistics at scrape time, so they are
+// never older than the scrape itself.
+type pgxPoolCollector struct {
+	db                      *postgres.DB
+	acquiredConns           *prometheus.Desc
+	idleConns               *prometheus.Desc
+	constructingConns       *prometheus.Desc
+	totalConns              *prometheus.Desc
+	maxConns                *prometheus.Desc
+	acquireCount            *prometheus.Desc
+	acquireDuration         *prometheus.Desc
+	canceledAcquireCount    *prometheus.Desc
+	emptyAcquireCount       *prometheus.Desc
+	newConnsCount           *prometheus.Desc
+	maxLifetimeDestroyCount *prometheus.Desc
+	maxIdleDestroyCount     *prometheus.Desc
+}
+
+func newPgxPoolCollector(db *postgres.DB) *pgxPoolCollector {
+	desc := func(name string, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(namespace, "pgxpool", name), help, nil, nil)
+	}
+	return &pgxPoolCollector{
+		db:                      db,
+		acquiredConns:           desc("acquired_conns", "Connections currently in use."),
+		idleConns:               desc("idle_conns", "Connections currently idle."),
+		constructingConns:       desc("constructing_conns", "Connections being opened."),
+		totalConns:              desc("total_conns", "Connections in the pool, in use, idle or being opened."),
+		maxConns:                desc("max_conns", "Maximum size of the pool."),
+		acquireCount:            desc("acquire_count_total", "Successful connection acquires."),
+		acquireDuration:         desc("acquire_duration_seconds_total", "Time spent waiting for successful acquires."),
+		canceledAcquireCount:    desc("canceled_acquire_count_total", "Acquires canceled by their context."),
+		emptyAcquireCount:       desc("empty_acquire_count_total", "Acquires that waited because the pool had no idle connection."),
+		newConnsCount:           desc("new_conns_count_total", "Connections opened."),
+		maxLifetimeDestroyCount: desc("max_lifetime_destroy_count_total", "Connections closed for reaching their maximum lifetime."),
+		maxIdleDestroyCount:     desc("max_idle_destroy_count_total", "Connections closed for staying idle too long."),
+	}
+}
+
+func (c *pgxPoolCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+func (c *pgxPoolCollector) Collect(ch chan<- prometheus.Metric) {
+	stat := c.db.Stat()
+	ch <- prometheus.MustNewConstMetric(c.acquiredConns, prometheus.GaugeValue, float64(stat.AcquiredConns()))
+	ch <- prometheus.MustNewConstMetric(c.idleConns, prometheus.GaugeValue, float64(stat.IdleConns()))
+	ch <- prometheus.MustNewConstMetric(c.constructingConns, prometheus.GaugeValue, float64(stat.ConstructingConns()))
+	ch <- prometheus.MustNewConstMetric(c.totalConns, prometheus.GaugeValue, float64(stat.TotalConns()))
+	ch <- prometheus.MustNewConstMetric(c.maxConns, prometheus.GaugeValue, float64(stat.MaxConns()))
+	ch <- prometheus.MustNewConstMetric(c.acquireCount, prometheus.CounterValue, float64(stat.AcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.acquireDuration, prometheus.CounterValue, stat.AcquireDuration().Seconds())
+	ch <- prometheus.MustNewConstMetric(c.canceledAcquireCount, prometheus.CounterValue, float64(stat.CanceledAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.emptyAcquireCount, prometheus.CounterValue, float64(stat.EmptyAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.newConnsCount, prometheus.CounterValue, float64(stat.NewConnsCount()))
+	ch <- prometheus.MustNewConstMetric(c.maxLifetimeDestroyCount, prometheus.CounterValue, float64(stat.MaxLifetimeDestroyCount()))
+	ch <- prometheus.MustNewConstMetric(c.maxIdleDestroyCount, prometheus.CounterValue, float64(stat.MaxIdleDestroyCount()))
+}
@@ -0,0 +1,102 @@
+package metrics
+
+import (
+	"net/http"
+	entity "post-tech-challenge-10soat/internal/entities"
+	"post-tech-challenge-10soat/internal/external/postgres"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "pos"
+
+// PrometheusMetricsGatewayImpl keeps the metrics in its own registry, next
+// to the Go runtime, process and pgx pool collectors, and serves them in the
+// Prometheus text format.
+type PrometheusMetricsGatewayImpl struct {
+	registry               *prometheus.Registry
+	httpRequestDuration    *prometheus.HistogramVec
+	ordersCreated          prometheus.Counter
+	orderStatusTransitions *prometheus.CounterVec
+	paymentsFinished       *prometheus.CounterVec
+	ordersByStatus         *prometheus.GaugeVec
+}
+
+func NewPrometheusMetricsGatewayImpl(db *postgres.DB) *PrometheusMetricsGatewayImpl {
+	g := &PrometheusMetricsGatewayImpl{
+		registry: prometheus.NewRegistry(),
+		httpRequestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "Duration of the HTTP requests by method, route and status.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		ordersCreated: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "orders_created_total",
+			Help:      "Orders created.",
+		}),
+		orderStatusTransitions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "order_status_transitions_total",
+			Help:      "Order status changes by previous and new status.",
+		}, []string{"from", "to"}),
+		paymentsFinished: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "payments_total",
+			Help:      "Payments by outcome, approved or failed.",
+		}, []string{"outcome"}),
+		ordersByStatus: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "orders",
+			Help:      "Orders in each status, counted when the metrics are scraped.",
+		}, []string{"status"}),
+	}
+	g.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		newPgxPoolCollector(db),
+		g.httpRequestDuration,
+		g.ordersCreated,
+		g.orderStatusTransitions,
+		g.paymentsFinished,
+		g.ordersByStatus,
+	)
+	return g
+}
+
+// Handler serves the registry for the scraper.
+func (g *PrometheusMetricsGatewayImpl) Handler() http.Handler {
+	return promhttp.HandlerFor(g.registry, promhttp.HandlerOpts{})
+}
+
+func (g *PrometheusMetricsGatewayImpl) ObserveHttpRequest(method string, route string, status int, duration time.Duration) {
+	g.httpRequestDuration.WithLabelValues(method, route, strconv.Itoa(status)).Observe(duration.Seconds())
+}
+
+func (g *PrometheusMetricsGatewayImpl) OrderCreated() {
+	g.ordersCreated.Inc()
+}
+
+func (g *PrometheusMetricsGatewayImpl) OrderStatusChanged(from entity.OrderStatus, to entity.OrderStatus) {
+	g.orderStatusTransitions.WithLabelValues(string(from), string(to)).Inc()
+}
+
+func (g *PrometheusMetricsGatewayImpl) PaymentFinished(approved bool) {
+	outcome := "failed"
+	if approved {
+		outcome = "approved"
+	}
+	g.paymentsFinished.WithLabelValues(outcome).Inc()
+}
+
+func (g *PrometheusMetricsGatewayImpl) SetOrdersByStatus(counts map[entity.OrderStatus]int) {
+	for status, count := range counts {
+		g.ordersByStatus.WithLabelValues(string(status)).Set(float64(count))
+	}
+}
@@ -0,0 +1,24 @@
+package mongo
+
+import (
+	"errors"
+	entity "post-tech-challenge-10soat/internal/entities"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// TranslateError maps mongo driver errors into the domain errors declared in
+// the entity package so the upper layers never depend on the database driver.
+func TranslateError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, mongo.ErrNoDocuments) || errors.Is(err, primitive.ErrInvalidHex) {
+		return entity.ErrDataNotFound
+	}
+	if mongo.IsDuplicateKeyError(err) {
+		return entity.ErrConflictingData
+	}
+	return err
+}
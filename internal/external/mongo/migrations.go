@@ -0,0 +1,274 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	ClientCollection         = "client"
+	migrationsCollection     = "schema_migrations"
+	migrationsLockCollection = "schema_migrations_lock"
+	migrationsLockId         = "migrations"
+)
+
+const (
+	// migrationsLease is how long the lock outlives a holder that stopped
+	// renewing it, such as a replica killed mid-migration. The holder
+	// renews it every third of that while it runs.
+	migrationsLease = time.Minute
+	// migrationsLockPoll is how often a waiting replica tries the lock again.
+	migrationsLockPoll = 500 * time.Millisecond
+)
+
+type migration struct {
+	Version     uint
+	Description string
+	Up          func(ctx context.Context, db *mongo.Database) error
+}
+
+// migrations are applied in order and recorded in the schema_migrations
+// collection, so each version runs only once per database.
+var migrations = []migration{
+	{
+		Version:     1,
+		Description: "backfill client timestamps",
+		Up:          backfillClientTimestamps,
+	},
+	{
+		Version:     2,
+		Description: "client json schema validator",
+		Up:          createClientValidator,
+	},
+	{
+		Version:     3,
+		Description: "client unique indexes on cpf and email",
+		Up:          createClientIndexes,
+	},
+}
+
+type migrationRecord struct {
+	Version     uint      `bson:"_id"`
+	Description string    `bson:"description"`
+	AppliedAt   time.Time `bson:"appliedAt"`
+}
+
+func (m *MONGO) Migrate(ctx context.Context) error {
+	unlock, err := m.lockMigrations(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	records := m.Database.Collection(migrationsCollection)
+	for _, migration := range migrations {
+		err := records.FindOne(ctx, bson.M{"_id": migration.Version}).Err()
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, mongo.ErrNoDocuments) {
+			return err
+		}
+		if err := migration.Up(ctx, m.Database); err != nil {
+			return fmt.Errorf("mongo migration %d (%s) failed - %w", migration.Version, migration.Description, err)
+		}
+		_, err = records.InsertOne(ctx, migrationRecord{
+			Version:     migration.Version,
+			Description: migration.Description,
+			AppliedAt:   time.Now().UTC(),
+		})
+		if err != nil {
+			return err
+		}
+		slog.Info("Mongo migration applied", "version", migration.Version, "description", migration.Description)
+	}
+	slog.Info("Mongo migrations applied successfully")
+	return nil
+}
+
+// lockMigrations claims the lease document, so replicas starting together
+// apply migrations one at a time, waiting for it as long as the context
+// allows. The returned function stops renewing the lease and releases it.
+func (m *MONGO) lockMigrations(ctx context.Context) (func(), error) {
+	lock := m.Database.Collection(migrationsLockCollection)
+	owner := primitive.NewObjectID().Hex()
+	waiting := false
+	for {
+		claimed, err := claimMigrationsLease(ctx, lock, owner)
+		if err != nil {
+			return nil, fmt.Errorf("failed to take the mongo migration lock - %w", err)
+		}
+		if claimed {
+			break
+		}
+		if !waiting {
+			slog.InfoContext(ctx, "Waiting for another process to finish the mongo migrations")
+			waiting = true
+		}
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("failed to take the mongo migration lock - %w", ctx.Err())
+		case <-time.After(migrationsLockPoll):
+		}
+	}
+
+	renewCtx, stop := context.WithCancel(context.WithoutCancel(ctx))
+	renewed := make(chan struct{})
+	go func() {
+		defer close(renewed)
+		ticker := time.NewTicker(migrationsLease / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-renewCtx.Done():
+				return
+			case <-ticker.C:
+				claimed, err := claimMigrationsLease(renewCtx, lock, owner)
+				if err == nil && !claimed {
+					err = errors.New("the lease was taken by another process")
+				}
+				if err != nil && renewCtx.Err() == nil {
+					slog.WarnContext(ctx, "Error renewing the mongo migration lock", "error", err)
+				}
+			}
+		}
+	}()
+
+	return func() {
+		stop()
+		<-renewed
+		// The lease expires on its own anyway if this fails.
+		releaseCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+		defer cancel()
+		if _, err := lock.DeleteOne(releaseCtx, bson.M{"_id": migrationsLockId, "owner": owner}); err != nil {
+			slog.WarnContext(ctx, "Error releasing the mongo migration lock", "error", err)
+		}
+	}, nil
+}
+
+// claimMigrationsLease takes the lease when it is free, expired or already
+// ours, extending it. While another process holds it the filter matches
+// nothing and the upsert collides with its document on the _id.
+func claimMigrationsLease(ctx context.Context, lock *mongo.Collection, owner string) (bool, error) {
+	now := time.Now().UTC()
+	filter := bson.M{
+		"_id": migrationsLockId,
+		"$or": bson.A{
+			bson.M{"owner": owner},
+			bson.M{"expiresAt": bson.M{"$lte": now}},
+		},
+	}
+	update := bson.M{"$set": bson.M{"owner": owner, "expiresAt": now.Add(migrationsLease)}}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	err := lock.FindOneAndUpdate(ctx, filter, update, opts).Err()
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// LatestMigrationVersion returns the version of the last migration in this
+// build, which the database must have reached before clients are served.
+func LatestMigrationVersion() uint {
+	return migrations[len(migrations)-1].Version
+}
+
+// Version returns the latest applied migration version, or zero when none ran.
+func (m *MONGO) Version(ctx context.Context) (uint, error) {
+	var record migrationRecord
+	opts := options.FindOne().SetSort(bson.D{{Key: "_id", Value: -1}})
+	err := m.Database.Collection(migrationsCollection).FindOne(ctx, bson.M{}, opts).Decode(&record)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return record.Version, nil
+}
+
+func backfillClientTimestamps(ctx context.Context, db *mongo.Database) error {
+	// Documents written before this migration carry either no timestamps or
+	// the zero time, so both are replaced by the migration time.
+	filter := bson.M{"$or": bson.A{
+		bson.M{"createdAt": bson.M{"$exists": false}},
+		bson.M{"createdAt": bson.M{"$lte": time.Unix(0, 0)}},
+	}}
+	update := bson.A{
+		bson.M{"$set": bson.M{"createdAt": "$$NOW", "updatedAt": "$$NOW"}},
+	}
+	_, err := db.Collection(ClientCollection).UpdateMany(ctx, filter, update)
+	return err
+}
+
+var clientSchema = bson.M{
+	"bsonType": "object",
+	"required": bson.A{"cpf", "name", "email", "createdAt", "updatedAt"},
+	"properties": bson.M{
+		"cpf": bson.M{
+			"bsonType":  "string",
+			"minLength": 1,
+		},
+		"name": bson.M{
+			"bsonType":  "string",
+			"minLength": 1,
+		},
+		"email": bson.M{
+			"bsonType": "string",
+			"pattern":  "^[^@\\s]+@[^@\\s]+$",
+		},
+		"language": bson.M{
+			"enum": bson.A{"pt-BR", "en", "es"},
+		},
+		"createdAt": bson.M{
+			"bsonType": "date",
+		},
+		"updatedAt": bson.M{
+			"bsonType": "date",
+		},
+	},
+}
+
+func createClientValidator(ctx context.Context, db *mongo.Database) error {
+	validator := bson.M{"$jsonSchema": clientSchema}
+	names, err := db.ListCollectionNames(ctx, bson.M{"name": ClientCollection})
+	if err != nil {
+		return err
+	}
+	if len(names) == 0 {
+		opts := options.CreateCollection().
+			SetValidator(validator).
+			SetValidationLevel("moderate")
+		return db.CreateCollection(ctx, ClientCollection, opts)
+	}
+	return db.RunCommand(ctx, bson.D{
+		{Key: "collMod", Value: ClientCollection},
+		{Key: "validator", Value: validator},
+		{Key: "validationLevel", Value: "moderate"},
+	}).Err()
+}
+
+func createClientIndexes(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection(ClientCollection).Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "cpf", Value: 1}},
+			Options: options.Index().SetName("client_cpf_unique").SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "email", Value: 1}},
+			Options: options.Index().SetName("client_email_unique").SetUnique(true),
+		},
+	})
+	return err
+}
@@ -0,0 +1,106 @@
+package mongo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func leaseClaimed(owner string) bson.D {
+	return mtest.CreateSuccessResponse(bson.E{Key: "value", Value: bson.D{
+		{Key: "_id", Value: migrationsLockId},
+		{Key: "owner", Value: owner},
+	}})
+}
+
+func leaseHeld() bson.D {
+	return mtest.CreateCommandErrorResponse(mtest.CommandError{
+		Code:    11000,
+		Name:    "DuplicateKey",
+		Message: "E11000 duplicate key error collection: schema_migrations_lock",
+	})
+}
+
+func TestClaimMigrationsLease(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("free", func(mt *mtest.T) {
+		// Setup
+		mt.AddMockResponses(leaseClaimed("owner-1"))
+
+		// Execute
+		claimed, err := claimMigrationsLease(context.Background(), mt.Coll, "owner-1")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.True(t, claimed)
+		started := mt.GetStartedEvent()
+		assert.Equal(t, "findAndModify", started.CommandName)
+		assert.True(t, started.Command.Lookup("upsert").Boolean())
+	})
+
+	mt.Run("held by another process", func(mt *mtest.T) {
+		// Setup
+		mt.AddMockResponses(leaseHeld())
+
+		// Execute
+		claimed, err := claimMigrationsLease(context.Background(), mt.Coll, "owner-1")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.False(t, claimed)
+	})
+
+	mt.Run("error", func(mt *mtest.T) {
+		// Setup
+		mt.AddMockResponses(mtest.CreateCommandErrorResponse(mtest.CommandError{Code: 13, Name: "Unauthorized", Message: "not authorized"}))
+
+		// Execute
+		claimed, err := claimMigrationsLease(context.Background(), mt.Coll, "owner-1")
+
+		// Assert
+		assert.Error(t, err)
+		assert.False(t, claimed)
+	})
+}
+
+func TestMONGO_LockMigrations(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("waits for the holder", func(mt *mtest.T) {
+		// Setup
+		m := &MONGO{Client: mt.Client, Database: mt.DB}
+		mt.AddMockResponses(leaseHeld(), leaseHeld(), leaseClaimed("owner-1"), mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}))
+
+		// Execute
+		unlock, err := m.lockMigrations(context.Background())
+
+		// Assert
+		assert.NoError(t, err)
+		unlock()
+		var commands []string
+		for event := mt.GetStartedEvent(); event != nil; event = mt.GetStartedEvent() {
+			commands = append(commands, event.CommandName)
+		}
+		assert.Equal(t, []string{"findAndModify", "findAndModify", "findAndModify", "delete"}, commands)
+	})
+
+	mt.Run("gives up with the context", func(mt *mtest.T) {
+		// Setup
+		m := &MONGO{Client: mt.Client, Database: mt.DB}
+		mt.AddMockResponses(leaseHeld())
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		// Execute
+		unlock, err := m.lockMigrations(ctx)
+
+		// Assert
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Nil(t, unlock)
+	})
+}
@@ -10,6 +10,7 @@ type ClientModel struct {
 	Cpf       string    `bson:"cpf"`
 	Name      string    `bson:"name"`
 	Email     string    `bson:"email"`
+	Language  string    `bson:"language,omitempty"`
 	CreatedAt time.Time `bson:"createdAt"`
 	UpdatedAt time.Time `bson:"updatedAt"`
 }
@@ -20,6 +21,7 @@ func (m ClientModel) ToDTO() dto.ClientDTO {
 		Cpf:       m.Cpf,
 		Name:      m.Name,
 		Email:     m.Email,
+		Language:  m.Language,
 		CreatedAt: m.CreatedAt,
 		UpdatedAt: m.UpdatedAt,
 	}
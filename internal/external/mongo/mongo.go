@@ -2,13 +2,17 @@ package mongo
 
 import (
 	"context"
-	"fmt"
 	"log/slog"
+	"net"
+	"net/url"
 	"post-tech-challenge-10soat/internal/infrastructure/config"
+	"post-tech-challenge-10soat/internal/utils"
+	"strings"
 	"time"
 
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.opentelemetry.io/contrib/instrumentation/go.mongodb.org/mongo-driver/mongo/otelmongo"
 )
 
 type MONGO struct {
@@ -16,41 +20,50 @@ type MONGO struct {
 	Database *mongo.Database
 }
 
+// New connects and waits for the server to answer, retrying with backoff
+// as configured. Once up, the driver keeps monitoring the servers and
+// reconnects on its own, so an outage only fails the commands sent during
+// it.
 func New(ctx context.Context, config *config.MONGO) (*MONGO, error) {
-	// Create connection string with authSource=admin for proper authentication
-	uri := fmt.Sprintf("mongodb://%s:%s@%s:%s/%s?authSource=admin",
-		config.User,
-		config.Password,
-		config.Host,
-		config.Port,
-		config.Name,
-	)
+	uri := connectionUri(config)
 
-	// Create client options with detailed configuration
-	clientOptions := options.Client().ApplyURI(uri)
-	clientOptions.SetAuth(options.Credential{
-		Username:   config.User,
-		Password:   config.Password,
-		AuthSource: "admin",
-		AuthMechanism: "SCRAM-SHA-1",
-	})
+	// The pool settings come first so the ones in a full URI win.
+	clientOptions := options.Client().
+		SetMaxPoolSize(uint64(config.MaxPoolSize)).
+		SetMinPoolSize(uint64(config.MinPoolSize)).
+		SetConnectTimeout(config.ConnectTimeout).
+		// While the server is away, commands fail after this long instead
+		// of the default 30 seconds.
+		SetServerSelectionTimeout(config.ConnectTimeout).
+		ApplyURI(uri)
+	// Every command becomes a span under the one of the request running it.
+	clientOptions.SetMonitor(otelmongo.NewMonitor())
 
-	// Enable detailed logging
-	slog.Info("Attempting MongoDB connection", "uri", maskCredentials(uri))
+	slog.Info("Attempting MongoDB connection", "uri", utils.MaskCredentials(uri))
 
+	// Connect only validates the options, servers are dialed in the
+	// background.
 	client, err := mongo.Connect(ctx, clientOptions)
 
 	if err != nil {
-		slog.Error("Error connecting to MongoDB", "error", err, "uri", maskCredentials(uri))
+		slog.Error("Error connecting to MongoDB", "error", err, "uri", utils.MaskCredentials(uri))
 		return nil, err
 	}
 
-	// Test connection
-	ctxPing, cancel := context.WithTimeout(ctx, 5*time.Second)
-	defer cancel()
-
-	if err := client.Ping(ctxPing, nil); err != nil {
-		slog.Error("Error pinging MongoDB", "error", err)
+	backoff := utils.Backoff{
+		Retries: config.ConnectRetries,
+		Initial: config.ConnectRetryBackoff,
+		Max:     config.ConnectRetryMaxBackoff,
+	}
+	err = utils.Retry(ctx, backoff, "connecting to mongo", func(ctx context.Context) error {
+		ctxPing, cancel := context.WithTimeout(ctx, config.ConnectTimeout)
+		defer cancel()
+		return client.Ping(ctxPing, nil)
+	})
+	if err != nil {
+		disconnectCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = client.Disconnect(disconnectCtx)
 		return nil, err
 	}
 
@@ -62,6 +75,52 @@ func New(ctx context.Context, config *config.MONGO) (*MONGO, error) {
 	}, nil
 }
 
-func maskCredentials(uri string) string {
-	return "mongodb://****:****@${DB_HOST}:${DB_PORT}/${DB_NAME}"
+// Close disconnects the client, waiting for in-use connections to be
+// returned to the pool until the context is done.
+func (m *MONGO) Close(ctx context.Context) error {
+	return m.Client.Disconnect(ctx)
+}
+
+// connectionUri builds the URI from the settings, unless a full one was
+// given.
+func connectionUri(config *config.MONGO) string {
+	if config.URI != "" {
+		return config.URI
+	}
+	query := url.Values{}
+	connection := url.URL{
+		Scheme: config.Connection,
+		Host:   net.JoinHostPort(config.Host, config.Port),
+		Path:   "/" + config.Name,
+	}
+	if config.Connection == "mongodb+srv" {
+		connection.Host = config.Host
+	}
+	if config.User != "" {
+		connection.User = url.User(config.User)
+		if config.Password != "" {
+			connection.User = url.UserPassword(config.User, config.Password)
+		}
+		// The other mechanisms authenticate against $external.
+		if config.AuthSource != "" && (config.AuthMechanism == "" || strings.HasPrefix(config.AuthMechanism, "SCRAM-")) {
+			query.Set("authSource", config.AuthSource)
+		}
+	}
+	if config.AuthMechanism != "" {
+		query.Set("authMechanism", config.AuthMechanism)
+	}
+	if config.ReplicaSet != "" {
+		query.Set("replicaSet", config.ReplicaSet)
+	}
+	if config.TLS {
+		query.Set("tls", "true")
+		if config.TLSCAFile != "" {
+			query.Set("tlsCAFile", config.TLSCAFile)
+		}
+		if config.TLSCertificateKeyFile != "" {
+			query.Set("tlsCertificateKeyFile", config.TLSCertificateKeyFile)
+		}
+	}
+	connection.RawQuery = query.Encode()
+	return connection.String()
 }
@@ -3,7 +3,9 @@ package repositorymongo
 import (
 	"context"
 	dto "post-tech-challenge-10soat/internal/dto/client"
+	mongodb "post-tech-challenge-10soat/internal/external/mongo"
 	"post-tech-challenge-10soat/internal/external/mongo/model"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -16,19 +18,23 @@ type ClientMongoRepositoryImpl struct {
 
 func NewClientMongoRepositoryImpl(db *mongo.Database) ClientMongoRepositoryImpl {
 	return ClientMongoRepositoryImpl{
-		collection: db.Collection("client"),
+		collection: db.Collection(mongodb.ClientCollection),
 	}
 }
 
 func (repository ClientMongoRepositoryImpl) CreateClient(ctx context.Context, client dto.CreateClientDTO) (dto.ClientDTO, error) {
+	now := time.Now().UTC()
 	clientModel := model.ClientModel{
-		Cpf:   client.Cpf,
-		Name:  client.Name,
-		Email: client.Email,
+		Cpf:       client.Cpf,
+		Name:      client.Name,
+		Email:     client.Email,
+		Language:  client.Language,
+		CreatedAt: now,
+		UpdatedAt: now,
 	}
 	res, err := repository.collection.InsertOne(ctx, clientModel)
 	if err != nil {
-		return dto.ClientDTO{}, err
+		return dto.ClientDTO{}, mongodb.TranslateError(err)
 	}
 	clientModel.Id = res.InsertedID.(primitive.ObjectID).Hex()
 	return clientModel.ToDTO(), nil
@@ -38,7 +44,7 @@ func (repository ClientMongoRepositoryImpl) GetClientByCpf(ctx context.Context,
 	var clientModel model.ClientModel
 	err := repository.collection.FindOne(ctx, bson.M{"cpf": cpf}).Decode(&clientModel)
 	if err != nil {
-		return dto.ClientDTO{}, err
+		return dto.ClientDTO{}, mongodb.TranslateError(err)
 	}
 	return clientModel.ToDTO(), nil
 }
@@ -47,11 +53,37 @@ func (repository ClientMongoRepositoryImpl) GetClientById(ctx context.Context, i
 	var clientModel model.ClientModel
 	objectId, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
-		return dto.ClientDTO{}, err
+		return dto.ClientDTO{}, mongodb.TranslateError(err)
 	}
 	err = repository.collection.FindOne(ctx, bson.M{"_id": objectId}).Decode(&clientModel)
 	if err != nil {
-		return dto.ClientDTO{}, err
+		return dto.ClientDTO{}, mongodb.TranslateError(err)
 	}
 	return clientModel.ToDTO(), nil
 }
+
+// ListClientsByIds finds the clients with a single query. Ids that are not
+// object ids cannot match a client and are left out, like unknown ones.
+func (repository ClientMongoRepositoryImpl) ListClientsByIds(ctx context.Context, ids []string) ([]dto.ClientDTO, error) {
+	objectIds := make([]primitive.ObjectID, 0, len(ids))
+	for _, id := range ids {
+		objectId, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			continue
+		}
+		objectIds = append(objectIds, objectId)
+	}
+	cursor, err := repository.collection.Find(ctx, bson.M{"_id": bson.M{"$in": objectIds}})
+	if err != nil {
+		return nil, mongodb.TranslateError(err)
+	}
+	var clientModels []model.ClientModel
+	if err := cursor.All(ctx, &clientModels); err != nil {
+		return nil, mongodb.TranslateError(err)
+	}
+	clients := make([]dto.ClientDTO, 0, len(clientModels))
+	for _, clientModel := range clientModels {
+		clients = append(clients, clientModel.ToDTO())
+	}
+	return clients, nil
+}
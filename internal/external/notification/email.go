@@ -0,0 +1,75 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	entity "post-tech-challenge-10soat/internal/entities"
+	"strings"
+)
+
+type EmailNotifier struct {
+	addr     string
+	host     string
+	username string
+	password string
+	from     string
+}
+
+func NewEmailNotifier(host, port, username, password, from string) EmailNotifier {
+	if port == "" {
+		port = "25"
+	}
+	return EmailNotifier{
+		addr:     net.JoinHostPort(host, port),
+		host:     host,
+		username: username,
+		password: password,
+		from:     from,
+	}
+}
+
+func (n EmailNotifier) Channel() string {
+	return entity.NotificationChannelEmail
+}
+
+func (n EmailNotifier) Recipient(notification entity.Notification) string {
+	return notification.Client.Email
+}
+
+func (n EmailNotifier) Send(ctx context.Context, notification entity.Notification) error {
+	to := notification.Client.Email
+	if to == "" {
+		return fmt.Errorf("%w: client has no email", entity.ErrNotificationSkipped)
+	}
+	// Credentials are optional so a local SMTP sink without auth can be used.
+	var auth smtp.Auth
+	if n.username != "" {
+		auth = smtp.PlainAuth("", n.username, n.password, n.host)
+	}
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- smtp.SendMail(n.addr, auth, n.from, []string{to}, n.message(to, notification))
+	}()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case err := <-errCh:
+		return err
+	}
+}
+
+func (n EmailNotifier) message(to string, notification entity.Notification) []byte {
+	var msg strings.Builder
+	msg.WriteString("From: " + n.from + "\r\n")
+	msg.WriteString("To: " + to + "\r\n")
+	msg.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", notification.Subject) + "\r\n")
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n")
+	msg.WriteString("Content-Language: " + notification.Language + "\r\n")
+	msg.WriteString("\r\n")
+	msg.WriteString(notification.Body)
+	return []byte(msg.String())
+}
@@ -0,0 +1,32 @@
+package notification
+
+import (
+	"context"
+	"log/slog"
+	entity "post-tech-challenge-10soat/internal/entities"
+)
+
+type LogNotifier struct{}
+
+func NewLogNotifier() LogNotifier {
+	return LogNotifier{}
+}
+
+func (n LogNotifier) Channel() string {
+	return entity.NotificationChannelLog
+}
+
+func (n LogNotifier) Recipient(notification entity.Notification) string {
+	return notification.Client.Id
+}
+
+func (n LogNotifier) Send(ctx context.Context, notification entity.Notification) error {
+	slog.InfoContext(ctx, "Notification sent",
+		"event", notification.Event,
+		"order_id", notification.OrderId,
+		"client_id", notification.Client.Id,
+		"language", notification.Language,
+		"subject", notification.Subject,
+	)
+	return nil
+}
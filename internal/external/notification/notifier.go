@@ -0,0 +1,56 @@
+package notification
+
+import (
+	"fmt"
+	entity "post-tech-challenge-10soat/internal/entities"
+	"post-tech-challenge-10soat/internal/infrastructure/config"
+	interfaces "post-tech-challenge-10soat/internal/interfaces/gateways"
+	"strings"
+)
+
+// New builds the notifiers listed in NOTIFICATION_CHANNELS. The log channel is
+// used when no channel is configured so transitions are always traceable.
+func New(config *config.NOTIFICATION) ([]interfaces.Notifier, error) {
+	channels := strings.Split(config.Channels, ",")
+	if strings.TrimSpace(config.Channels) == "" {
+		channels = []string{entity.NotificationChannelLog}
+	}
+	var notifiers []interfaces.Notifier
+	for _, channel := range channels {
+		switch strings.TrimSpace(channel) {
+		case entity.NotificationChannelLog:
+			notifiers = append(notifiers, NewLogNotifier())
+		case entity.NotificationChannelEmail:
+			if config.SmtpHost == "" || config.SmtpFrom == "" {
+				return nil, fmt.Errorf("email channel requires NOTIFICATION_SMTP_HOST and NOTIFICATION_SMTP_FROM")
+			}
+			notifiers = append(notifiers, NewEmailNotifier(
+				config.SmtpHost,
+				config.SmtpPort,
+				config.SmtpUser,
+				config.SmtpPassword,
+				config.SmtpFrom,
+			))
+		case entity.NotificationChannelWebhook:
+			if config.WebhookUrl == "" {
+				return nil, fmt.Errorf("webhook channel requires NOTIFICATION_WEBHOOK_URL")
+			}
+			notifiers = append(notifiers, NewWebhookNotifier(config.WebhookUrl))
+		default:
+			return nil, fmt.Errorf("unknown notification channel '%s'", channel)
+		}
+	}
+	return notifiers, nil
+}
+
+// Find returns the notifier for the channel. Flows that depend on a channel,
+// like the identification code sent by email, fail instead of silently
+// falling back to the log.
+func Find(notifiers []interfaces.Notifier, channel string) (interfaces.Notifier, error) {
+	for _, notifier := range notifiers {
+		if notifier.Channel() == channel {
+			return notifier, nil
+		}
+	}
+	return nil, fmt.Errorf("notification channel '%s' is not configured in NOTIFICATION_CHANNELS", channel)
+}
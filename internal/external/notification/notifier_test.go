@@ -0,0 +1,35 @@
+package notification
+
+import (
+	entity "post-tech-challenge-10soat/internal/entities"
+	"post-tech-challenge-10soat/internal/infrastructure/config"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFind(t *testing.T) {
+	// Setup
+	notifiers, err := New(&config.NOTIFICATION{Channels: "log,email", SmtpHost: "127.0.0.1", SmtpPort: "1025", SmtpFrom: "pedidos@postech.local"})
+	assert.NoError(t, err)
+
+	// Execute
+	notifier, err := Find(notifiers, entity.NotificationChannelEmail)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, entity.NotificationChannelEmail, notifier.Channel())
+}
+
+func TestFind_NotConfigured(t *testing.T) {
+	// Setup
+	notifiers, err := New(&config.NOTIFICATION{Channels: "log"})
+	assert.NoError(t, err)
+
+	// Execute
+	notifier, err := Find(notifiers, entity.NotificationChannelEmail)
+
+	// Assert
+	assert.ErrorContains(t, err, "notification channel 'email' is not configured")
+	assert.Nil(t, notifier)
+}
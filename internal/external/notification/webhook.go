@@ -0,0 +1,76 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	entity "post-tech-challenge-10soat/internal/entities"
+	"post-tech-challenge-10soat/internal/infrastructure/logger"
+	"time"
+)
+
+type WebhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhookNotifier(url string) WebhookNotifier {
+	return WebhookNotifier{
+		url:    url,
+		client: &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+type webhookPayload struct {
+	Event       entity.NotificationEvent `json:"event"`
+	OrderId     string                   `json:"order_id"`
+	OrderNumber int                      `json:"order_number"`
+	ClientId    string                   `json:"client_id"`
+	Language    string                   `json:"language"`
+	Subject     string                   `json:"subject"`
+	Body        string                   `json:"body"`
+	OccurredAt  time.Time                `json:"occurred_at"`
+}
+
+func (n WebhookNotifier) Channel() string {
+	return entity.NotificationChannelWebhook
+}
+
+func (n WebhookNotifier) Recipient(notification entity.Notification) string {
+	return n.url
+}
+
+func (n WebhookNotifier) Send(ctx context.Context, notification entity.Notification) error {
+	payload, err := json.Marshal(webhookPayload{
+		Event:       notification.Event,
+		OrderId:     notification.OrderId,
+		OrderNumber: notification.OrderNumber,
+		ClientId:    notification.Client.Id,
+		Language:    notification.Language,
+		Subject:     notification.Subject,
+		Body:        notification.Body,
+		OccurredAt:  notification.OccurredAt,
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if requestId, ok := logger.RequestIdFromContext(ctx); ok {
+		req.Header.Set(logger.RequestIdHeader, requestId)
+	}
+	res, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %d", res.StatusCode)
+	}
+	return nil
+}
@@ -2,75 +2,211 @@ package postgres
 
 import (
 	"context"
-	"embed"
 	"fmt"
-	"log"
-	"os"
+	"log/slog"
+	"net"
+	"net/url"
+	entity "post-tech-challenge-10soat/internal/entities"
 	"post-tech-challenge-10soat/internal/infrastructure/config"
-
-	"github.com/golang-migrate/migrate/v4"
+	"post-tech-challenge-10soat/internal/utils"
+	"sync/atomic"
+	"time"
 
 	"github.com/Masterminds/squirrel"
-	_ "github.com/golang-migrate/migrate/v4/database/postgres"
-	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/exaring/otelpgx"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
-//go:embed migrations/*.sql
-var migrationsFS embed.FS
-
 type DB struct {
 	*pgxpool.Pool
 	QueryBuilder *squirrel.StatementBuilderType
 	url          string
+	replica      *pgxpool.Pool
+	// replicaUp is the outcome of the last ping of the replica, so reads
+	// go to the primary while it is away.
+	replicaUp    atomic.Bool
+	stopWatching context.CancelFunc
+	watching     chan struct{}
 }
 
+// New creates the pool and waits for the database to answer, retrying with
+// backoff as configured. Once up, the pool replaces broken connections on
+// its own, so a database restart only fails the queries running during it.
+// The replica, when configured, gets a pool of its own with the same
+// settings. It is optional like in the readiness probe: it is pinged once
+// instead of retried, and while it does not answer the reads go to the
+// primary.
 func New(ctx context.Context, config *config.DB) (*DB, error) {
-	url := fmt.Sprintf("%s://%s:%s@%s:%s/%s?sslmode=disable",
-		config.Connection,
-		config.User,
-		config.Password,
-		config.Host,
-		config.Port,
-		config.Name,
-	)
-	log.Printf("Connecting to database: %s", maskCredentials(url))
-	db, err := pgxpool.New(ctx, url)
+	url := connectionString(config)
+	db, err := newPool(ctx, config, url, "postgres")
 	if err != nil {
-		log.Fatalf("Error creating connection pool: %v", err)
 		return nil, err
 	}
-	err = db.Ping(ctx)
+	psql := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+	conn := &DB{
+		Pool:         db,
+		QueryBuilder: &psql,
+		url:          url,
+	}
+	if replicaUrl := replicaConnectionString(config); replicaUrl != "" {
+		replica, err := openPool(ctx, config, replicaUrl, "the postgres replica")
+		if err != nil {
+			db.Close()
+			return nil, err
+		}
+		conn.replica = replica
+		conn.checkReplica(ctx, config.ConnectTimeout)
+		conn.watchReplica(config.HealthCheckPeriod, config.ConnectTimeout)
+	}
+	return conn, nil
+}
+
+func newPool(ctx context.Context, config *config.DB, url string, name string) (*pgxpool.Pool, error) {
+	pool, err := openPool(ctx, config, url, name)
 	if err != nil {
-		log.Fatalf("Error pinging the database: %v", err)
 		return nil, err
 	}
-	psql := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
-	return &DB{
-		db,
-		&psql,
-		url,
-	}, nil
+	backoff := utils.Backoff{
+		Retries: config.ConnectRetries,
+		Initial: config.ConnectRetryBackoff,
+		Max:     config.ConnectRetryMaxBackoff,
+	}
+	if err := utils.Retry(ctx, backoff, "connecting to "+name, pool.Ping); err != nil {
+		pool.Close()
+		return nil, err
+	}
+	return pool, nil
 }
 
-func (db *DB) Migrate() error {
-	driver, err := iofs.New(migrationsFS, "migrations")
+// openPool creates the pool without waiting for a connection, which is
+// only made when a query needs one.
+func openPool(ctx context.Context, config *config.DB, url string, name string) (*pgxpool.Pool, error) {
+	slog.Info("Connecting to "+name, "url", utils.MaskCredentials(url))
+	poolConfig, err := pgxpool.ParseConfig(url)
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("invalid database connection string %s - %w", utils.MaskCredentials(url), err)
 	}
-
-	migrations, err := migrate.NewWithSourceInstance("iofs", driver, db.url)
+	poolConfig.ConnConfig.ConnectTimeout = config.ConnectTimeout
+	poolConfig.MaxConns = int32(config.MaxConns)
+	poolConfig.MinConns = int32(config.MinConns)
+	poolConfig.MaxConnLifetime = config.MaxConnLifetime
+	poolConfig.MaxConnIdleTime = config.MaxConnIdleTime
+	poolConfig.HealthCheckPeriod = config.HealthCheckPeriod
+	// Every query becomes a span under the one of the request running it.
+	poolConfig.ConnConfig.Tracer = otelpgx.NewTracer()
+	pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("failed to create the connection pool for %s - %w", name, err)
 	}
-	err = migrations.Up()
-	if err != nil && err != migrate.ErrNoChange {
-		return err
+	return pool, nil
+}
+
+// checkReplica pings the replica and records whether it answered, logging
+// when that changes.
+func (db *DB) checkReplica(ctx context.Context, timeout time.Duration) {
+	ctxPing, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	err := db.replica.Ping(ctxPing)
+	up := err == nil
+	if db.replicaUp.Swap(up) == up {
+		return
 	}
-	log.Println("Migrations applied successfully")
-	return nil
+	if up {
+		slog.InfoContext(ctx, "The postgres replica is up, reading from it")
+	} else {
+		slog.WarnContext(ctx, "The postgres replica is down, reading from the primary", "error", err)
+	}
+}
+
+// watchReplica pings the replica every period until Close.
+func (db *DB) watchReplica(period time.Duration, timeout time.Duration) {
+	ctx, cancel := context.WithCancel(context.Background())
+	db.stopWatching = cancel
+	db.watching = make(chan struct{})
+	go func() {
+		defer close(db.watching)
+		ticker := time.NewTicker(period)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				db.checkReplica(ctx, timeout)
+			}
+		}
+	}()
 }
 
-func maskCredentials(url string) string {
-	return os.ExpandEnv("${DB_CONNECTION}://****:****@${DB_HOST}:${DB_PORT}/${DB_NAME}?sslmode=disable")
+// Reader is the pool for lists and reports, which can stand a replica a
+// little behind the primary. It is the primary itself without a replica,
+// while the replica is down, or when the context asks to read its own
+// writes.
+func (db *DB) Reader(ctx context.Context) *pgxpool.Pool {
+	if db.replica == nil || !db.replicaUp.Load() || entity.ReadYourWritesFromContext(ctx) {
+		return db.Pool
+	}
+	return db.replica
+}
+
+// Replica is the pool of the replica, nil when there is none.
+func (db *DB) Replica() *pgxpool.Pool {
+	return db.replica
+}
+
+// Close stops watching the replica and closes the pools of the primary and
+// of the replica.
+func (db *DB) Close() {
+	if db.stopWatching != nil {
+		db.stopWatching()
+		<-db.watching
+	}
+	db.Pool.Close()
+	if db.replica != nil {
+		db.replica.Close()
+	}
+}
+
+// replicaConnectionString is the URL of the replica, with the settings of
+// the primary but the host and port, unless a full DSN was given. It is
+// empty without a replica.
+func replicaConnectionString(config *config.DB) string {
+	if config.ReplicaDSN != "" {
+		return config.ReplicaDSN
+	}
+	if config.ReplicaHost == "" {
+		return ""
+	}
+	replica := *config
+	replica.Host = config.ReplicaHost
+	if config.ReplicaPort != "" {
+		replica.Port = config.ReplicaPort
+	}
+	return connectionString(&replica)
+}
+
+// connectionString builds the URL from the settings, unless a full DSN was
+// given. It is a URL rather than keywords because golang-migrate only takes
+// URLs.
+func connectionString(config *config.DB) string {
+	if config.DSN != "" {
+		return config.DSN
+	}
+	query := url.Values{}
+	query.Set("sslmode", config.SslMode)
+	if config.SslRootCert != "" {
+		query.Set("sslrootcert", config.SslRootCert)
+	}
+	if config.SslCert != "" {
+		query.Set("sslcert", config.SslCert)
+		query.Set("sslkey", config.SslKey)
+	}
+	connection := url.URL{
+		Scheme:   config.Connection,
+		User:     url.UserPassword(config.User, config.Password),
+		Host:     net.JoinHostPort(config.Host, config.Port),
+		Path:     "/" + config.Name,
+		RawQuery: query.Encode(),
+	}
+	return connection.String()
 }
@@ -0,0 +1,36 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	entity "post-tech-challenge-10soat/internal/entities"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const (
+	uniqueViolationCode     = "23505"
+	foreignKeyViolationCode = "23503"
+)
+
+// TranslateError maps pgx driver errors into the domain errors declared in the
+// entity package so the upper layers never depend on the database driver.
+func TranslateError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, pgx.ErrNoRows) {
+		return entity.ErrDataNotFound
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case uniqueViolationCode:
+			return fmt.Errorf("%w: %s", entity.ErrConflictingData, pgErr.ConstraintName)
+		case foreignKeyViolationCode:
+			return fmt.Errorf("%w: %s", entity.ErrDataNotFound, pgErr.ConstraintName)
+		}
+	}
+	return err
+}
@@ -0,0 +1,181 @@
+package postgres
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"strings"
+
+	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+)
+
+//go:embed migrations/*.sql
+var migrationsFS embed.FS
+
+// migrationLockKey identifies the advisory lock held while migrating, so
+// replicas starting together, or an operator running the migrate command
+// during a deploy, apply migrations one at a time. golang-migrate has a lock
+// of its own, but it gives up after 15 seconds, failing the replicas that
+// wait for a long migration.
+const migrationLockKey int64 = 7_102_100_047
+
+// MigrationStatus is the version the database is at and the last one
+// embedded in this build.
+type MigrationStatus struct {
+	Version uint
+	Dirty   bool
+	Latest  uint
+}
+
+// Migrate applies every pending migration.
+func (db *DB) Migrate(ctx context.Context) error {
+	err := db.withMigrations(ctx, func(migrations *migrate.Migrate) error {
+		return migrations.Up()
+	})
+	if err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return err
+	}
+	slog.InfoContext(ctx, "Migrations applied successfully")
+	return nil
+}
+
+// MigrateDown reverts the last steps migrations.
+func (db *DB) MigrateDown(ctx context.Context, steps int) error {
+	if steps < 1 {
+		return fmt.Errorf("the number of migrations to revert must be at least 1")
+	}
+	return db.withMigrations(ctx, func(migrations *migrate.Migrate) error {
+		return migrations.Steps(-steps)
+	})
+}
+
+// ForceMigrationVersion records the version without running any migration
+// and clears the dirty flag, once a failed migration was fixed by hand. -1
+// records that no migration was applied.
+func (db *DB) ForceMigrationVersion(ctx context.Context, version int) error {
+	return db.withMigrations(ctx, func(migrations *migrate.Migrate) error {
+		return migrations.Force(version)
+	})
+}
+
+// MigrationStatus reads the version without taking the lock, so it can be
+// checked while a migration runs.
+func (db *DB) MigrationStatus() (MigrationStatus, error) {
+	latest, err := LatestMigrationVersion()
+	if err != nil {
+		return MigrationStatus{}, err
+	}
+	migrations, err := db.newMigrate()
+	if err != nil {
+		return MigrationStatus{}, err
+	}
+	defer migrations.Close()
+	version, dirty, err := migrations.Version()
+	if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
+		return MigrationStatus{}, err
+	}
+	return MigrationStatus{
+		Version: version,
+		Dirty:   dirty,
+		Latest:  latest,
+	}, nil
+}
+
+// withMigrations runs fn holding the migration lock, waiting for it as long
+// as the context allows.
+func (db *DB) withMigrations(ctx context.Context, fn func(migrations *migrate.Migrate) error) error {
+	conn, err := db.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire a connection for the migration lock - %w", err)
+	}
+	defer conn.Release()
+	var locked bool
+	if err := conn.QueryRow(ctx, "SELECT pg_try_advisory_lock($1)", migrationLockKey).Scan(&locked); err != nil {
+		return fmt.Errorf("failed to take the migration lock - %w", err)
+	}
+	if !locked {
+		slog.InfoContext(ctx, "Waiting for another process to finish migrating")
+		if _, err := conn.Exec(ctx, "SELECT pg_advisory_lock($1)", migrationLockKey); err != nil {
+			return fmt.Errorf("failed to take the migration lock - %w", err)
+		}
+	}
+	defer func() {
+		// The lock is released with the session anyway if this fails.
+		if _, err := conn.Exec(context.Background(), "SELECT pg_advisory_unlock($1)", migrationLockKey); err != nil {
+			slog.WarnContext(ctx, "Error releasing the migration lock", "error", err)
+		}
+	}()
+
+	migrations, err := db.newMigrate()
+	if err != nil {
+		return err
+	}
+	defer migrations.Close()
+	return fn(migrations)
+}
+
+func (db *DB) newMigrate() (*migrate.Migrate, error) {
+	driver, err := iofs.New(migrationsFS, "migrations")
+	if err != nil {
+		return nil, err
+	}
+	migrations, err := migrate.NewWithSourceInstance("iofs", driver, db.url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the database for migrations - %w", err)
+	}
+	migrations.Log = migrationLogger{}
+	return migrations, nil
+}
+
+// migrationLogger writes what golang-migrate reports, such as each applied
+// migration, to the default logger.
+type migrationLogger struct{}
+
+func (migrationLogger) Printf(format string, v ...any) {
+	slog.Info(strings.TrimSpace(fmt.Sprintf(format, v...)))
+}
+
+func (migrationLogger) Verbose() bool {
+	return false
+}
+
+// MigrationVersion returns the version recorded by golang-migrate and
+// whether a migration failed halfway through it.
+func (db *DB) MigrationVersion(ctx context.Context) (uint, bool, error) {
+	var version uint
+	var dirty bool
+	err := db.QueryRow(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&version, &dirty)
+	if err != nil {
+		return 0, false, TranslateError(err)
+	}
+	return version, dirty, nil
+}
+
+// LatestMigrationVersion returns the version of the last embedded migration,
+// the one a database running this build is expected to be at.
+func LatestMigrationVersion() (uint, error) {
+	driver, err := iofs.New(migrationsFS, "migrations")
+	if err != nil {
+		return 0, err
+	}
+	defer driver.Close()
+	version, err := driver.First()
+	if err != nil {
+		return 0, err
+	}
+	for {
+		next, err := driver.Next(version)
+		if errors.Is(err, fs.ErrNotExist) {
+			return version, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		version = next
+	}
+}
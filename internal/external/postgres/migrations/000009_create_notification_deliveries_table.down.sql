@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "notification_deliveries";
+
+DROP TYPE IF EXISTS "notification_deliveries_status_enum";
@@ -0,0 +1,20 @@
+CREATE TYPE "notification_deliveries_status_enum" AS ENUM ('sent', 'failed', 'skipped');
+
+CREATE TABLE IF NOT EXISTS "notification_deliveries" (
+	"id" uuid NOT NULL DEFAULT uuid_generate_v4(),
+	"order_id" uuid NOT NULL,
+	"client_id" varchar NULL,
+	"event" varchar NOT NULL,
+	"channel" varchar NOT NULL,
+	"recipient" varchar NULL,
+	"status" notification_deliveries_status_enum NOT NULL,
+	"error" varchar NULL,
+	"created_at" timestamp DEFAULT now() NOT NULL,
+	CONSTRAINT notification_deliveries_pk PRIMARY KEY (id)
+);
+
+ALTER TABLE "notification_deliveries"
+      ADD CONSTRAINT fk_notification_deliveries_order FOREIGN KEY (order_id)
+          REFERENCES "orders" (id) ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS idx_notification_deliveries_order ON "notification_deliveries" (order_id);
@@ -0,0 +1,9 @@
+UPDATE "orders" SET "client_id" = NULL
+    WHERE "client_id" !~ '^[0-9a-fA-F-]{36}$';
+
+ALTER TABLE "orders"
+    ALTER COLUMN "client_id" TYPE uuid USING "client_id"::uuid;
+
+ALTER TABLE "orders"
+      ADD CONSTRAINT fk_orders_client FOREIGN KEY (client_id)
+          REFERENCES "clients" (id);
@@ -0,0 +1,6 @@
+-- Clients are stored in MongoDB, so orders reference them by their ObjectID.
+ALTER TABLE "orders"
+    DROP CONSTRAINT IF EXISTS fk_orders_client;
+
+ALTER TABLE "orders"
+    ALTER COLUMN "client_id" TYPE varchar USING "client_id"::varchar;
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS "client_identifications" (
+	"id" uuid NOT NULL DEFAULT uuid_generate_v4(),
+	"client_id" varchar NOT NULL,
+	"code_hash" varchar NOT NULL,
+	"attempts" integer DEFAULT 0 NOT NULL,
+	"max_attempts" integer NOT NULL,
+	"expires_at" timestamp NOT NULL,
+	"verified_at" timestamp NULL,
+	"created_at" timestamp DEFAULT now() NOT NULL,
+	CONSTRAINT client_identifications_pk PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_client_identifications_client ON "client_identifications" (client_id);
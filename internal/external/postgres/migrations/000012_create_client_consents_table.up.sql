@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS "client_consents" (
+	"id" uuid NOT NULL DEFAULT uuid_generate_v4(),
+	"client_id" varchar NOT NULL,
+	"purpose" varchar NOT NULL,
+	"granted" boolean NOT NULL,
+	"source" varchar NOT NULL,
+	"policy_version" varchar NOT NULL,
+	"created_at" timestamp DEFAULT now() NOT NULL,
+	CONSTRAINT client_consents_pk PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_client_consents_client_purpose ON "client_consents" (client_id, purpose, created_at DESC);
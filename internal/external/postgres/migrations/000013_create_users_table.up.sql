@@ -0,0 +1,13 @@
+CREATE TYPE "users_role_enum" AS ENUM ('kiosk', 'kitchen', 'admin');
+
+CREATE TABLE IF NOT EXISTS "users" (
+	"id" uuid NOT NULL DEFAULT uuid_generate_v4(),
+	"username" varchar NOT NULL,
+	"password_hash" varchar NOT NULL,
+	"role" users_role_enum NOT NULL,
+	"active" boolean DEFAULT true NOT NULL,
+	"created_at" timestamp DEFAULT now() NOT NULL,
+	"updated_at" timestamp DEFAULT now() NOT NULL,
+	CONSTRAINT users_pk PRIMARY KEY (id),
+	CONSTRAINT users_username_unique UNIQUE (username)
+);
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS "api_keys" (
+	"id" uuid NOT NULL DEFAULT uuid_generate_v4(),
+	"name" varchar NOT NULL,
+	"prefix" varchar NOT NULL,
+	"key_hash" varchar NOT NULL,
+	"scopes" varchar[] NOT NULL,
+	"expires_at" timestamp NULL,
+	"last_used_at" timestamp NULL,
+	"revoked_at" timestamp NULL,
+	"created_at" timestamp DEFAULT now() NOT NULL,
+	CONSTRAINT api_keys_pk PRIMARY KEY (id),
+	CONSTRAINT api_keys_prefix_unique UNIQUE (prefix)
+);
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS "idempotency_keys" (
+	"id" uuid NOT NULL DEFAULT uuid_generate_v4(),
+	"principal" varchar NOT NULL,
+	"key" varchar NOT NULL,
+	"request_hash" varchar NOT NULL,
+	"status_code" integer NULL,
+	"response_body" bytea NULL,
+	"content_type" varchar NULL,
+	"created_at" timestamp DEFAULT now() NOT NULL,
+	"completed_at" timestamp NULL,
+	"expires_at" timestamp NOT NULL,
+	CONSTRAINT idempotency_keys_pk PRIMARY KEY (id),
+	CONSTRAINT idempotency_keys_principal_key_unique UNIQUE (principal, key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON "idempotency_keys" (expires_at);
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS "rate_limit_buckets" (
+	"key" varchar NOT NULL,
+	"tokens" double precision NOT NULL,
+	"updated_at" timestamp NOT NULL,
+	"full_at" timestamp NOT NULL,
+	CONSTRAINT rate_limit_buckets_pk PRIMARY KEY (key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_rate_limit_buckets_full_at ON "rate_limit_buckets" (full_at);
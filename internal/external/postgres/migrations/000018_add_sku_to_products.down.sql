@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS categories_name_idx;
+
+ALTER TABLE "products" DROP CONSTRAINT IF EXISTS products_sku_unique;
+
+ALTER TABLE "products" DROP COLUMN IF EXISTS "sku";
@@ -0,0 +1,11 @@
+ALTER TABLE "products" ADD COLUMN IF NOT EXISTS "sku" varchar NULL;
+
+-- Existing products use their id as SKU, as do the ones created through the
+-- API without one.
+UPDATE "products" SET "sku" = "id"::text WHERE "sku" IS NULL;
+
+ALTER TABLE "products" ALTER COLUMN "sku" SET NOT NULL;
+
+ALTER TABLE "products" ADD CONSTRAINT products_sku_unique UNIQUE ("sku");
+
+CREATE INDEX IF NOT EXISTS categories_name_idx ON "categories" ("name");
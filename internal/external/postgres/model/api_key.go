@@ -0,0 +1,33 @@
+package model
+
+import (
+	"database/sql"
+	dto "post-tech-challenge-10soat/internal/dto/apikey"
+	"time"
+)
+
+type ApiKeyModel struct {
+	Id         string       `db:"id"`
+	Name       string       `db:"name"`
+	Prefix     string       `db:"prefix"`
+	KeyHash    string       `db:"keyHash"`
+	Scopes     []string     `db:"scopes"`
+	ExpiresAt  sql.NullTime `db:"expiresAt"`
+	LastUsedAt sql.NullTime `db:"lastUsedAt"`
+	RevokedAt  sql.NullTime `db:"revokedAt"`
+	CreatedAt  time.Time    `db:"createdAt"`
+}
+
+func (m ApiKeyModel) ToDTO() dto.ApiKeyDTO {
+	return dto.ApiKeyDTO{
+		Id:         m.Id,
+		Name:       m.Name,
+		Prefix:     m.Prefix,
+		KeyHash:    m.KeyHash,
+		Scopes:     m.Scopes,
+		ExpiresAt:  m.ExpiresAt.Time,
+		LastUsedAt: m.LastUsedAt.Time,
+		RevokedAt:  m.RevokedAt.Time,
+		CreatedAt:  m.CreatedAt,
+	}
+}
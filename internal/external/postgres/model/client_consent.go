@@ -0,0 +1,28 @@
+package model
+
+import (
+	dto "post-tech-challenge-10soat/internal/dto/consent"
+	"time"
+)
+
+type ClientConsentModel struct {
+	Id            string    `db:"id"`
+	ClientId      string    `db:"clientId"`
+	Purpose       string    `db:"purpose"`
+	Granted       bool      `db:"granted"`
+	Source        string    `db:"source"`
+	PolicyVersion string    `db:"policyVersion"`
+	CreatedAt     time.Time `db:"createdAt"`
+}
+
+func (m ClientConsentModel) ToDTO() dto.ClientConsentDTO {
+	return dto.ClientConsentDTO{
+		Id:            m.Id,
+		ClientId:      m.ClientId,
+		Purpose:       m.Purpose,
+		Granted:       m.Granted,
+		Source:        m.Source,
+		PolicyVersion: m.PolicyVersion,
+		CreatedAt:     m.CreatedAt,
+	}
+}
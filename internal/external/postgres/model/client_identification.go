@@ -0,0 +1,31 @@
+package model
+
+import (
+	"database/sql"
+	dto "post-tech-challenge-10soat/internal/dto/client"
+	"time"
+)
+
+type ClientIdentificationModel struct {
+	Id          string       `db:"id"`
+	ClientId    string       `db:"clientId"`
+	CodeHash    string       `db:"codeHash"`
+	Attempts    int          `db:"attempts"`
+	MaxAttempts int          `db:"maxAttempts"`
+	ExpiresAt   time.Time    `db:"expiresAt"`
+	VerifiedAt  sql.NullTime `db:"verifiedAt"`
+	CreatedAt   time.Time    `db:"createdAt"`
+}
+
+func (m ClientIdentificationModel) ToDTO() dto.ClientIdentificationDTO {
+	return dto.ClientIdentificationDTO{
+		Id:          m.Id,
+		ClientId:    m.ClientId,
+		CodeHash:    m.CodeHash,
+		Attempts:    m.Attempts,
+		MaxAttempts: m.MaxAttempts,
+		ExpiresAt:   m.ExpiresAt,
+		VerifiedAt:  m.VerifiedAt.Time,
+		CreatedAt:   m.CreatedAt,
+	}
+}
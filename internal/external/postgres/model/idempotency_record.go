@@ -0,0 +1,35 @@
+package model
+
+import (
+	"database/sql"
+	dto "post-tech-challenge-10soat/internal/dto/idempotency"
+	"time"
+)
+
+type IdempotencyRecordModel struct {
+	Id           string         `db:"id"`
+	Principal    string         `db:"principal"`
+	Key          string         `db:"key"`
+	RequestHash  string         `db:"requestHash"`
+	StatusCode   sql.NullInt32  `db:"statusCode"`
+	ResponseBody []byte         `db:"responseBody"`
+	ContentType  sql.NullString `db:"contentType"`
+	CreatedAt    time.Time      `db:"createdAt"`
+	CompletedAt  sql.NullTime   `db:"completedAt"`
+	ExpiresAt    time.Time      `db:"expiresAt"`
+}
+
+func (m IdempotencyRecordModel) ToDTO() dto.IdempotencyRecordDTO {
+	return dto.IdempotencyRecordDTO{
+		Id:           m.Id,
+		Principal:    m.Principal,
+		Key:          m.Key,
+		RequestHash:  m.RequestHash,
+		StatusCode:   int(m.StatusCode.Int32),
+		ResponseBody: m.ResponseBody,
+		ContentType:  m.ContentType.String,
+		CreatedAt:    m.CreatedAt,
+		CompletedAt:  m.CompletedAt.Time,
+		ExpiresAt:    m.ExpiresAt,
+	}
+}
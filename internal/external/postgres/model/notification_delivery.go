@@ -0,0 +1,33 @@
+package model
+
+import (
+	"database/sql"
+	dto "post-tech-challenge-10soat/internal/dto/notification"
+	"time"
+)
+
+type NotificationDeliveryModel struct {
+	Id        string         `db:"id"`
+	OrderId   string         `db:"orderId"`
+	ClientId  sql.NullString `db:"clientId"`
+	Event     string         `db:"event"`
+	Channel   string         `db:"channel"`
+	Recipient sql.NullString `db:"recipient"`
+	Status    string         `db:"status"`
+	Error     sql.NullString `db:"error"`
+	CreatedAt time.Time      `db:"createdAt"`
+}
+
+func (m NotificationDeliveryModel) ToDTO() dto.NotificationDeliveryDTO {
+	return dto.NotificationDeliveryDTO{
+		Id:        m.Id,
+		OrderId:   m.OrderId,
+		ClientId:  m.ClientId.String,
+		Event:     m.Event,
+		Channel:   m.Channel,
+		Recipient: m.Recipient.String,
+		Status:    m.Status,
+		Error:     m.Error.String,
+		CreatedAt: m.CreatedAt,
+	}
+}
@@ -10,11 +10,12 @@ type OrderModel struct {
 	Id        string         `db:"id"`
 	Number    int            `db:"number"`
 	Status    string         `db:"status"`
-	ClientId  string         `db:"clientId"`
+	ClientId  sql.NullString `db:"clientId"`
 	PaymentId sql.NullString `db:"paymentId"`
 	Total     float64        `db:"total"`
 	CreatedAt time.Time      `db:"createdAt"`
 	UpdatedAt time.Time      `db:"updatedAt"`
+	Version   int            `db:"version"`
 }
 
 func (m OrderModel) ToDTO() dto.OrderDTO {
@@ -22,9 +23,10 @@ func (m OrderModel) ToDTO() dto.OrderDTO {
 		Id:        m.Id,
 		Number:    m.Number,
 		Status:    m.Status,
-		ClientId:  m.ClientId,
+		ClientId:  m.ClientId.String,
 		PaymentId: m.PaymentId.String,
 		Total:     m.Total,
+		Version:   m.Version,
 		CreatedAt: m.CreatedAt,
 		UpdatedAt: m.UpdatedAt,
 	}
@@ -15,17 +15,21 @@ type ProductModel struct {
 	CategoryModel CategoryModel `db:"categoryModel"`
 	CreatedAt     time.Time     `db:"createdAt"`
 	UpdatedAt     time.Time     `db:"updatedAt"`
+	Version       int           `db:"version"`
+	Sku           string        `db:"sku"`
 }
 
 func (m ProductModel) ToDTO() dto.ProductDTO {
 	return dto.ProductDTO{
 		Id:          m.Id,
+		Sku:         m.Sku,
 		Name:        m.Name,
 		Description: m.Description,
 		Image:       m.Image,
 		Value:       m.Value,
 		CategoryId:  m.CategoryId,
 		CategoryDTO: m.CategoryModel.ToDTO(),
+		Version:     m.Version,
 		CreatedAt:   m.CreatedAt,
 		UpdatedAt:   m.UpdatedAt,
 	}
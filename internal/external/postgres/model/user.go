@@ -0,0 +1,28 @@
+package model
+
+import (
+	dto "post-tech-challenge-10soat/internal/dto/user"
+	"time"
+)
+
+type UserModel struct {
+	Id           string    `db:"id"`
+	Username     string    `db:"username"`
+	PasswordHash string    `db:"passwordHash"`
+	Role         string    `db:"role"`
+	Active       bool      `db:"active"`
+	CreatedAt    time.Time `db:"createdAt"`
+	UpdatedAt    time.Time `db:"updatedAt"`
+}
+
+func (m UserModel) ToDTO() dto.UserDTO {
+	return dto.UserDTO{
+		Id:           m.Id,
+		Username:     m.Username,
+		PasswordHash: m.PasswordHash,
+		Role:         m.Role,
+		Active:       m.Active,
+		CreatedAt:    m.CreatedAt,
+		UpdatedAt:    m.UpdatedAt,
+	}
+}
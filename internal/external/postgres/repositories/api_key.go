@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	dto "post-tech-challenge-10soat/internal/dto/apikey"
+	"post-tech-challenge-10soat/internal/external/postgres"
+	"post-tech-challenge-10soat/internal/external/postgres/model"
+	"post-tech-challenge-10soat/internal/utils"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+type ApiKeyRepositoryImpl struct {
+	db *postgres.DB
+}
+
+func NewApiKeyRepositoryImpl(db *postgres.DB) ApiKeyRepositoryImpl {
+	return ApiKeyRepositoryImpl{
+		db,
+	}
+}
+
+func (repository ApiKeyRepositoryImpl) CreateApiKey(ctx context.Context, apiKey dto.StoreApiKeyDTO) (dto.ApiKeyDTO, error) {
+	query := repository.db.QueryBuilder.Insert("api_keys").
+		Columns("name", "prefix", "key_hash", "scopes", "expires_at").
+		Values(apiKey.Name, apiKey.Prefix, apiKey.KeyHash, apiKey.Scopes, utils.NullTime(apiKey.ExpiresAt)).
+		Suffix("RETURNING *")
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return dto.ApiKeyDTO{}, postgres.TranslateError(err)
+	}
+	return repository.scan(repository.db.QueryRow(ctx, sql, args...))
+}
+
+func (repository ApiKeyRepositoryImpl) GetApiKeyById(ctx context.Context, id string) (dto.ApiKeyDTO, error) {
+	return repository.getBy(ctx, sq.Eq{"id": id})
+}
+
+func (repository ApiKeyRepositoryImpl) GetApiKeyByPrefix(ctx context.Context, prefix string) (dto.ApiKeyDTO, error) {
+	return repository.getBy(ctx, sq.Eq{"prefix": prefix})
+}
+
+func (repository ApiKeyRepositoryImpl) ListApiKeys(ctx context.Context) ([]dto.ApiKeyDTO, error) {
+	query := repository.db.QueryBuilder.Select("*").
+		From("api_keys").
+		OrderBy("created_at DESC")
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, postgres.TranslateError(err)
+	}
+	rows, err := repository.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, postgres.TranslateError(err)
+	}
+	defer rows.Close()
+	var apiKeys []dto.ApiKeyDTO
+	for rows.Next() {
+		apiKey, err := repository.scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		apiKeys = append(apiKeys, apiKey)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, postgres.TranslateError(err)
+	}
+	return apiKeys, nil
+}
+
+// RevokeApiKey only revokes keys that are still active, so revoking twice
+// reports the key as not found.
+func (repository ApiKeyRepositoryImpl) RevokeApiKey(ctx context.Context, id string) (dto.ApiKeyDTO, error) {
+	query := repository.db.QueryBuilder.Update("api_keys").
+		Set("revoked_at", time.Now().UTC()).
+		Where(sq.Eq{"id": id, "revoked_at": nil}).
+		Suffix("RETURNING *")
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return dto.ApiKeyDTO{}, postgres.TranslateError(err)
+	}
+	return repository.scan(repository.db.QueryRow(ctx, sql, args...))
+}
+
+func (repository ApiKeyRepositoryImpl) TouchApiKey(ctx context.Context, id string, usedAt time.Time) error {
+	query := repository.db.QueryBuilder.Update("api_keys").
+		Set("last_used_at", usedAt).
+		Where(sq.Eq{"id": id})
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return postgres.TranslateError(err)
+	}
+	_, err = repository.db.Exec(ctx, sql, args...)
+	if err != nil {
+		return postgres.TranslateError(err)
+	}
+	return nil
+}
+
+func (repository ApiKeyRepositoryImpl) getBy(ctx context.Context, where sq.Eq) (dto.ApiKeyDTO, error) {
+	query := repository.db.QueryBuilder.Select("*").
+		From("api_keys").
+		Where(where).
+		Limit(1)
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return dto.ApiKeyDTO{}, postgres.TranslateError(err)
+	}
+	return repository.scan(repository.db.QueryRow(ctx, sql, args...))
+}
+
+func (repository ApiKeyRepositoryImpl) scan(row pgx.Row) (dto.ApiKeyDTO, error) {
+	var apiKeyModel model.ApiKeyModel
+	err := row.Scan(
+		&apiKeyModel.Id,
+		&apiKeyModel.Name,
+		&apiKeyModel.Prefix,
+		&apiKeyModel.KeyHash,
+		&apiKeyModel.Scopes,
+		&apiKeyModel.ExpiresAt,
+		&apiKeyModel.LastUsedAt,
+		&apiKeyModel.RevokedAt,
+		&apiKeyModel.CreatedAt,
+	)
+	if err != nil {
+		return dto.ApiKeyDTO{}, postgres.TranslateError(err)
+	}
+	return apiKeyModel.ToDTO(), nil
+}
@@ -2,13 +2,11 @@ package repository
 
 import (
 	"context"
-	"errors"
 	dto "post-tech-challenge-10soat/internal/dto/category"
 	"post-tech-challenge-10soat/internal/external/postgres"
 	"post-tech-challenge-10soat/internal/external/postgres/model"
 
 	sq "github.com/Masterminds/squirrel"
-	"github.com/jackc/pgx"
 )
 
 type CategoryRepositoryImpl struct {
@@ -30,7 +28,7 @@ func (cr CategoryRepositoryImpl) GetCategoryById(ctx context.Context, id string)
 	sql, args, err := query.ToSql()
 
 	if err != nil {
-		return dto.CategoryDTO{}, err
+		return dto.CategoryDTO{}, postgres.TranslateError(err)
 	}
 	err = cr.db.QueryRow(ctx, sql, args...).Scan(
 		&categoryModel.Id,
@@ -39,10 +37,79 @@ func (cr CategoryRepositoryImpl) GetCategoryById(ctx context.Context, id string)
 		&categoryModel.UpdatedAt,
 	)
 	if err != nil {
-		if err == pgx.ErrNoRows {
-			return dto.CategoryDTO{}, errors.New("No data found")
-		}
-		return dto.CategoryDTO{}, err
+		return dto.CategoryDTO{}, postgres.TranslateError(err)
 	}
 	return categoryModel.ToDTO(), nil
 }
+
+func (cr CategoryRepositoryImpl) ListCategoriesByIds(ctx context.Context, ids []string) ([]dto.CategoryDTO, error) {
+	var categoryModel model.CategoryModel
+	var categories []dto.CategoryDTO
+	query := cr.db.QueryBuilder.Select("*").
+		From("categories").
+		Where(sq.Eq{"id": ids})
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return []dto.CategoryDTO{}, postgres.TranslateError(err)
+	}
+	rows, err := cr.db.Query(ctx, sql, args...)
+	if err != nil {
+		return []dto.CategoryDTO{}, postgres.TranslateError(err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		err := rows.Scan(
+			&categoryModel.Id,
+			&categoryModel.Name,
+			&categoryModel.CreatedAt,
+			&categoryModel.UpdatedAt,
+		)
+		if err != nil {
+			return []dto.CategoryDTO{}, postgres.TranslateError(err)
+		}
+		categories = append(categories, categoryModel.ToDTO())
+	}
+	return categories, nil
+}
+
+func (cr CategoryRepositoryImpl) ListCategories(ctx context.Context) ([]dto.CategoryDTO, error) {
+	query := cr.db.QueryBuilder.Select("*").
+		From("categories").
+		OrderBy("name")
+	return cr.listCategories(ctx, query)
+}
+
+func (cr CategoryRepositoryImpl) ListCategoriesByNames(ctx context.Context, names []string) ([]dto.CategoryDTO, error) {
+	query := cr.db.QueryBuilder.Select("*").
+		From("categories").
+		Where(sq.Eq{"name": names}).
+		OrderBy("created_at")
+	return cr.listCategories(ctx, query)
+}
+
+func (cr CategoryRepositoryImpl) listCategories(ctx context.Context, query sq.SelectBuilder) ([]dto.CategoryDTO, error) {
+	var categoryModel model.CategoryModel
+	var categories []dto.CategoryDTO
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return []dto.CategoryDTO{}, postgres.TranslateError(err)
+	}
+	rows, err := cr.db.Query(ctx, sql, args...)
+	if err != nil {
+		return []dto.CategoryDTO{}, postgres.TranslateError(err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		err := rows.Scan(
+			&categoryModel.Id,
+			&categoryModel.Name,
+			&categoryModel.CreatedAt,
+			&categoryModel.UpdatedAt,
+		)
+		if err != nil {
+			return []dto.CategoryDTO{}, postgres.TranslateError(err)
+		}
+		categories = append(categories, categoryModel.ToDTO())
+	}
+	return categories, nil
+}
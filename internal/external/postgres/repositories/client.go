@@ -27,7 +27,7 @@ func (repository ClientRepositoryImpl) CreateClient(ctx context.Context, client
 		Suffix("RETURNING id, name, email, created_at, updated_at")
 	sql, args, err := query.ToSql()
 	if err != nil {
-		return dto.ClientDTO{}, err
+		return dto.ClientDTO{}, postgres.TranslateError(err)
 	}
 	err = repository.db.QueryRow(ctx, sql, args...).Scan(
 		&clientModel.Id,
@@ -37,7 +37,7 @@ func (repository ClientRepositoryImpl) CreateClient(ctx context.Context, client
 		&clientModel.UpdatedAt,
 	)
 	if err != nil {
-		return dto.ClientDTO{}, err
+		return dto.ClientDTO{}, postgres.TranslateError(err)
 	}
 	return clientModel.ToDTO(), nil
 }
@@ -50,7 +50,7 @@ func (repository ClientRepositoryImpl) GetClientByCpf(ctx context.Context, cpf s
 		Limit(1)
 	sql, args, err := query.ToSql()
 	if err != nil {
-		return dto.ClientDTO{}, err
+		return dto.ClientDTO{}, postgres.TranslateError(err)
 	}
 	err = repository.db.QueryRow(ctx, sql, args...).Scan(
 		&clientModel.Id,
@@ -61,7 +61,7 @@ func (repository ClientRepositoryImpl) GetClientByCpf(ctx context.Context, cpf s
 		&clientModel.UpdatedAt,
 	)
 	if err != nil {
-		return dto.ClientDTO{}, err
+		return dto.ClientDTO{}, postgres.TranslateError(err)
 	}
 	return clientModel.ToDTO(), nil
 }
@@ -74,7 +74,7 @@ func (repository ClientRepositoryImpl) GetClientById(ctx context.Context, id str
 		Limit(1)
 	sql, args, err := query.ToSql()
 	if err != nil {
-		return dto.ClientDTO{}, err
+		return dto.ClientDTO{}, postgres.TranslateError(err)
 	}
 	err = repository.db.QueryRow(ctx, sql, args...).Scan(
 		&clientModel.Id,
@@ -85,7 +85,39 @@ func (repository ClientRepositoryImpl) GetClientById(ctx context.Context, id str
 		&clientModel.UpdatedAt,
 	)
 	if err != nil {
-		return dto.ClientDTO{}, err
+		return dto.ClientDTO{}, postgres.TranslateError(err)
 	}
 	return clientModel.ToDTO(), nil
 }
+
+func (repository ClientRepositoryImpl) ListClientsByIds(ctx context.Context, ids []string) ([]dto.ClientDTO, error) {
+	var clientModel model.ClientModel
+	var clients []dto.ClientDTO
+	query := repository.db.QueryBuilder.Select("*").
+		From("clients").
+		Where(sq.Eq{"id": ids})
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return []dto.ClientDTO{}, postgres.TranslateError(err)
+	}
+	rows, err := repository.db.Reader(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return []dto.ClientDTO{}, postgres.TranslateError(err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		err := rows.Scan(
+			&clientModel.Id,
+			&clientModel.Cpf,
+			&clientModel.Name,
+			&clientModel.Email,
+			&clientModel.CreatedAt,
+			&clientModel.UpdatedAt,
+		)
+		if err != nil {
+			return []dto.ClientDTO{}, postgres.TranslateError(err)
+		}
+		clients = append(clients, clientModel.ToDTO())
+	}
+	return clients, nil
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	dto "post-tech-challenge-10soat/internal/dto/consent"
+	"post-tech-challenge-10soat/internal/external/postgres"
+	"post-tech-challenge-10soat/internal/external/postgres/model"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+type ClientConsentRepositoryImpl struct {
+	db *postgres.DB
+}
+
+func NewClientConsentRepositoryImpl(db *postgres.DB) ClientConsentRepositoryImpl {
+	return ClientConsentRepositoryImpl{
+		db,
+	}
+}
+
+func (repository ClientConsentRepositoryImpl) CreateClientConsent(ctx context.Context, consent dto.CreateClientConsentDTO) (dto.ClientConsentDTO, error) {
+	query := repository.db.QueryBuilder.Insert("client_consents").
+		Columns("client_id", "purpose", "granted", "source", "policy_version").
+		Values(consent.ClientId, consent.Purpose, consent.Granted, consent.Source, consent.PolicyVersion).
+		Suffix("RETURNING *")
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return dto.ClientConsentDTO{}, postgres.TranslateError(err)
+	}
+	return repository.scan(repository.db.QueryRow(ctx, sql, args...))
+}
+
+func (repository ClientConsentRepositoryImpl) GetClientConsent(ctx context.Context, clientId string, purpose string) (dto.ClientConsentDTO, error) {
+	query := repository.db.QueryBuilder.Select("*").
+		From("client_consents").
+		Where(sq.Eq{"client_id": clientId, "purpose": purpose}).
+		OrderBy("created_at DESC").
+		Limit(1)
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return dto.ClientConsentDTO{}, postgres.TranslateError(err)
+	}
+	return repository.scan(repository.db.QueryRow(ctx, sql, args...))
+}
+
+// ListClientConsents returns the most recent record of each purpose.
+func (repository ClientConsentRepositoryImpl) ListClientConsents(ctx context.Context, clientId string) ([]dto.ClientConsentDTO, error) {
+	query := repository.db.QueryBuilder.Select("DISTINCT ON (purpose) *").
+		From("client_consents").
+		Where(sq.Eq{"client_id": clientId}).
+		OrderBy("purpose", "created_at DESC")
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, postgres.TranslateError(err)
+	}
+	rows, err := repository.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, postgres.TranslateError(err)
+	}
+	defer rows.Close()
+	var consents []dto.ClientConsentDTO
+	for rows.Next() {
+		consent, err := repository.scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		consents = append(consents, consent)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, postgres.TranslateError(err)
+	}
+	return consents, nil
+}
+
+func (repository ClientConsentRepositoryImpl) scan(row pgx.Row) (dto.ClientConsentDTO, error) {
+	var consentModel model.ClientConsentModel
+	err := row.Scan(
+		&consentModel.Id,
+		&consentModel.ClientId,
+		&consentModel.Purpose,
+		&consentModel.Granted,
+		&consentModel.Source,
+		&consentModel.PolicyVersion,
+		&consentModel.CreatedAt,
+	)
+	if err != nil {
+		return dto.ClientConsentDTO{}, postgres.TranslateError(err)
+	}
+	return consentModel.ToDTO(), nil
+}
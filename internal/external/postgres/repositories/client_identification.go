@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	dto "post-tech-challenge-10soat/internal/dto/client"
+	"post-tech-challenge-10soat/internal/external/postgres"
+	"post-tech-challenge-10soat/internal/external/postgres/model"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+type ClientIdentificationRepositoryImpl struct {
+	db *postgres.DB
+}
+
+func NewClientIdentificationRepositoryImpl(db *postgres.DB) ClientIdentificationRepositoryImpl {
+	return ClientIdentificationRepositoryImpl{
+		db,
+	}
+}
+
+func (repository ClientIdentificationRepositoryImpl) CreateClientIdentification(ctx context.Context, identification dto.CreateClientIdentificationDTO) (dto.ClientIdentificationDTO, error) {
+	query := repository.db.QueryBuilder.Insert("client_identifications").
+		Columns("client_id", "code_hash", "max_attempts", "expires_at").
+		Values(identification.ClientId, identification.CodeHash, identification.MaxAttempts, identification.ExpiresAt).
+		Suffix("RETURNING *")
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return dto.ClientIdentificationDTO{}, postgres.TranslateError(err)
+	}
+	return repository.scan(repository.db.QueryRow(ctx, sql, args...))
+}
+
+func (repository ClientIdentificationRepositoryImpl) GetClientIdentificationById(ctx context.Context, id string) (dto.ClientIdentificationDTO, error) {
+	query := repository.db.QueryBuilder.Select("*").
+		From("client_identifications").
+		Where(sq.Eq{"id": id}).
+		Limit(1)
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return dto.ClientIdentificationDTO{}, postgres.TranslateError(err)
+	}
+	return repository.scan(repository.db.QueryRow(ctx, sql, args...))
+}
+
+// IncrementClientIdentificationAttempts spends one attempt of a pending,
+// unexpired identification in a single statement, so concurrent guesses can
+// never go past max_attempts. Nothing is spent, and ErrDataNotFound is
+// returned, once the identification can no longer be verified.
+func (repository ClientIdentificationRepositoryImpl) IncrementClientIdentificationAttempts(ctx context.Context, id string) (dto.ClientIdentificationDTO, error) {
+	query := repository.db.QueryBuilder.Update("client_identifications").
+		Set("attempts", sq.Expr("attempts + 1")).
+		Where(sq.Eq{"id": id, "verified_at": nil}).
+		Where(sq.Gt{"expires_at": time.Now().UTC()}).
+		Where("attempts < max_attempts").
+		Suffix("RETURNING *")
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return dto.ClientIdentificationDTO{}, postgres.TranslateError(err)
+	}
+	return repository.scan(repository.db.QueryRow(ctx, sql, args...))
+}
+
+// VerifyClientIdentification marks the identification as verified only while it
+// is still pending and unexpired, so a code is used once. The attempt that
+// matched was already spent by IncrementClientIdentificationAttempts, hence
+// attempts may equal max_attempts here.
+func (repository ClientIdentificationRepositoryImpl) VerifyClientIdentification(ctx context.Context, id string) (dto.ClientIdentificationDTO, error) {
+	now := time.Now().UTC()
+	query := repository.db.QueryBuilder.Update("client_identifications").
+		Set("verified_at", now).
+		Where(sq.Eq{"id": id, "verified_at": nil}).
+		Where(sq.Gt{"expires_at": now}).
+		Where("attempts <= max_attempts").
+		Suffix("RETURNING *")
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return dto.ClientIdentificationDTO{}, postgres.TranslateError(err)
+	}
+	return repository.scan(repository.db.QueryRow(ctx, sql, args...))
+}
+
+func (repository ClientIdentificationRepositoryImpl) ExpirePendingClientIdentifications(ctx context.Context, clientId string) error {
+	query := repository.db.QueryBuilder.Update("client_identifications").
+		Set("expires_at", time.Now().UTC()).
+		Where(sq.Eq{"client_id": clientId, "verified_at": nil}).
+		Where(sq.Gt{"expires_at": time.Now().UTC()})
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return postgres.TranslateError(err)
+	}
+	_, err = repository.db.Exec(ctx, sql, args...)
+	if err != nil {
+		return postgres.TranslateError(err)
+	}
+	return nil
+}
+
+func (repository ClientIdentificationRepositoryImpl) scan(row pgx.Row) (dto.ClientIdentificationDTO, error) {
+	var identificationModel model.ClientIdentificationModel
+	err := row.Scan(
+		&identificationModel.Id,
+		&identificationModel.ClientId,
+		&identificationModel.CodeHash,
+		&identificationModel.Attempts,
+		&identificationModel.MaxAttempts,
+		&identificationModel.ExpiresAt,
+		&identificationModel.VerifiedAt,
+		&identificationModel.CreatedAt,
+	)
+	if err != nil {
+		return dto.ClientIdentificationDTO{}, postgres.TranslateError(err)
+	}
+	return identificationModel.ToDTO(), nil
+}
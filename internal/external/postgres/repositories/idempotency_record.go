@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	dto "post-tech-challenge-10soat/internal/dto/idempotency"
+	entity "post-tech-challenge-10soat/internal/entities"
+	"post-tech-challenge-10soat/internal/external/postgres"
+	"post-tech-challenge-10soat/internal/external/postgres/model"
+	"post-tech-challenge-10soat/internal/utils"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+type IdempotencyRecordRepositoryImpl struct {
+	db *postgres.DB
+}
+
+func NewIdempotencyRecordRepositoryImpl(db *postgres.DB) IdempotencyRecordRepositoryImpl {
+	return IdempotencyRecordRepositoryImpl{
+		db,
+	}
+}
+
+// CreateIdempotencyRecord reserves the key for the principal. An expired record
+// with the same key is replaced, a live one makes the reservation fail with
+// entity.ErrConflictingData.
+func (repository IdempotencyRecordRepositoryImpl) CreateIdempotencyRecord(ctx context.Context, record dto.CreateIdempotencyRecordDTO) (dto.IdempotencyRecordDTO, error) {
+	query := repository.db.QueryBuilder.Insert("idempotency_keys").
+		Columns("principal", "key", "request_hash", "expires_at").
+		Values(record.Principal, record.Key, record.RequestHash, record.ExpiresAt).
+		Suffix(`ON CONFLICT (principal, key) DO UPDATE SET
+			request_hash = EXCLUDED.request_hash,
+			status_code = NULL,
+			response_body = NULL,
+			content_type = NULL,
+			created_at = now(),
+			completed_at = NULL,
+			expires_at = EXCLUDED.expires_at
+			WHERE idempotency_keys.expires_at <= now()
+			RETURNING *`)
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return dto.IdempotencyRecordDTO{}, postgres.TranslateError(err)
+	}
+	created, err := repository.scan(repository.db.QueryRow(ctx, sql, args...))
+	if errors.Is(err, entity.ErrDataNotFound) {
+		return dto.IdempotencyRecordDTO{}, fmt.Errorf("%w: idempotency key in use", entity.ErrConflictingData)
+	}
+	return created, err
+}
+
+func (repository IdempotencyRecordRepositoryImpl) GetIdempotencyRecord(ctx context.Context, principal string, key string) (dto.IdempotencyRecordDTO, error) {
+	query := repository.db.QueryBuilder.Select("*").
+		From("idempotency_keys").
+		Where(sq.Eq{"principal": principal, "key": key}).
+		Limit(1)
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return dto.IdempotencyRecordDTO{}, postgres.TranslateError(err)
+	}
+	return repository.scan(repository.db.QueryRow(ctx, sql, args...))
+}
+
+func (repository IdempotencyRecordRepositoryImpl) CompleteIdempotencyRecord(ctx context.Context, record dto.CompleteIdempotencyRecordDTO) error {
+	query := repository.db.QueryBuilder.Update("idempotency_keys").
+		Set("status_code", record.StatusCode).
+		Set("response_body", record.ResponseBody).
+		Set("content_type", utils.NullString(record.ContentType)).
+		Set("completed_at", time.Now().UTC()).
+		Where(sq.Eq{"id": record.Id})
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return postgres.TranslateError(err)
+	}
+	_, err = repository.db.Exec(ctx, sql, args...)
+	if err != nil {
+		return postgres.TranslateError(err)
+	}
+	return nil
+}
+
+func (repository IdempotencyRecordRepositoryImpl) DeleteIdempotencyRecord(ctx context.Context, id string) error {
+	query := repository.db.QueryBuilder.Delete("idempotency_keys").
+		Where(sq.Eq{"id": id})
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return postgres.TranslateError(err)
+	}
+	_, err = repository.db.Exec(ctx, sql, args...)
+	if err != nil {
+		return postgres.TranslateError(err)
+	}
+	return nil
+}
+
+func (repository IdempotencyRecordRepositoryImpl) DeleteExpiredIdempotencyRecords(ctx context.Context) error {
+	query := repository.db.QueryBuilder.Delete("idempotency_keys").
+		Where(sq.LtOrEq{"expires_at": time.Now().UTC()})
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return postgres.TranslateError(err)
+	}
+	_, err = repository.db.Exec(ctx, sql, args...)
+	if err != nil {
+		return postgres.TranslateError(err)
+	}
+	return nil
+}
+
+func (repository IdempotencyRecordRepositoryImpl) scan(row pgx.Row) (dto.IdempotencyRecordDTO, error) {
+	var recordModel model.IdempotencyRecordModel
+	err := row.Scan(
+		&recordModel.Id,
+		&recordModel.Principal,
+		&recordModel.Key,
+		&recordModel.RequestHash,
+		&recordModel.StatusCode,
+		&recordModel.ResponseBody,
+		&recordModel.ContentType,
+		&recordModel.CreatedAt,
+		&recordModel.CompletedAt,
+		&recordModel.ExpiresAt,
+	)
+	if err != nil {
+		return dto.IdempotencyRecordDTO{}, postgres.TranslateError(err)
+	}
+	return recordModel.ToDTO(), nil
+}
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	dto "post-tech-challenge-10soat/internal/dto/product"
+	"post-tech-challenge-10soat/internal/external/postgres"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+type MenuRepositoryImpl struct {
+	db *postgres.DB
+}
+
+func NewMenuRepositoryImpl(db *postgres.DB) MenuRepositoryImpl {
+	return MenuRepositoryImpl{
+		db,
+	}
+}
+
+// ImportMenu creates the categories and then creates or updates each product
+// by SKU, in a single transaction so a failure leaves the menu untouched.
+// Products refer to their category by name, the oldest one winning when
+// names repeat.
+func (repository MenuRepositoryImpl) ImportMenu(ctx context.Context, categories []string, products []dto.ImportProductDTO) error {
+	tx, err := repository.db.Begin(ctx)
+	if err != nil {
+		return postgres.TranslateError(err)
+	}
+	// Does nothing once committed.
+	defer tx.Rollback(ctx)
+
+	for _, name := range categories {
+		query := repository.db.QueryBuilder.Insert("categories").
+			Columns("name").
+			Values(name)
+		sql, args, err := query.ToSql()
+		if err != nil {
+			return postgres.TranslateError(err)
+		}
+		if _, err := tx.Exec(ctx, sql, args...); err != nil {
+			return fmt.Errorf("failed to create category %s - %w", name, postgres.TranslateError(err))
+		}
+	}
+	for _, product := range products {
+		query := repository.db.QueryBuilder.Insert("products").
+			Columns("sku", "name", "description", "image", "value", "category_id").
+			Values(
+				product.Sku,
+				product.Name,
+				product.Description,
+				product.Image,
+				product.Value,
+				sq.Expr(`(SELECT "id" FROM "categories" WHERE "name" = ? ORDER BY "created_at" LIMIT 1)`, product.Category),
+			).
+			Suffix(`ON CONFLICT ("sku") DO UPDATE SET
+				"name" = EXCLUDED."name",
+				"description" = EXCLUDED."description",
+				"image" = EXCLUDED."image",
+				"value" = EXCLUDED."value",
+				"category_id" = EXCLUDED."category_id",
+				"updated_at" = now(),
+				"version" = "products"."version" + 1`)
+		sql, args, err := query.ToSql()
+		if err != nil {
+			return postgres.TranslateError(err)
+		}
+		if _, err := tx.Exec(ctx, sql, args...); err != nil {
+			return fmt.Errorf("failed to import product %s - %w", product.Sku, postgres.TranslateError(err))
+		}
+	}
+	return postgres.TranslateError(tx.Commit(ctx))
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	dto "post-tech-challenge-10soat/internal/dto/notification"
+	"post-tech-challenge-10soat/internal/external/postgres"
+	"post-tech-challenge-10soat/internal/external/postgres/model"
+	"post-tech-challenge-10soat/internal/utils"
+)
+
+type NotificationDeliveryRepositoryImpl struct {
+	db *postgres.DB
+}
+
+func NewNotificationDeliveryRepositoryImpl(db *postgres.DB) NotificationDeliveryRepositoryImpl {
+	return NotificationDeliveryRepositoryImpl{
+		db,
+	}
+}
+
+func (repository NotificationDeliveryRepositoryImpl) CreateNotificationDelivery(ctx context.Context, delivery dto.CreateNotificationDeliveryDTO) (dto.NotificationDeliveryDTO, error) {
+	var deliveryModel model.NotificationDeliveryModel
+	query := repository.db.QueryBuilder.Insert("notification_deliveries").
+		Columns("order_id", "client_id", "event", "channel", "recipient", "status", "error").
+		Values(
+			delivery.OrderId,
+			utils.NullString(delivery.ClientId),
+			delivery.Event,
+			delivery.Channel,
+			utils.NullString(delivery.Recipient),
+			delivery.Status,
+			utils.NullString(delivery.Error),
+		).
+		Suffix("RETURNING *")
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return dto.NotificationDeliveryDTO{}, postgres.TranslateError(err)
+	}
+	err = repository.db.QueryRow(ctx, sql, args...).Scan(
+		&deliveryModel.Id,
+		&deliveryModel.OrderId,
+		&deliveryModel.ClientId,
+		&deliveryModel.Event,
+		&deliveryModel.Channel,
+		&deliveryModel.Recipient,
+		&deliveryModel.Status,
+		&deliveryModel.Error,
+		&deliveryModel.CreatedAt,
+	)
+	if err != nil {
+		return dto.NotificationDeliveryDTO{}, postgres.TranslateError(err)
+	}
+	return deliveryModel.ToDTO(), nil
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 	dto "post-tech-challenge-10soat/internal/dto/order"
+	entity "post-tech-challenge-10soat/internal/entities"
 	"post-tech-challenge-10soat/internal/external/postgres"
 	"post-tech-challenge-10soat/internal/external/postgres/model"
+	"post-tech-challenge-10soat/internal/utils"
 
 	sq "github.com/Masterminds/squirrel"
 )
@@ -20,17 +22,26 @@ func NewOrderRepositoryImpl(db *postgres.DB) OrderRepositoryImpl {
 	}
 }
 
+// CreateOrder inserts the order and its products in a single transaction,
+// so an order is never left without them.
 func (repository OrderRepositoryImpl) CreateOrder(ctx context.Context, order dto.CreateOrderDTO) (dto.OrderDTO, error) {
+	tx, err := repository.db.Begin(ctx)
+	if err != nil {
+		return dto.OrderDTO{}, postgres.TranslateError(err)
+	}
+	// Does nothing once committed.
+	defer tx.Rollback(ctx)
+
 	var orderModel model.OrderModel
 	query := repository.db.QueryBuilder.Insert("orders").
 		Columns("status", "client_id", "total").
-		Values(order.Status, order.ClientId, order.Total).
+		Values(order.Status, utils.NullString(order.ClientId), order.Total).
 		Suffix("RETURNING *")
 	sql, args, err := query.ToSql()
 	if err != nil {
-		return dto.OrderDTO{}, err
+		return dto.OrderDTO{}, postgres.TranslateError(err)
 	}
-	err = repository.db.QueryRow(ctx, sql, args...).Scan(
+	err = tx.QueryRow(ctx, sql, args...).Scan(
 		&orderModel.Id,
 		&orderModel.Number,
 		&orderModel.Status,
@@ -39,9 +50,27 @@ func (repository OrderRepositoryImpl) CreateOrder(ctx context.Context, order dto
 		&orderModel.Total,
 		&orderModel.CreatedAt,
 		&orderModel.UpdatedAt,
+		&orderModel.Version,
 	)
 	if err != nil {
-		return dto.OrderDTO{}, err
+		return dto.OrderDTO{}, postgres.TranslateError(err)
+	}
+	if len(order.Products) > 0 {
+		productsQuery := repository.db.QueryBuilder.Insert("order_products").
+			Columns("order_id", "product_id", "quantity", "sub_total", "observation")
+		for _, product := range order.Products {
+			productsQuery = productsQuery.Values(orderModel.Id, product.ProductId, product.Quantity, product.SubTotal, product.Observation)
+		}
+		sql, args, err := productsQuery.ToSql()
+		if err != nil {
+			return dto.OrderDTO{}, postgres.TranslateError(err)
+		}
+		if _, err := tx.Exec(ctx, sql, args...); err != nil {
+			return dto.OrderDTO{}, postgres.TranslateError(err)
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return dto.OrderDTO{}, postgres.TranslateError(err)
 	}
 	return orderModel.ToDTO(), nil
 }
@@ -51,11 +80,14 @@ func (repository OrderRepositoryImpl) DeleteOrder(ctx context.Context, id string
 		Where(sq.Eq{"id": id})
 	sql, args, err := query.ToSql()
 	if err != nil {
-		return err
+		return postgres.TranslateError(err)
 	}
-	_, err = repository.db.Exec(ctx, sql, args...)
+	tag, err := repository.db.Exec(ctx, sql, args...)
 	if err != nil {
-		return err
+		return postgres.TranslateError(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return entity.ErrDataNotFound
 	}
 	return nil
 }
@@ -74,11 +106,11 @@ func (repository OrderRepositoryImpl) ListOrders(ctx context.Context, limit uint
 		Limit(limit)
 	sql, args, err := query.ToSql()
 	if err != nil {
-		return []dto.OrderDTO{}, fmt.Errorf("failed to get orders - %s", err.Error())
+		return []dto.OrderDTO{}, fmt.Errorf("failed to get orders - %w", postgres.TranslateError(err))
 	}
-	rows, err := repository.db.Query(ctx, sql, args...)
+	rows, err := repository.db.Reader(ctx).Query(ctx, sql, args...)
 	if err != nil {
-		return []dto.OrderDTO{}, fmt.Errorf("failed to get orders - %s", err.Error())
+		return []dto.OrderDTO{}, fmt.Errorf("failed to get orders - %w", postgres.TranslateError(err))
 	}
 	for rows.Next() {
 		err := rows.Scan(
@@ -90,6 +122,7 @@ func (repository OrderRepositoryImpl) ListOrders(ctx context.Context, limit uint
 			&orderModel.Total,
 			&orderModel.CreatedAt,
 			&orderModel.UpdatedAt,
+			&orderModel.Version,
 		)
 		if err == nil {
 			order := orderModel.ToDTO()
@@ -107,7 +140,7 @@ func (repository OrderRepositoryImpl) GetOrderById(ctx context.Context, id strin
 		Limit(1)
 	sql, args, err := query.ToSql()
 	if err != nil {
-		return dto.OrderDTO{}, err
+		return dto.OrderDTO{}, postgres.TranslateError(err)
 	}
 	err = repository.db.QueryRow(ctx, sql, args...).Scan(
 		&orderModel.Id,
@@ -118,22 +151,26 @@ func (repository OrderRepositoryImpl) GetOrderById(ctx context.Context, id strin
 		&orderModel.Total,
 		&orderModel.CreatedAt,
 		&orderModel.UpdatedAt,
+		&orderModel.Version,
 	)
 	if err != nil {
-		return dto.OrderDTO{}, err
+		return dto.OrderDTO{}, postgres.TranslateError(err)
 	}
 	return orderModel.ToDTO(), nil
 }
 
-func (repository OrderRepositoryImpl) UpdateOrderStatus(ctx context.Context, id string, status string) (dto.OrderDTO, error) {
+// UpdateOrderStatus only applies when the order still has the given version,
+// returning entity.ErrDataNotFound otherwise.
+func (repository OrderRepositoryImpl) UpdateOrderStatus(ctx context.Context, id string, status string, version int) (dto.OrderDTO, error) {
 	var orderModel model.OrderModel
 	query := repository.db.QueryBuilder.Update("orders").
 		Set("status", sq.Expr("COALESCE(?, status)", status)).
-		Where(sq.Eq{"id": id}).
+		Set("version", sq.Expr("version + 1")).
+		Where(sq.Eq{"id": id, "version": version}).
 		Suffix("RETURNING *")
 	sql, args, err := query.ToSql()
 	if err != nil {
-		return dto.OrderDTO{}, err
+		return dto.OrderDTO{}, postgres.TranslateError(err)
 	}
 	err = repository.db.QueryRow(ctx, sql, args...).Scan(
 		&orderModel.Id,
@@ -144,9 +181,38 @@ func (repository OrderRepositoryImpl) UpdateOrderStatus(ctx context.Context, id
 		&orderModel.Total,
 		&orderModel.CreatedAt,
 		&orderModel.UpdatedAt,
+		&orderModel.Version,
 	)
 	if err != nil {
-		return dto.OrderDTO{}, err
+		return dto.OrderDTO{}, postgres.TranslateError(err)
 	}
 	return orderModel.ToDTO(), nil
 }
+
+func (repository OrderRepositoryImpl) CountOrdersByStatus(ctx context.Context) (map[string]int, error) {
+	query := repository.db.QueryBuilder.Select("status", "COUNT(*)").
+		From("orders").
+		GroupBy("status")
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to count orders - %w", postgres.TranslateError(err))
+	}
+	rows, err := repository.db.Reader(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count orders - %w", postgres.TranslateError(err))
+	}
+	defer rows.Close()
+	counts := map[string]int{}
+	for rows.Next() {
+		var status string
+		var count int
+		if err := rows.Scan(&status, &count); err != nil {
+			return nil, fmt.Errorf("failed to count orders - %w", postgres.TranslateError(err))
+		}
+		counts[status] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to count orders - %w", postgres.TranslateError(err))
+	}
+	return counts, nil
+}
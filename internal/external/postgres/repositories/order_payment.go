@@ -5,6 +5,8 @@ import (
 	dto "post-tech-challenge-10soat/internal/dto/order"
 	"post-tech-challenge-10soat/internal/external/postgres"
 	"post-tech-challenge-10soat/internal/external/postgres/model"
+
+	sq "github.com/Masterminds/squirrel"
 )
 
 type OrderProductRepositoryImpl struct {
@@ -25,7 +27,7 @@ func (repository OrderProductRepositoryImpl) CreateOrderProduct(ctx context.Cont
 		Suffix("RETURNING *")
 	sql, args, err := query.ToSql()
 	if err != nil {
-		return dto.OrderProductDTO{}, err
+		return dto.OrderProductDTO{}, postgres.TranslateError(err)
 	}
 	err = repository.db.QueryRow(ctx, sql, args...).Scan(
 		&orderProductModel.Id,
@@ -38,7 +40,42 @@ func (repository OrderProductRepositoryImpl) CreateOrderProduct(ctx context.Cont
 		&orderProductModel.UpdatedAt,
 	)
 	if err != nil {
-		return dto.OrderProductDTO{}, err
+		return dto.OrderProductDTO{}, postgres.TranslateError(err)
 	}
 	return orderProductModel.ToDTO(), nil
 }
+
+func (repository OrderProductRepositoryImpl) ListOrderProductsByOrderIds(ctx context.Context, orderIds []string) ([]dto.OrderProductDTO, error) {
+	var orderProductModel model.OrderProductModel
+	var orderProducts []dto.OrderProductDTO
+	query := repository.db.QueryBuilder.Select("*").
+		From("order_products").
+		Where(sq.Eq{"order_id": orderIds}).
+		OrderBy("created_at")
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return []dto.OrderProductDTO{}, postgres.TranslateError(err)
+	}
+	rows, err := repository.db.Reader(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return []dto.OrderProductDTO{}, postgres.TranslateError(err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		err := rows.Scan(
+			&orderProductModel.Id,
+			&orderProductModel.OrderId,
+			&orderProductModel.ProductId,
+			&orderProductModel.Quantity,
+			&orderProductModel.SubTotal,
+			&orderProductModel.Observation,
+			&orderProductModel.CreatedAt,
+			&orderProductModel.UpdatedAt,
+		)
+		if err != nil {
+			return []dto.OrderProductDTO{}, postgres.TranslateError(err)
+		}
+		orderProducts = append(orderProducts, orderProductModel.ToDTO())
+	}
+	return orderProducts, nil
+}
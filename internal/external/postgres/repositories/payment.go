@@ -25,7 +25,7 @@ func (repository PaymentRepositoryImpl) CreatePayment(ctx context.Context, payme
 		Suffix("RETURNING *")
 	sql, args, err := query.ToSql()
 	if err != nil {
-		return dto.PaymentDTO{}, err
+		return dto.PaymentDTO{}, postgres.TranslateError(err)
 	}
 	err = repository.db.QueryRow(ctx, sql, args...).Scan(
 		&paymentModel.Id,
@@ -35,7 +35,7 @@ func (repository PaymentRepositoryImpl) CreatePayment(ctx context.Context, payme
 		&paymentModel.UpdatedAt,
 	)
 	if err != nil {
-		return dto.PaymentDTO{}, err
+		return dto.PaymentDTO{}, postgres.TranslateError(err)
 	}
 	return paymentModel.ToDTO(), nil
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	dto "post-tech-challenge-10soat/internal/dto/product"
+	entity "post-tech-challenge-10soat/internal/entities"
 	"post-tech-challenge-10soat/internal/external/postgres"
 	"post-tech-challenge-10soat/internal/external/postgres/model"
 
@@ -40,11 +41,11 @@ func (repository ProductRepositoryImpl) ListProducts(ctx context.Context, catego
 	}
 	sql, args, err := query.ToSql()
 	if err != nil {
-		return []dto.ProductDTO{}, err
+		return []dto.ProductDTO{}, postgres.TranslateError(err)
 	}
-	rows, err := repository.db.Query(ctx, sql, args...)
+	rows, err := repository.db.Reader(ctx).Query(ctx, sql, args...)
 	if err != nil {
-		return []dto.ProductDTO{}, err
+		return []dto.ProductDTO{}, postgres.TranslateError(err)
 	}
 	for rows.Next() {
 		err := rows.Scan(
@@ -56,9 +57,11 @@ func (repository ProductRepositoryImpl) ListProducts(ctx context.Context, catego
 			&productModel.CategoryId,
 			&productModel.CreatedAt,
 			&productModel.UpdatedAt,
+			&productModel.Version,
+			&productModel.Sku,
 		)
 		if err != nil {
-			return []dto.ProductDTO{}, err
+			return []dto.ProductDTO{}, postgres.TranslateError(err)
 		}
 		product := productModel.ToDTO()
 		products = append(products, product)
@@ -66,6 +69,78 @@ func (repository ProductRepositoryImpl) ListProducts(ctx context.Context, catego
 	return products, nil
 }
 
+func (repository ProductRepositoryImpl) ListProductsByIds(ctx context.Context, ids []string) ([]dto.ProductDTO, error) {
+	var productModel model.ProductModel
+	var products []dto.ProductDTO
+	query := repository.db.QueryBuilder.Select("*").
+		From("products").
+		Where(sq.Eq{"id": ids})
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return []dto.ProductDTO{}, postgres.TranslateError(err)
+	}
+	rows, err := repository.db.Reader(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return []dto.ProductDTO{}, postgres.TranslateError(err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		err := rows.Scan(
+			&productModel.Id,
+			&productModel.Name,
+			&productModel.Description,
+			&productModel.Image,
+			&productModel.Value,
+			&productModel.CategoryId,
+			&productModel.CreatedAt,
+			&productModel.UpdatedAt,
+			&productModel.Version,
+			&productModel.Sku,
+		)
+		if err != nil {
+			return []dto.ProductDTO{}, postgres.TranslateError(err)
+		}
+		products = append(products, productModel.ToDTO())
+	}
+	return products, nil
+}
+
+func (repository ProductRepositoryImpl) ListProductsBySkus(ctx context.Context, skus []string) ([]dto.ProductDTO, error) {
+	var productModel model.ProductModel
+	var products []dto.ProductDTO
+	query := repository.db.QueryBuilder.Select("*").
+		From("products").
+		Where(sq.Eq{"sku": skus})
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return []dto.ProductDTO{}, postgres.TranslateError(err)
+	}
+	rows, err := repository.db.Query(ctx, sql, args...)
+	if err != nil {
+		return []dto.ProductDTO{}, postgres.TranslateError(err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		err := rows.Scan(
+			&productModel.Id,
+			&productModel.Name,
+			&productModel.Description,
+			&productModel.Image,
+			&productModel.Value,
+			&productModel.CategoryId,
+			&productModel.CreatedAt,
+			&productModel.UpdatedAt,
+			&productModel.Version,
+			&productModel.Sku,
+		)
+		if err != nil {
+			return []dto.ProductDTO{}, postgres.TranslateError(err)
+		}
+		products = append(products, productModel.ToDTO())
+	}
+	return products, nil
+}
+
 func (repository ProductRepositoryImpl) GetProductById(ctx context.Context, id string) (dto.ProductDTO, error) {
 	var productModel model.ProductModel
 	query := repository.db.QueryBuilder.Select("*").
@@ -74,7 +149,7 @@ func (repository ProductRepositoryImpl) GetProductById(ctx context.Context, id s
 		Limit(1)
 	sql, args, err := query.ToSql()
 	if err != nil {
-		return dto.ProductDTO{}, err
+		return dto.ProductDTO{}, postgres.TranslateError(err)
 	}
 	err = repository.db.QueryRow(ctx, sql, args...).Scan(
 		&productModel.Id,
@@ -85,22 +160,29 @@ func (repository ProductRepositoryImpl) GetProductById(ctx context.Context, id s
 		&productModel.CategoryId,
 		&productModel.CreatedAt,
 		&productModel.UpdatedAt,
+		&productModel.Version,
+		&productModel.Sku,
 	)
 	if err != nil {
-		return dto.ProductDTO{}, err
+		return dto.ProductDTO{}, postgres.TranslateError(err)
 	}
 	return productModel.ToDTO(), nil
 }
 
 func (repository ProductRepositoryImpl) CreateProduct(ctx context.Context, product dto.CreateProductDTO) (dto.ProductDTO, error) {
 	var productModel model.ProductModel
+	id := uuid.NewString()
+	sku := product.Sku
+	if sku == "" {
+		sku = id
+	}
 	query := repository.db.QueryBuilder.Insert("products").
-		Columns("name", "description", "image", "value", "category_id").
-		Values(product.Name, product.Description, product.Image, product.Value, product.CategoryId).
+		Columns("id", "sku", "name", "description", "image", "value", "category_id").
+		Values(id, sku, product.Name, product.Description, product.Image, product.Value, product.CategoryId).
 		Suffix("RETURNING *")
 	sql, args, err := query.ToSql()
 	if err != nil {
-		return dto.ProductDTO{}, err
+		return dto.ProductDTO{}, postgres.TranslateError(err)
 	}
 	err = repository.db.QueryRow(ctx, sql, args...).Scan(
 		&productModel.Id,
@@ -111,13 +193,17 @@ func (repository ProductRepositoryImpl) CreateProduct(ctx context.Context, produ
 		&productModel.CategoryId,
 		&productModel.CreatedAt,
 		&productModel.UpdatedAt,
+		&productModel.Version,
+		&productModel.Sku,
 	)
 	if err != nil {
-		return dto.ProductDTO{}, err
+		return dto.ProductDTO{}, postgres.TranslateError(err)
 	}
 	return productModel.ToDTO(), nil
 }
 
+// UpdateProduct only applies when the product still has the given version,
+// returning entity.ErrDataNotFound otherwise.
 func (repository ProductRepositoryImpl) UpdateProduct(ctx context.Context, product dto.UpdateProductDTO) (dto.ProductDTO, error) {
 	var productModel model.ProductModel
 	name := utils.NullString(product.Name)
@@ -130,11 +216,12 @@ func (repository ProductRepositoryImpl) UpdateProduct(ctx context.Context, produ
 		Set("value", sq.Expr("COALESCE(?, value)", product.Value)).
 		Set("category_id", sq.Expr("COALESCE(?, category_id)", product.CategoryId)).
 		Set("updated_at", time.Now()).
-		Where(sq.Eq{"id": product.Id}).
+		Set("version", sq.Expr("version + 1")).
+		Where(sq.Eq{"id": product.Id, "version": product.Version}).
 		Suffix("RETURNING *")
 	sql, args, err := query.ToSql()
 	if err != nil {
-		return dto.ProductDTO{}, err
+		return dto.ProductDTO{}, postgres.TranslateError(err)
 	}
 	err = repository.db.QueryRow(ctx, sql, args...).Scan(
 		&productModel.Id,
@@ -145,9 +232,11 @@ func (repository ProductRepositoryImpl) UpdateProduct(ctx context.Context, produ
 		&productModel.CategoryId,
 		&productModel.CreatedAt,
 		&productModel.UpdatedAt,
+		&productModel.Version,
+		&productModel.Sku,
 	)
 	if err != nil {
-		return dto.ProductDTO{}, err
+		return dto.ProductDTO{}, postgres.TranslateError(err)
 	}
 	return productModel.ToDTO(), nil
 }
@@ -157,11 +246,14 @@ func (repository ProductRepositoryImpl) DeleteProduct(ctx context.Context, id st
 		Where(sq.Eq{"id": id})
 	sql, args, err := query.ToSql()
 	if err != nil {
-		return err
+		return postgres.TranslateError(err)
 	}
-	_, err = repository.db.Exec(ctx, sql, args...)
+	tag, err := repository.db.Exec(ctx, sql, args...)
 	if err != nil {
-		return err
+		return postgres.TranslateError(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return entity.ErrDataNotFound
 	}
 	return nil
 }
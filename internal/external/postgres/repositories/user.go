@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+	dto "post-tech-challenge-10soat/internal/dto/user"
+	"post-tech-challenge-10soat/internal/external/postgres"
+	"post-tech-challenge-10soat/internal/external/postgres/model"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+type UserRepositoryImpl struct {
+	db *postgres.DB
+}
+
+func NewUserRepositoryImpl(db *postgres.DB) UserRepositoryImpl {
+	return UserRepositoryImpl{
+		db,
+	}
+}
+
+func (repository UserRepositoryImpl) CreateUser(ctx context.Context, user dto.StoreUserDTO) (dto.UserDTO, error) {
+	query := repository.db.QueryBuilder.Insert("users").
+		Columns("username", "password_hash", "role").
+		Values(user.Username, user.PasswordHash, user.Role).
+		Suffix("RETURNING *")
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return dto.UserDTO{}, postgres.TranslateError(err)
+	}
+	return repository.scan(repository.db.QueryRow(ctx, sql, args...))
+}
+
+func (repository UserRepositoryImpl) GetUserByUsername(ctx context.Context, username string) (dto.UserDTO, error) {
+	query := repository.db.QueryBuilder.Select("*").
+		From("users").
+		Where(sq.Eq{"username": username}).
+		Limit(1)
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return dto.UserDTO{}, postgres.TranslateError(err)
+	}
+	return repository.scan(repository.db.QueryRow(ctx, sql, args...))
+}
+
+func (repository UserRepositoryImpl) scan(row pgx.Row) (dto.UserDTO, error) {
+	var userModel model.UserModel
+	err := row.Scan(
+		&userModel.Id,
+		&userModel.Username,
+		&userModel.PasswordHash,
+		&userModel.Role,
+		&userModel.Active,
+		&userModel.CreatedAt,
+		&userModel.UpdatedAt,
+	)
+	if err != nil {
+		return dto.UserDTO{}, postgres.TranslateError(err)
+	}
+	return userModel.ToDTO(), nil
+}
@@ -0,0 +1,51 @@
+package ratelimit
+
+import (
+	"context"
+	entity "post-tech-challenge-10soat/internal/entities"
+	"sync"
+	"time"
+)
+
+const sweepInterval = time.Minute
+
+type memoryBucket struct {
+	entity.TokenBucket
+	fullAt time.Time
+}
+
+// MemoryRateLimitGatewayImpl keeps the buckets in the process, so every
+// instance enforces its own limits. Full buckets are swept periodically.
+type MemoryRateLimitGatewayImpl struct {
+	mu        sync.Mutex
+	buckets   map[string]memoryBucket
+	lastSweep time.Time
+}
+
+func NewMemoryRateLimitGatewayImpl() *MemoryRateLimitGatewayImpl {
+	return &MemoryRateLimitGatewayImpl{
+		buckets: map[string]memoryBucket{},
+	}
+}
+
+func (g *MemoryRateLimitGatewayImpl) Take(ctx context.Context, key string, limit entity.RateLimit) (entity.RateLimitDecision, error) {
+	now := time.Now()
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.sweep(now)
+	bucket, decision := g.buckets[key].Take(limit, now)
+	g.buckets[key] = memoryBucket{bucket, bucket.FullAt(limit)}
+	return decision, nil
+}
+
+func (g *MemoryRateLimitGatewayImpl) sweep(now time.Time) {
+	if now.Sub(g.lastSweep) < sweepInterval {
+		return
+	}
+	g.lastSweep = now
+	for key, bucket := range g.buckets {
+		if !now.Before(bucket.fullAt) {
+			delete(g.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package ratelimit
+
+import (
+	"context"
+	"log/slog"
+	entity "post-tech-challenge-10soat/internal/entities"
+	"post-tech-challenge-10soat/internal/external/postgres"
+	"sync"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+const purgeInterval = time.Hour
+
+// PostgresRateLimitGatewayImpl shares the buckets between instances. Each
+// take locks the bucket row, so concurrent requests for the same key are
+// serialized by the database.
+type PostgresRateLimitGatewayImpl struct {
+	db         *postgres.DB
+	purgeMutex sync.Mutex
+	lastPurge  time.Time
+}
+
+func NewPostgresRateLimitGatewayImpl(db *postgres.DB) *PostgresRateLimitGatewayImpl {
+	return &PostgresRateLimitGatewayImpl{
+		db: db,
+	}
+}
+
+func (g *PostgresRateLimitGatewayImpl) Take(ctx context.Context, key string, limit entity.RateLimit) (entity.RateLimitDecision, error) {
+	g.purge(ctx)
+	tx, err := g.db.Begin(ctx)
+	if err != nil {
+		return entity.RateLimitDecision{}, postgres.TranslateError(err)
+	}
+	defer tx.Rollback(ctx)
+
+	bucket, err := g.lockBucket(ctx, tx, key)
+	if err != nil {
+		return entity.RateLimitDecision{}, err
+	}
+	bucket, decision := bucket.Take(limit, time.Now().UTC())
+	query := g.db.QueryBuilder.Insert("rate_limit_buckets").
+		Columns("key", "tokens", "updated_at", "full_at").
+		Values(key, bucket.Tokens, bucket.UpdatedAt, bucket.FullAt(limit)).
+		Suffix(`ON CONFLICT (key) DO UPDATE SET
+			tokens = EXCLUDED.tokens,
+			updated_at = EXCLUDED.updated_at,
+			full_at = EXCLUDED.full_at`)
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return entity.RateLimitDecision{}, postgres.TranslateError(err)
+	}
+	if _, err = tx.Exec(ctx, sql, args...); err != nil {
+		return entity.RateLimitDecision{}, postgres.TranslateError(err)
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return entity.RateLimitDecision{}, postgres.TranslateError(err)
+	}
+	return decision, nil
+}
+
+// lockBucket returns the stored bucket, or a full one for a new key. A new
+// key first gets a placeholder row with a zero update time, which Take
+// treats as full, because FOR UPDATE locks nothing when the row is missing
+// and two first requests would otherwise both start from a full bucket.
+func (g *PostgresRateLimitGatewayImpl) lockBucket(ctx context.Context, tx pgx.Tx, key string) (entity.TokenBucket, error) {
+	insert := g.db.QueryBuilder.Insert("rate_limit_buckets").
+		Columns("key", "tokens", "updated_at", "full_at").
+		Values(key, 0, time.Time{}, time.Time{}).
+		Suffix("ON CONFLICT (key) DO NOTHING")
+	sql, args, err := insert.ToSql()
+	if err != nil {
+		return entity.TokenBucket{}, postgres.TranslateError(err)
+	}
+	if _, err = tx.Exec(ctx, sql, args...); err != nil {
+		return entity.TokenBucket{}, postgres.TranslateError(err)
+	}
+
+	query := g.db.QueryBuilder.Select("tokens", "updated_at").
+		From("rate_limit_buckets").
+		Where(sq.Eq{"key": key}).
+		Suffix("FOR UPDATE")
+	sql, args, err = query.ToSql()
+	if err != nil {
+		return entity.TokenBucket{}, postgres.TranslateError(err)
+	}
+	var bucket entity.TokenBucket
+	if err = tx.QueryRow(ctx, sql, args...).Scan(&bucket.Tokens, &bucket.UpdatedAt); err != nil {
+		return entity.TokenBucket{}, postgres.TranslateError(err)
+	}
+	return bucket, nil
+}
+
+// purge drops full buckets, which behave like missing ones, at most once an
+// hour per instance.
+func (g *PostgresRateLimitGatewayImpl) purge(ctx context.Context) {
+	g.purgeMutex.Lock()
+	if time.Since(g.lastPurge) < purgeInterval {
+		g.purgeMutex.Unlock()
+		return
+	}
+	g.lastPurge = time.Now()
+	g.purgeMutex.Unlock()
+	query := g.db.QueryBuilder.Delete("rate_limit_buckets").
+		Where(sq.LtOrEq{"full_at": time.Now().UTC()})
+	sql, args, err := query.ToSql()
+	if err == nil {
+		_, err = g.db.Exec(ctx, sql, args...)
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Error purging full rate limit buckets", "error", postgres.TranslateError(err))
+	}
+}
@@ -0,0 +1,90 @@
+package token
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	entity "post-tech-challenge-10soat/internal/entities"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+type claims struct {
+	Role entity.Role `json:"role"`
+	jwt.RegisteredClaims
+}
+
+// JwtTokenGatewayImpl signs tokens with the current key and verifies them with
+// any known key, selected by the "kid" header, so keys can be rotated without
+// invalidating sessions that are still open.
+type JwtTokenGatewayImpl struct {
+	keyId  string
+	keys   map[string][]byte
+	issuer string
+}
+
+func NewJwtTokenGatewayImpl(keyId string, secret string, previousKeys map[string]string, issuer string) JwtTokenGatewayImpl {
+	keys := make(map[string][]byte, len(previousKeys)+1)
+	for id, previousSecret := range previousKeys {
+		keys[id] = []byte(previousSecret)
+	}
+	keys[keyId] = []byte(secret)
+	return JwtTokenGatewayImpl{
+		keyId:  keyId,
+		keys:   keys,
+		issuer: issuer,
+	}
+}
+
+func (g JwtTokenGatewayImpl) IssueToken(ctx context.Context, session entity.Session) (entity.Token, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims{
+		Role: session.Role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    g.issuer,
+			Subject:   session.Subject,
+			ExpiresAt: jwt.NewNumericDate(session.ExpiresAt),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	})
+	token.Header["kid"] = g.keyId
+	signed, err := token.SignedString(g.keys[g.keyId])
+	if err != nil {
+		return entity.Token{}, err
+	}
+	return entity.Token{
+		AccessToken: signed,
+		ExpiresAt:   session.ExpiresAt,
+	}, nil
+}
+
+func (g JwtTokenGatewayImpl) ParseToken(ctx context.Context, tokenString string) (entity.Session, error) {
+	var parsed claims
+	_, err := jwt.ParseWithClaims(tokenString, &parsed, g.key,
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(g.issuer),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return entity.Session{}, fmt.Errorf("%w: token expired", entity.ErrUnauthorized)
+		}
+		return entity.Session{}, fmt.Errorf("%w: invalid token", entity.ErrUnauthorized)
+	}
+	return entity.Session{
+		Subject:   parsed.Subject,
+		Role:      parsed.Role,
+		ExpiresAt: parsed.ExpiresAt.Time,
+	}, nil
+}
+
+// key picks the verification key from the "kid" header, which every token
+// issued by IssueToken carries.
+func (g JwtTokenGatewayImpl) key(token *jwt.Token) (any, error) {
+	keyId, _ := token.Header["kid"].(string)
+	key, ok := g.keys[keyId]
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key '%s'", keyId)
+	}
+	return key, nil
+}
@@ -0,0 +1,78 @@
+package gateways
+
+import (
+	"context"
+	dto "post-tech-challenge-10soat/internal/dto/apikey"
+	entity "post-tech-challenge-10soat/internal/entities"
+	interfaces "post-tech-challenge-10soat/internal/interfaces/repositories"
+	"time"
+)
+
+type ApiKeyGatewayImpl struct {
+	repository interfaces.ApiKeyRepository
+}
+
+func NewApiKeyGatewayImpl(repository interfaces.ApiKeyRepository) *ApiKeyGatewayImpl {
+	return &ApiKeyGatewayImpl{
+		repository,
+	}
+}
+
+func (ag ApiKeyGatewayImpl) CreateApiKey(ctx context.Context, apiKey entity.ApiKey) (entity.ApiKey, error) {
+	scopes := make([]string, 0, len(apiKey.Scopes))
+	for _, scope := range apiKey.Scopes {
+		scopes = append(scopes, string(scope))
+	}
+	storeApiKeyDTO := dto.StoreApiKeyDTO{
+		Name:      apiKey.Name,
+		Prefix:    apiKey.Prefix,
+		KeyHash:   apiKey.KeyHash,
+		Scopes:    scopes,
+		ExpiresAt: apiKey.ExpiresAt,
+	}
+	createdApiKey, err := ag.repository.CreateApiKey(ctx, storeApiKeyDTO)
+	if err != nil {
+		return entity.ApiKey{}, err
+	}
+	return createdApiKey.ToEntity(), nil
+}
+
+func (ag ApiKeyGatewayImpl) GetApiKeyById(ctx context.Context, id string) (entity.ApiKey, error) {
+	apiKey, err := ag.repository.GetApiKeyById(ctx, id)
+	if err != nil {
+		return entity.ApiKey{}, err
+	}
+	return apiKey.ToEntity(), nil
+}
+
+func (ag ApiKeyGatewayImpl) GetApiKeyByPrefix(ctx context.Context, prefix string) (entity.ApiKey, error) {
+	apiKey, err := ag.repository.GetApiKeyByPrefix(ctx, prefix)
+	if err != nil {
+		return entity.ApiKey{}, err
+	}
+	return apiKey.ToEntity(), nil
+}
+
+func (ag ApiKeyGatewayImpl) ListApiKeys(ctx context.Context) ([]entity.ApiKey, error) {
+	apiKeysDTO, err := ag.repository.ListApiKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	apiKeys := make([]entity.ApiKey, 0, len(apiKeysDTO))
+	for _, apiKey := range apiKeysDTO {
+		apiKeys = append(apiKeys, apiKey.ToEntity())
+	}
+	return apiKeys, nil
+}
+
+func (ag ApiKeyGatewayImpl) RevokeApiKey(ctx context.Context, id string) (entity.ApiKey, error) {
+	apiKey, err := ag.repository.RevokeApiKey(ctx, id)
+	if err != nil {
+		return entity.ApiKey{}, err
+	}
+	return apiKey.ToEntity(), nil
+}
+
+func (ag ApiKeyGatewayImpl) TouchApiKey(ctx context.Context, id string, usedAt time.Time) error {
+	return ag.repository.TouchApiKey(ctx, id, usedAt)
+}
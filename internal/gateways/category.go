@@ -23,3 +23,39 @@ func (cg CategoryGatewayImpl) GetCategoryById(ctx context.Context, categoryId st
 	}
 	return category.ToEntity(), nil
 }
+
+func (cg CategoryGatewayImpl) ListCategoriesByIds(ctx context.Context, ids []string) ([]entity.Category, error) {
+	var categoriesRes []entity.Category
+	categories, err := cg.repository.ListCategoriesByIds(ctx, ids)
+	if err != nil {
+		return []entity.Category{}, err
+	}
+	for _, category := range categories {
+		categoriesRes = append(categoriesRes, category.ToEntity())
+	}
+	return categoriesRes, nil
+}
+
+func (cg CategoryGatewayImpl) ListCategories(ctx context.Context) ([]entity.Category, error) {
+	var categoriesRes []entity.Category
+	categories, err := cg.repository.ListCategories(ctx)
+	if err != nil {
+		return []entity.Category{}, err
+	}
+	for _, category := range categories {
+		categoriesRes = append(categoriesRes, category.ToEntity())
+	}
+	return categoriesRes, nil
+}
+
+func (cg CategoryGatewayImpl) ListCategoriesByNames(ctx context.Context, names []string) ([]entity.Category, error) {
+	var categoriesRes []entity.Category
+	categories, err := cg.repository.ListCategoriesByNames(ctx, names)
+	if err != nil {
+		return []entity.Category{}, err
+	}
+	for _, category := range categories {
+		categoriesRes = append(categoriesRes, category.ToEntity())
+	}
+	return categoriesRes, nil
+}
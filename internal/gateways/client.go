@@ -19,9 +19,10 @@ func NewClientGatewayImpl(repository interfaces.ClientRepository) *ClientGateway
 
 func (cg ClientGatewayImpl) CreateClient(ctx context.Context, client entity.Client) (entity.Client, error) {
 	createClientDTO := dto.CreateClientDTO{
-		Cpf:   client.Cpf,
-		Name:  client.Name,
-		Email: client.Email,
+		Cpf:      client.Cpf,
+		Name:     client.Name,
+		Email:    client.Email,
+		Language: client.Language,
 	}
 	createdClient, err := cg.repository.CreateClient(ctx, createClientDTO)
 	if err != nil {
@@ -45,3 +46,15 @@ func (cg ClientGatewayImpl) GetClientById(ctx context.Context, id string) (entit
 	}
 	return client.ToEntity(), nil
 }
+
+func (cg ClientGatewayImpl) ListClientsByIds(ctx context.Context, ids []string) ([]entity.Client, error) {
+	clients, err := cg.repository.ListClientsByIds(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	var clientEntities []entity.Client
+	for _, client := range clients {
+		clientEntities = append(clientEntities, client.ToEntity())
+	}
+	return clientEntities, nil
+}
@@ -0,0 +1,53 @@
+package gateways
+
+import (
+	"context"
+	dto "post-tech-challenge-10soat/internal/dto/consent"
+	entity "post-tech-challenge-10soat/internal/entities"
+	interfaces "post-tech-challenge-10soat/internal/interfaces/repositories"
+)
+
+type ClientConsentGatewayImpl struct {
+	repository interfaces.ClientConsentRepository
+}
+
+func NewClientConsentGatewayImpl(repository interfaces.ClientConsentRepository) *ClientConsentGatewayImpl {
+	return &ClientConsentGatewayImpl{
+		repository,
+	}
+}
+
+func (cg ClientConsentGatewayImpl) CreateClientConsent(ctx context.Context, consent entity.ClientConsent) (entity.ClientConsent, error) {
+	createConsentDTO := dto.CreateClientConsentDTO{
+		ClientId:      consent.ClientId,
+		Purpose:       string(consent.Purpose),
+		Granted:       consent.Granted,
+		Source:        consent.Source,
+		PolicyVersion: consent.PolicyVersion,
+	}
+	createdConsent, err := cg.repository.CreateClientConsent(ctx, createConsentDTO)
+	if err != nil {
+		return entity.ClientConsent{}, err
+	}
+	return createdConsent.ToEntity(), nil
+}
+
+func (cg ClientConsentGatewayImpl) GetClientConsent(ctx context.Context, clientId string, purpose entity.ConsentPurpose) (entity.ClientConsent, error) {
+	consent, err := cg.repository.GetClientConsent(ctx, clientId, string(purpose))
+	if err != nil {
+		return entity.ClientConsent{}, err
+	}
+	return consent.ToEntity(), nil
+}
+
+func (cg ClientConsentGatewayImpl) ListClientConsents(ctx context.Context, clientId string) ([]entity.ClientConsent, error) {
+	consentsDTO, err := cg.repository.ListClientConsents(ctx, clientId)
+	if err != nil {
+		return nil, err
+	}
+	consents := make([]entity.ClientConsent, 0, len(consentsDTO))
+	for _, consent := range consentsDTO {
+		consents = append(consents, consent.ToEntity())
+	}
+	return consents, nil
+}
@@ -0,0 +1,60 @@
+package gateways
+
+import (
+	"context"
+	dto "post-tech-challenge-10soat/internal/dto/client"
+	entity "post-tech-challenge-10soat/internal/entities"
+	interfaces "post-tech-challenge-10soat/internal/interfaces/repositories"
+)
+
+type ClientIdentificationGatewayImpl struct {
+	repository interfaces.ClientIdentificationRepository
+}
+
+func NewClientIdentificationGatewayImpl(repository interfaces.ClientIdentificationRepository) *ClientIdentificationGatewayImpl {
+	return &ClientIdentificationGatewayImpl{
+		repository,
+	}
+}
+
+func (cg ClientIdentificationGatewayImpl) CreateClientIdentification(ctx context.Context, identification entity.ClientIdentification) (entity.ClientIdentification, error) {
+	createIdentificationDTO := dto.CreateClientIdentificationDTO{
+		ClientId:    identification.ClientId,
+		CodeHash:    identification.CodeHash,
+		MaxAttempts: identification.MaxAttempts,
+		ExpiresAt:   identification.ExpiresAt,
+	}
+	createdIdentification, err := cg.repository.CreateClientIdentification(ctx, createIdentificationDTO)
+	if err != nil {
+		return entity.ClientIdentification{}, err
+	}
+	return createdIdentification.ToEntity(), nil
+}
+
+func (cg ClientIdentificationGatewayImpl) GetClientIdentificationById(ctx context.Context, id string) (entity.ClientIdentification, error) {
+	identification, err := cg.repository.GetClientIdentificationById(ctx, id)
+	if err != nil {
+		return entity.ClientIdentification{}, err
+	}
+	return identification.ToEntity(), nil
+}
+
+func (cg ClientIdentificationGatewayImpl) IncrementClientIdentificationAttempts(ctx context.Context, id string) (entity.ClientIdentification, error) {
+	identification, err := cg.repository.IncrementClientIdentificationAttempts(ctx, id)
+	if err != nil {
+		return entity.ClientIdentification{}, err
+	}
+	return identification.ToEntity(), nil
+}
+
+func (cg ClientIdentificationGatewayImpl) VerifyClientIdentification(ctx context.Context, id string) (entity.ClientIdentification, error) {
+	identification, err := cg.repository.VerifyClientIdentification(ctx, id)
+	if err != nil {
+		return entity.ClientIdentification{}, err
+	}
+	return identification.ToEntity(), nil
+}
+
+func (cg ClientIdentificationGatewayImpl) ExpirePendingClientIdentifications(ctx context.Context, clientId string) error {
+	return cg.repository.ExpirePendingClientIdentifications(ctx, clientId)
+}
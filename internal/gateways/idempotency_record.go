@@ -0,0 +1,57 @@
+package gateways
+
+import (
+	"context"
+	dto "post-tech-challenge-10soat/internal/dto/idempotency"
+	entity "post-tech-challenge-10soat/internal/entities"
+	interfaces "post-tech-challenge-10soat/internal/interfaces/repositories"
+)
+
+type IdempotencyRecordGatewayImpl struct {
+	repository interfaces.IdempotencyRecordRepository
+}
+
+func NewIdempotencyRecordGatewayImpl(repository interfaces.IdempotencyRecordRepository) *IdempotencyRecordGatewayImpl {
+	return &IdempotencyRecordGatewayImpl{
+		repository,
+	}
+}
+
+func (ig IdempotencyRecordGatewayImpl) CreateIdempotencyRecord(ctx context.Context, record entity.IdempotencyRecord) (entity.IdempotencyRecord, error) {
+	createRecordDTO := dto.CreateIdempotencyRecordDTO{
+		Principal:   record.Principal,
+		Key:         record.Key,
+		RequestHash: record.RequestHash,
+		ExpiresAt:   record.ExpiresAt,
+	}
+	createdRecord, err := ig.repository.CreateIdempotencyRecord(ctx, createRecordDTO)
+	if err != nil {
+		return entity.IdempotencyRecord{}, err
+	}
+	return createdRecord.ToEntity(), nil
+}
+
+func (ig IdempotencyRecordGatewayImpl) GetIdempotencyRecord(ctx context.Context, principal string, key string) (entity.IdempotencyRecord, error) {
+	record, err := ig.repository.GetIdempotencyRecord(ctx, principal, key)
+	if err != nil {
+		return entity.IdempotencyRecord{}, err
+	}
+	return record.ToEntity(), nil
+}
+
+func (ig IdempotencyRecordGatewayImpl) CompleteIdempotencyRecord(ctx context.Context, record entity.IdempotencyRecord) error {
+	return ig.repository.CompleteIdempotencyRecord(ctx, dto.CompleteIdempotencyRecordDTO{
+		Id:           record.Id,
+		StatusCode:   record.StatusCode,
+		ResponseBody: record.ResponseBody,
+		ContentType:  record.ContentType,
+	})
+}
+
+func (ig IdempotencyRecordGatewayImpl) DeleteIdempotencyRecord(ctx context.Context, id string) error {
+	return ig.repository.DeleteIdempotencyRecord(ctx, id)
+}
+
+func (ig IdempotencyRecordGatewayImpl) DeleteExpiredIdempotencyRecords(ctx context.Context) error {
+	return ig.repository.DeleteExpiredIdempotencyRecords(ctx)
+}
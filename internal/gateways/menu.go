@@ -0,0 +1,33 @@
+package gateways
+
+import (
+	"context"
+	dto "post-tech-challenge-10soat/internal/dto/product"
+	entity "post-tech-challenge-10soat/internal/entities"
+	interfaces "post-tech-challenge-10soat/internal/interfaces/repositories"
+)
+
+type MenuGatewayImpl struct {
+	repository interfaces.MenuRepository
+}
+
+func NewMenuGatewayImpl(repository interfaces.MenuRepository) *MenuGatewayImpl {
+	return &MenuGatewayImpl{
+		repository,
+	}
+}
+
+func (mg MenuGatewayImpl) ImportMenu(ctx context.Context, categories []string, items []entity.MenuItem) error {
+	products := make([]dto.ImportProductDTO, 0, len(items))
+	for _, item := range items {
+		products = append(products, dto.ImportProductDTO{
+			Sku:         item.Sku,
+			Name:        item.Name,
+			Description: item.Description,
+			Image:       item.Image,
+			Value:       item.Value,
+			Category:    item.Category,
+		})
+	}
+	return mg.repository.ImportMenu(ctx, categories, products)
+}
@@ -0,0 +1,35 @@
+package gateways
+
+import (
+	"context"
+	dto "post-tech-challenge-10soat/internal/dto/notification"
+	entity "post-tech-challenge-10soat/internal/entities"
+	interfaces "post-tech-challenge-10soat/internal/interfaces/repositories"
+)
+
+type NotificationDeliveryGatewayImpl struct {
+	repository interfaces.NotificationDeliveryRepository
+}
+
+func NewNotificationDeliveryGatewayImpl(repository interfaces.NotificationDeliveryRepository) *NotificationDeliveryGatewayImpl {
+	return &NotificationDeliveryGatewayImpl{
+		repository,
+	}
+}
+
+func (ng NotificationDeliveryGatewayImpl) CreateNotificationDelivery(ctx context.Context, delivery entity.NotificationDelivery) (entity.NotificationDelivery, error) {
+	createDeliveryDTO := dto.CreateNotificationDeliveryDTO{
+		OrderId:   delivery.OrderId,
+		ClientId:  delivery.ClientId,
+		Event:     string(delivery.Event),
+		Channel:   delivery.Channel,
+		Recipient: delivery.Recipient,
+		Status:    string(delivery.Status),
+		Error:     delivery.Error,
+	}
+	createdDelivery, err := ng.repository.CreateNotificationDelivery(ctx, createDeliveryDTO)
+	if err != nil {
+		return entity.NotificationDelivery{}, err
+	}
+	return createdDelivery.ToEntity(), nil
+}
@@ -17,12 +17,20 @@ func NewOrderGatewayImpl(repository interfaces.OrderRepository) *OrderGatewayImp
 	}
 }
 
-func (og OrderGatewayImpl) CreateOrder(ctx context.Context, order entity.Order) (entity.Order, error) {
+func (og OrderGatewayImpl) CreateOrder(ctx context.Context, order entity.Order, products []entity.OrderProduct) (entity.Order, error) {
 	createOrderDTO := dto.CreateOrderDTO{
 		Status:   string(order.Status),
 		ClientId: order.ClientId,
 		Total:    order.Total,
 	}
+	for _, product := range products {
+		createOrderDTO.Products = append(createOrderDTO.Products, dto.CreateOrderProduct{
+			ProductId:   product.ProductId,
+			Quantity:    product.Quantity,
+			Observation: product.Observation,
+			SubTotal:    product.SubTotal,
+		})
+	}
 	createdOrder, err := og.repository.CreateOrder(ctx, createOrderDTO)
 	if err != nil {
 		return entity.Order{}, err
@@ -58,10 +66,22 @@ func (og OrderGatewayImpl) GetOrderById(ctx context.Context, id string) (entity.
 	return order.ToEntity(), nil
 }
 
-func (og OrderGatewayImpl) UpdateOrderStatus(ctx context.Context, id string, status string) (entity.Order, error) {
-	order, err := og.repository.UpdateOrderStatus(ctx, id, status)
+func (og OrderGatewayImpl) UpdateOrderStatus(ctx context.Context, id string, status string, version int) (entity.Order, error) {
+	order, err := og.repository.UpdateOrderStatus(ctx, id, status, version)
 	if err != nil {
 		return entity.Order{}, err
 	}
 	return order.ToEntity(), nil
 }
+
+func (og OrderGatewayImpl) CountOrdersByStatus(ctx context.Context) (map[entity.OrderStatus]int, error) {
+	counts, err := og.repository.CountOrdersByStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+	countsRes := make(map[entity.OrderStatus]int, len(counts))
+	for status, count := range counts {
+		countsRes[entity.OrderStatus(status)] = count
+	}
+	return countsRes, nil
+}
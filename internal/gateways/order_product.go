@@ -27,7 +27,19 @@ func (og OrderProductGatewayImpl) CreateOrderProduct(ctx context.Context, orderP
 	}
 	createdOrderProduct, err := og.repository.CreateOrderProduct(ctx, orderProductDTO)
 	if err != nil {
-		return entity.OrderProduct{}, nil
+		return entity.OrderProduct{}, err
 	}
 	return createdOrderProduct.ToEntity(), nil
 }
+
+func (og OrderProductGatewayImpl) ListOrderProductsByOrderIds(ctx context.Context, orderIds []string) ([]entity.OrderProduct, error) {
+	var orderProductsRes []entity.OrderProduct
+	orderProducts, err := og.repository.ListOrderProductsByOrderIds(ctx, orderIds)
+	if err != nil {
+		return []entity.OrderProduct{}, err
+	}
+	for _, orderProduct := range orderProducts {
+		orderProductsRes = append(orderProductsRes, orderProduct.ToEntity())
+	}
+	return orderProductsRes, nil
+}
@@ -24,7 +24,7 @@ func (pg PaymentGatewayImpl) CreatePayment(ctx context.Context, payment entity.P
 	}
 	createdPayment, err := pg.repository.CreatePayment(ctx, createPaymentDTO)
 	if err != nil {
-		return entity.Payment{}, nil
+		return entity.Payment{}, err
 	}
 	return createdPayment.ToEntity(), nil
 }
@@ -29,6 +29,30 @@ func (pg ProductGatewayImpl) ListProducts(ctx context.Context, categoryId string
 	return productsRes, nil
 }
 
+func (pg ProductGatewayImpl) ListProductsByIds(ctx context.Context, ids []string) ([]entity.Product, error) {
+	var productsRes []entity.Product
+	products, err := pg.repository.ListProductsByIds(ctx, ids)
+	if err != nil {
+		return []entity.Product{}, err
+	}
+	for _, product := range products {
+		productsRes = append(productsRes, product.ToEntity())
+	}
+	return productsRes, nil
+}
+
+func (pg ProductGatewayImpl) ListProductsBySkus(ctx context.Context, skus []string) ([]entity.Product, error) {
+	var productsRes []entity.Product
+	products, err := pg.repository.ListProductsBySkus(ctx, skus)
+	if err != nil {
+		return []entity.Product{}, err
+	}
+	for _, product := range products {
+		productsRes = append(productsRes, product.ToEntity())
+	}
+	return productsRes, nil
+}
+
 func (pg ProductGatewayImpl) GetProductById(ctx context.Context, id string) (entity.Product, error) {
 	product, err := pg.repository.GetProductById(ctx, id)
 	if err != nil {
@@ -39,6 +63,7 @@ func (pg ProductGatewayImpl) GetProductById(ctx context.Context, id string) (ent
 
 func (pg ProductGatewayImpl) CreateProduct(ctx context.Context, product entity.Product) (entity.Product, error) {
 	createProductDTO := dto.CreateProductDTO{
+		Sku:         product.Sku,
 		Name:        product.Name,
 		Description: product.Description,
 		Image:       product.Image,
@@ -60,6 +85,7 @@ func (pg ProductGatewayImpl) UpdateProduct(ctx context.Context, product entity.P
 		Image:       product.Image,
 		Value:       product.Value,
 		CategoryId:  product.CategoryId,
+		Version:     product.Version,
 	}
 	updatedProduct, err := pg.repository.UpdateProduct(ctx, updateProductDTO)
 	if err != nil {
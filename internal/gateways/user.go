@@ -0,0 +1,39 @@
+package gateways
+
+import (
+	"context"
+	dto "post-tech-challenge-10soat/internal/dto/user"
+	entity "post-tech-challenge-10soat/internal/entities"
+	interfaces "post-tech-challenge-10soat/internal/interfaces/repositories"
+)
+
+type UserGatewayImpl struct {
+	repository interfaces.UserRepository
+}
+
+func NewUserGatewayImpl(repository interfaces.UserRepository) *UserGatewayImpl {
+	return &UserGatewayImpl{
+		repository,
+	}
+}
+
+func (ug UserGatewayImpl) CreateUser(ctx context.Context, user entity.User) (entity.User, error) {
+	storeUserDTO := dto.StoreUserDTO{
+		Username:     user.Username,
+		PasswordHash: user.PasswordHash,
+		Role:         string(user.Role),
+	}
+	createdUser, err := ug.repository.CreateUser(ctx, storeUserDTO)
+	if err != nil {
+		return entity.User{}, err
+	}
+	return createdUser.ToEntity(), nil
+}
+
+func (ug UserGatewayImpl) GetUserByUsername(ctx context.Context, username string) (entity.User, error) {
+	user, err := ug.repository.GetUserByUsername(ctx, username)
+	if err != nil {
+		return entity.User{}, err
+	}
+	return user.ToEntity(), nil
+}
@@ -1,84 +1,214 @@
 package config
 
 import (
-	"os"
-
-	"fmt"
+	"time"
 )
 
+// Every setting is read, in order of precedence, from the environment
+// variable in its env tag, from the file named by that variable with a
+// _FILE suffix, from the YAML file under its yaml tag and from its default.
+// Settings tagged secret are redacted when the configuration is printed.
 type (
 	Container struct {
-		App   *App
-		HTTP  *HTTP
-		DB    *DB
-		MONGO *MONGO
+		App          *App          `yaml:"app"`
+		HTTP         *HTTP         `yaml:"http"`
+		GRPC         *GRPC         `yaml:"grpc"`
+		DB           *DB           `yaml:"db"`
+		MONGO        *MONGO        `yaml:"mongo"`
+		NOTIFICATION *NOTIFICATION `yaml:"notification"`
+		AUTH         *AUTH         `yaml:"auth"`
+		TRACING      *TRACING      `yaml:"tracing"`
+		LOG          *LOG          `yaml:"log"`
 	}
 
 	App struct {
-		Name string
-		Env  string
+		Name string `yaml:"name" env:"APP_NAME" default:"post-tech-challenge-10soat" validate:"required"`
+		Env  string `yaml:"env" env:"APP_ENV" validate:"required"`
 	}
 
 	HTTP struct {
-		Env            string
-		URL            string
-		Port           string
-		AllowedOrigins string
+		// Env mirrors App.Env.
+		Env            string `yaml:"-"`
+		URL            string `yaml:"url" env:"HTTP_URL"`
+		Port           string `yaml:"port" env:"HTTP_PORT" default:"8080" validate:"required,numeric"`
+		AllowedOrigins string `yaml:"allowed_origins" env:"HTTP_ALLOWED_ORIGINS" default:"*" validate:"required"`
+		// TrustedProxies are the comma-separated IPs or CIDRs of the load
+		// balancers whose X-Forwarded-For is believed. With none the client
+		// IP is always the one of the connection.
+		TrustedProxies string        `yaml:"trusted_proxies" env:"HTTP_TRUSTED_PROXIES"`
+		IdempotencyTTL time.Duration `yaml:"idempotency_ttl" env:"HTTP_IDEMPOTENCY_TTL" default:"24h" validate:"gt=0"`
+		RateLimitStore string        `yaml:"rate_limit_store" env:"HTTP_RATE_LIMIT_STORE" default:"memory" validate:"oneof=memory postgres"`
+		RateLimits     RateLimits    `yaml:"rate_limits" env:"HTTP_RATE_LIMITS" default:"default:120/1m,auth:10/1m"`
+		// Timeouts of the HTTP server. ShutdownTimeout is the grace period
+		// given to in-flight requests when the process is asked to stop.
+		ReadTimeout     time.Duration `yaml:"read_timeout" env:"HTTP_READ_TIMEOUT" default:"15s" validate:"gt=0"`
+		WriteTimeout    time.Duration `yaml:"write_timeout" env:"HTTP_WRITE_TIMEOUT" default:"30s" validate:"gt=0"`
+		IdleTimeout     time.Duration `yaml:"idle_timeout" env:"HTTP_IDLE_TIMEOUT" default:"60s" validate:"gt=0"`
+		ShutdownTimeout time.Duration `yaml:"shutdown_timeout" env:"HTTP_SHUTDOWN_TIMEOUT" default:"30s" validate:"gt=0"`
+		// HealthCheckTimeout bounds each dependency checked by the readiness
+		// probe.
+		HealthCheckTimeout time.Duration `yaml:"health_check_timeout" env:"HTTP_HEALTH_CHECK_TIMEOUT" default:"2s" validate:"gt=0"`
+	}
+
+	GRPC struct {
+		// URL defaults to the HTTP one.
+		URL  string `yaml:"url" env:"GRPC_URL"`
+		Port string `yaml:"port" env:"GRPC_PORT" default:"9090" validate:"required,numeric"`
+	}
+
+	RateLimit struct {
+		Requests int
+		Period   time.Duration
 	}
 
 	DB struct {
-		Connection string
-		Host       string
-		Port       string
-		User       string
-		Password   string
-		Name       string
+		// DSN is a full postgres:// URL that replaces every connection
+		// setting below, TLS included.
+		DSN        string `yaml:"dsn" env:"DB_DSN" secret:"true" validate:"omitempty,startswith=postgres://|startswith=postgresql://"`
+		Connection string `yaml:"connection" env:"DB_CONNECTION" default:"postgres" validate:"required"`
+		Host       string `yaml:"host" env:"DB_HOST" validate:"required_without=DSN"`
+		Port       string `yaml:"port" env:"DB_PORT" default:"5432" validate:"required,numeric"`
+		User       string `yaml:"user" env:"DB_USER" validate:"required_without=DSN"`
+		Password   string `yaml:"password" env:"DB_PASSWORD" secret:"true"`
+		Name       string `yaml:"name" env:"DB_NAME" validate:"required_without=DSN"`
+		// TLS, with the sslmode values of libpq. The certificates are paths
+		// to PEM files.
+		SslMode        string        `yaml:"ssl_mode" env:"DB_SSL_MODE" default:"disable" validate:"oneof=disable allow prefer require verify-ca verify-full"`
+		SslRootCert    string        `yaml:"ssl_root_cert" env:"DB_SSL_ROOT_CERT" validate:"omitempty,file"`
+		SslCert        string        `yaml:"ssl_cert" env:"DB_SSL_CERT" validate:"omitempty,file,required_with=SslKey"`
+		SslKey         string        `yaml:"ssl_key" env:"DB_SSL_KEY" validate:"omitempty,file,required_with=SslCert"`
+		ConnectTimeout time.Duration `yaml:"connect_timeout" env:"DB_CONNECT_TIMEOUT" default:"10s" validate:"gt=0"`
+		// Retries of the first connection, waiting a backoff that doubles
+		// up to the max, so the API outlives a database starting late.
+		ConnectRetries         int           `yaml:"connect_retries" env:"DB_CONNECT_RETRIES" default:"10" validate:"min=0"`
+		ConnectRetryBackoff    time.Duration `yaml:"connect_retry_backoff" env:"DB_CONNECT_RETRY_BACKOFF" default:"500ms" validate:"gt=0,ltefield=ConnectRetryMaxBackoff"`
+		ConnectRetryMaxBackoff time.Duration `yaml:"connect_retry_max_backoff" env:"DB_CONNECT_RETRY_MAX_BACKOFF" default:"30s" validate:"gt=0"`
+		// Size and recycling of the connection pool.
+		MaxConns          int           `yaml:"max_conns" env:"DB_MAX_CONNS" default:"10" validate:"min=1"`
+		MinConns          int           `yaml:"min_conns" env:"DB_MIN_CONNS" default:"0" validate:"min=0,ltefield=MaxConns"`
+		MaxConnLifetime   time.Duration `yaml:"max_conn_lifetime" env:"DB_MAX_CONN_LIFETIME" default:"1h" validate:"gt=0"`
+		MaxConnIdleTime   time.Duration `yaml:"max_conn_idle_time" env:"DB_MAX_CONN_IDLE_TIME" default:"30m" validate:"gt=0"`
+		HealthCheckPeriod time.Duration `yaml:"health_check_period" env:"DB_HEALTH_CHECK_PERIOD" default:"1m" validate:"gt=0"`
+		// Replica takes the list and report queries. DB_REPLICA_HOST reuses
+		// every other setting of the primary, DB_REPLICA_DSN replaces them.
+		ReplicaHost string `yaml:"replica_host" env:"DB_REPLICA_HOST" validate:"excluded_with=DSN"`
+		ReplicaPort string `yaml:"replica_port" env:"DB_REPLICA_PORT" validate:"omitempty,numeric"`
+		ReplicaDSN  string `yaml:"replica_dsn" env:"DB_REPLICA_DSN" secret:"true" validate:"omitempty,startswith=postgres://|startswith=postgresql://"`
+		// AutoMigrate applies pending Postgres and Mongo migrations when
+		// serving. The -auto-migrate flag of serve overrides it.
+		AutoMigrate bool `yaml:"auto_migrate" env:"DB_AUTO_MIGRATE" default:"true"`
 	}
 
 	MONGO struct {
-		Connection string
-		Host       string
-		Port       string
-		User       string
-		Password   string
-		Name       string
+		// URI is a full mongodb:// or mongodb+srv:// URI that replaces every
+		// connection setting below, TLS included.
+		URI string `yaml:"uri" env:"MONGO_URI" secret:"true" validate:"omitempty,startswith=mongodb://|startswith=mongodb+srv://"`
+		// Connection is the scheme, mongodb or mongodb+srv. With mongodb+srv
+		// the port is not used.
+		Connection string `yaml:"connection" env:"MONGO_CONNECTION" default:"mongodb" validate:"oneof=mongodb mongodb+srv"`
+		Host       string `yaml:"host" env:"MONGO_HOST" validate:"required_without=URI"`
+		Port       string `yaml:"port" env:"MONGO_PORT" default:"27017" validate:"required,numeric"`
+		User       string `yaml:"user" env:"MONGO_USER"`
+		Password   string `yaml:"password" env:"MONGO_PASSWORD" secret:"true"`
+		// AuthSource only applies to the SCRAM mechanisms. An empty
+		// AuthMechanism lets the server pick one.
+		AuthSource    string `yaml:"auth_source" env:"MONGO_AUTH_SOURCE" default:"admin"`
+		AuthMechanism string `yaml:"auth_mechanism" env:"MONGO_AUTH_MECHANISM" validate:"omitempty,oneof=SCRAM-SHA-1 SCRAM-SHA-256 MONGODB-X509 MONGODB-AWS PLAIN GSSAPI"`
+		ReplicaSet    string `yaml:"replica_set" env:"MONGO_REPLICA_SET"`
+		// TLS, with the CA and the client certificate and key as paths to
+		// PEM files.
+		TLS                   bool   `yaml:"tls" env:"MONGO_TLS" default:"false"`
+		TLSCAFile             string `yaml:"tls_ca_file" env:"MONGO_TLS_CA_FILE" validate:"omitempty,file"`
+		TLSCertificateKeyFile string `yaml:"tls_certificate_key_file" env:"MONGO_TLS_CERTIFICATE_KEY_FILE" validate:"omitempty,file"`
+		// MONGO_NAME is still read when MONGO_DB is not set.
+		Name string `yaml:"name" env:"MONGO_DB,MONGO_NAME" validate:"required"`
+		// Size of the connection pool and how long to wait for a server.
+		MaxPoolSize    int           `yaml:"max_pool_size" env:"MONGO_MAX_POOL_SIZE" default:"100" validate:"min=1"`
+		MinPoolSize    int           `yaml:"min_pool_size" env:"MONGO_MIN_POOL_SIZE" default:"0" validate:"min=0,ltefield=MaxPoolSize"`
+		ConnectTimeout time.Duration `yaml:"connect_timeout" env:"MONGO_CONNECT_TIMEOUT" default:"10s" validate:"gt=0"`
+		// Retries of the first connection, as in DB.
+		ConnectRetries         int           `yaml:"connect_retries" env:"MONGO_CONNECT_RETRIES" default:"10" validate:"min=0"`
+		ConnectRetryBackoff    time.Duration `yaml:"connect_retry_backoff" env:"MONGO_CONNECT_RETRY_BACKOFF" default:"500ms" validate:"gt=0,ltefield=ConnectRetryMaxBackoff"`
+		ConnectRetryMaxBackoff time.Duration `yaml:"connect_retry_max_backoff" env:"MONGO_CONNECT_RETRY_MAX_BACKOFF" default:"30s" validate:"gt=0"`
+	}
+
+	NOTIFICATION struct {
+		Channels        string `yaml:"channels" env:"NOTIFICATION_CHANNELS" default:"log"`
+		DefaultLanguage string `yaml:"default_language" env:"NOTIFICATION_DEFAULT_LANGUAGE" default:"pt-BR"`
+		SmtpHost        string `yaml:"smtp_host" env:"NOTIFICATION_SMTP_HOST"`
+		SmtpPort        string `yaml:"smtp_port" env:"NOTIFICATION_SMTP_PORT" default:"25" validate:"omitempty,numeric"`
+		SmtpUser        string `yaml:"smtp_user" env:"NOTIFICATION_SMTP_USER"`
+		SmtpPassword    string `yaml:"smtp_password" env:"NOTIFICATION_SMTP_PASSWORD" secret:"true"`
+		SmtpFrom        string `yaml:"smtp_from" env:"NOTIFICATION_SMTP_FROM"`
+		// Webhook URLs usually carry a token in the path or query.
+		WebhookUrl string `yaml:"webhook_url" env:"NOTIFICATION_WEBHOOK_URL" secret:"true" validate:"omitempty,url"`
+		// Timeout bounds each notification, since they outlive the request that triggered them.
+		Timeout time.Duration `yaml:"timeout" env:"NOTIFICATION_TIMEOUT" default:"30s" validate:"gt=0"`
+	}
+
+	AUTH struct {
+		JwtKeyId string `yaml:"jwt_key_id" env:"AUTH_JWT_KEY_ID" default:"default" validate:"required"`
+		// JwtSecret signs HS256 tokens, which need a key at least as long as
+		// the 32 byte hash.
+		JwtSecret       string `yaml:"jwt_secret" env:"AUTH_JWT_SECRET" secret:"true" validate:"required,min=32"`
+		JwtPreviousKeys Keys   `yaml:"jwt_previous_keys" env:"AUTH_JWT_PREVIOUS_KEYS" secret:"true"`
+		// JwtIssuer mirrors App.Name.
+		JwtIssuer                  string        `yaml:"-"`
+		StaffSessionTTL            time.Duration `yaml:"staff_session_ttl" env:"AUTH_STAFF_SESSION_TTL" default:"8h" validate:"gt=0"`
+		CustomerSessionTTL         time.Duration `yaml:"customer_session_ttl" env:"AUTH_CUSTOMER_SESSION_TTL" default:"15m" validate:"gt=0"`
+		IdentificationCodeTTL      time.Duration `yaml:"identification_code_ttl" env:"AUTH_IDENTIFICATION_CODE_TTL" default:"5m" validate:"gt=0"`
+		IdentificationCodeCooldown time.Duration `yaml:"identification_code_cooldown" env:"AUTH_IDENTIFICATION_CODE_COOLDOWN" default:"1m" validate:"gt=0"`
+		IdentificationMaxAttempts  int           `yaml:"identification_max_attempts" env:"AUTH_IDENTIFICATION_MAX_ATTEMPTS" default:"5" validate:"min=1"`
+		BootstrapAdminUsername     string        `yaml:"bootstrap_admin_username" env:"AUTH_BOOTSTRAP_ADMIN_USERNAME"`
+		BootstrapAdminPassword     string        `yaml:"bootstrap_admin_password" env:"AUTH_BOOTSTRAP_ADMIN_PASSWORD" secret:"true"`
 	}
-)
 
-func New() (*Container, error) {
-	if os.Getenv("APP_ENV") == "" {
-		return nil, fmt.Errorf("APP_ENV is not set")
+	TRACING struct {
+		// Exporter is none, otlp or stdout.
+		Exporter    string  `yaml:"exporter" env:"TRACING_EXPORTER" default:"none" validate:"oneof=none otlp stdout"`
+		SampleRatio float64 `yaml:"sample_ratio" env:"TRACING_SAMPLE_RATIO" default:"1" validate:"min=0,max=1"`
 	}
-	app := &App{
-		Name: os.Getenv("APP_NAME"),
-		Env:  os.Getenv("APP_ENV"),
+
+	LOG struct {
+		// Level is the initial one, it can be changed at runtime through
+		// /v1/admin/log-level.
+		Level   string  `yaml:"level" env:"LOG_LEVEL" default:"info" validate:"oneof=debug info warn error"`
+		Format  string  `yaml:"format" env:"LOG_FORMAT" default:"text" validate:"oneof=text json"`
+		Outputs Outputs `yaml:"outputs" env:"LOG_OUTPUTS" default:"stderr"`
+		// Rotation of the file output, with sizes in megabytes and ages in
+		// days.
+		File           string `yaml:"file" env:"LOG_FILE" default:"log/app.log" validate:"required"`
+		FileMaxSize    int    `yaml:"file_max_size" env:"LOG_FILE_MAX_SIZE" default:"100" validate:"min=1"`
+		FileMaxBackups int    `yaml:"file_max_backups" env:"LOG_FILE_MAX_BACKUPS" default:"3" validate:"min=0"`
+		FileMaxAge     int    `yaml:"file_max_age" env:"LOG_FILE_MAX_AGE" default:"28" validate:"min=0"`
+		FileCompress   bool   `yaml:"file_compress" env:"LOG_FILE_COMPRESS" default:"true"`
+	}
+)
+
+// New loads the configuration from the optional YAML file at path, when not
+// empty, and from the environment, and validates it.
+func New(path string) (*Container, error) {
+	container := &Container{
+		App:          &App{},
+		HTTP:         &HTTP{},
+		GRPC:         &GRPC{},
+		DB:           &DB{},
+		MONGO:        &MONGO{},
+		NOTIFICATION: &NOTIFICATION{},
+		AUTH:         &AUTH{},
+		TRACING:      &TRACING{},
+		LOG:          &LOG{},
 	}
-	http := &HTTP{
-		Env:            os.Getenv("APP_ENV"),
-		URL:            os.Getenv("HTTP_URL"),
-		Port:           os.Getenv("HTTP_PORT"),
-		AllowedOrigins: os.Getenv("HTTP_ALLOWED_ORIGINS"),
+	if err := load(container, path); err != nil {
+		return nil, err
 	}
-	db := &DB{
-		Connection: os.Getenv("DB_CONNECTION"),
-		Host:       os.Getenv("DB_HOST"),
-		Port:       os.Getenv("DB_PORT"),
-		User:       os.Getenv("DB_USER"),
-		Password:   os.Getenv("DB_PASSWORD"),
-		Name:       os.Getenv("DB_NAME"),
+	container.HTTP.Env = container.App.Env
+	if container.GRPC.URL == "" {
+		container.GRPC.URL = container.HTTP.URL
 	}
-	mongo := &MONGO{
-		Connection: os.Getenv("MONGO_CONNECTION"),
-		Host:       os.Getenv("MONGO_HOST"),
-		Port:       os.Getenv("MONGO_PORT"),
-		User:       os.Getenv("MONGO_USER"),
-		Password:   os.Getenv("MONGO_PASSWORD"),
-		Name:       os.Getenv("MONGO_NAME"),
+	container.AUTH.JwtIssuer = container.App.Name
+	if err := validate(container); err != nil {
+		return nil, err
 	}
-	return &Container{
-		app,
-		http,
-		db,
-		mongo,
-	}, nil
+	return container, nil
 }
@@ -0,0 +1,258 @@
+package config
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"gopkg.in/yaml.v3"
+)
+
+const redacted = "****"
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// load fills every section with the defaults, then the YAML file and then
+// the environment, each one overriding only the settings it has.
+func load(container *Container, path string) error {
+	sections := reflect.ValueOf(container).Elem()
+	for i := 0; i < sections.NumField(); i++ {
+		if err := eachSetting(sections.Field(i).Elem(), func(field reflect.Value, tag reflect.StructTag) error {
+			value, ok := tag.Lookup("default")
+			if !ok {
+				return nil
+			}
+			return set(field, envNames(tag)[0], value)
+		}); err != nil {
+			return err
+		}
+	}
+	if path != "" {
+		file, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read config file - %w", err)
+		}
+		if err := yaml.Unmarshal(file, container); err != nil {
+			return fmt.Errorf("failed to parse config file %s - %w", path, err)
+		}
+	}
+	for i := 0; i < sections.NumField(); i++ {
+		if err := eachSetting(sections.Field(i).Elem(), func(field reflect.Value, tag reflect.StructTag) error {
+			for _, name := range envNames(tag) {
+				value, ok, err := lookupEnv(name)
+				if err != nil {
+					return err
+				}
+				if ok {
+					return set(field, name, value)
+				}
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lookupEnv reads the variable or, when it is empty, the file named by the
+// variable with the _FILE suffix, as mounted by Docker and Kubernetes
+// secrets. The trailing line break most editors add to the file is dropped.
+func lookupEnv(name string) (string, bool, error) {
+	if value := os.Getenv(name); value != "" {
+		return value, true, nil
+	}
+	path := os.Getenv(name + "_FILE")
+	if path == "" {
+		return "", false, nil
+	}
+	value, err := os.ReadFile(path)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to read %s_FILE - %w", name, err)
+	}
+	return strings.TrimRight(string(value), "\r\n"), true, nil
+}
+
+// eachSetting calls fn with every field of the section read from the
+// environment.
+func eachSetting(section reflect.Value, fn func(field reflect.Value, tag reflect.StructTag) error) error {
+	for i := 0; i < section.NumField(); i++ {
+		tag := section.Type().Field(i).Tag
+		if _, ok := tag.Lookup("env"); !ok {
+			continue
+		}
+		if err := fn(section.Field(i), tag); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func envNames(tag reflect.StructTag) []string {
+	return strings.Split(tag.Get("env"), ",")
+}
+
+func set(field reflect.Value, name string, value string) error {
+	if unmarshaler, ok := field.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		if err := unmarshaler.UnmarshalText([]byte(value)); err != nil {
+			return fmt.Errorf("%s %w", name, err)
+		}
+		return nil
+	}
+	if field.Type() == durationType {
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("%s is not a valid duration: %w", name, err)
+		}
+		field.SetInt(int64(duration))
+		return nil
+	}
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Int:
+		number, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("%s is not a valid integer: %w", name, err)
+		}
+		field.SetInt(int64(number))
+	case reflect.Float64:
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("%s is not a valid number: %w", name, err)
+		}
+		field.SetFloat(number)
+	case reflect.Bool:
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("%s is not a valid boolean: %w", name, err)
+		}
+		field.SetBool(enabled)
+	default:
+		return fmt.Errorf("%s has an unsupported type %s", name, field.Type())
+	}
+	return nil
+}
+
+// validate checks the validate tags and reports every invalid setting at
+// once, named by its environment variable.
+func validate(container *Container) error {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		if _, ok := field.Tag.Lookup("env"); ok {
+			return envNames(field.Tag)[0]
+		}
+		return field.Name
+	})
+	err := validate.Struct(container)
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return err
+	}
+	errs := make([]error, 0, len(validationErrors))
+	for _, fieldError := range validationErrors {
+		errs = append(errs, fmt.Errorf("%s %s", fieldError.Field(), describe(container, fieldError)))
+	}
+	return errors.Join(errs...)
+}
+
+func describe(container *Container, fieldError validator.FieldError) string {
+	switch fieldError.Tag() {
+	case "required", "required_without":
+		return "is not set"
+	case "required_with":
+		return "must be set along with " + envNameOf(container, fieldError.StructNamespace(), fieldError.Param())
+	case "excluded_with":
+		return "cannot be set along with " + envNameOf(container, fieldError.StructNamespace(), fieldError.Param())
+	case "file":
+		return "must be an existing file"
+	case "numeric":
+		return "must be a number"
+	case "url":
+		return "must be a valid URL"
+	case "oneof":
+		return "must be one of " + strings.ReplaceAll(fieldError.Param(), " ", ", ")
+	case "gt":
+		return "must be greater than " + fieldError.Param()
+	case "min":
+		if fieldError.Kind() == reflect.String {
+			return "must be at least " + fieldError.Param() + " characters long"
+		}
+		return "must be at least " + fieldError.Param()
+	case "max":
+		return "must be at most " + fieldError.Param()
+	case "startswith=postgres://|startswith=postgresql://":
+		return "must be a postgres:// URL"
+	case "startswith=mongodb://|startswith=mongodb+srv://":
+		return "must be a mongodb:// or mongodb+srv:// URI"
+	case "ltefield":
+		return "must not be greater than " + envNameOf(container, fieldError.StructNamespace(), fieldError.Param())
+	default:
+		return "is invalid"
+	}
+}
+
+// envNameOf finds the variable of a sibling of the field at namespace, such
+// as "Container.DB.MinConns", to name it in messages.
+func envNameOf(container *Container, namespace string, sibling string) string {
+	parts := strings.Split(namespace, ".")
+	if len(parts) != 3 {
+		return sibling
+	}
+	section, ok := reflect.TypeOf(container).Elem().FieldByName(parts[1])
+	if !ok {
+		return sibling
+	}
+	field, ok := section.Type.Elem().FieldByName(sibling)
+	if !ok {
+		return sibling
+	}
+	return envNames(field.Tag)[0]
+}
+
+// Print writes the configuration as YAML, in the layout of the config file,
+// with every secret replaced by asterisks.
+func Print(w io.Writer, container *Container) error {
+	printed := &Container{}
+	sections := reflect.ValueOf(container).Elem()
+	printedSections := reflect.ValueOf(printed).Elem()
+	for i := 0; i < sections.NumField(); i++ {
+		section := reflect.New(sections.Field(i).Elem().Type())
+		section.Elem().Set(sections.Field(i).Elem())
+		redact(section.Elem())
+		printedSections.Field(i).Set(section)
+	}
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(printed); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+func redact(section reflect.Value) {
+	for i := 0; i < section.NumField(); i++ {
+		field := section.Field(i)
+		if section.Type().Field(i).Tag.Get("secret") != "true" || field.IsZero() {
+			continue
+		}
+		switch field.Kind() {
+		case reflect.String:
+			field.SetString(redacted)
+		case reflect.Map:
+			// The ids stay visible, only the values are secret.
+			secrets := reflect.MakeMapWithSize(field.Type(), field.Len())
+			for _, key := range field.MapKeys() {
+				secrets.SetMapIndex(key, reflect.ValueOf(redacted))
+			}
+			field.Set(secrets)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RateLimits holds the limit of each group, written as a comma separated
+// list of "group:requests/period" entries, such as "default:120/1m,auth:10/1m".
+// A group set to 0 requests is not limited.
+type RateLimits map[string]RateLimit
+
+func (r *RateLimits) UnmarshalText(text []byte) error {
+	limits := RateLimits{}
+	for _, entry := range strings.Split(string(text), ",") {
+		group, rate, found := strings.Cut(strings.TrimSpace(entry), ":")
+		requests, period, hasPeriod := strings.Cut(rate, "/")
+		if !found || group == "" || !hasPeriod {
+			return fmt.Errorf("must be a list of group:requests/period entries")
+		}
+		count, err := strconv.Atoi(requests)
+		if err != nil || count < 0 {
+			return fmt.Errorf("has an invalid request count for %s", group)
+		}
+		duration, err := time.ParseDuration(period)
+		if err != nil || duration <= 0 {
+			return fmt.Errorf("has an invalid period for %s", group)
+		}
+		limits[group] = RateLimit{Requests: count, Period: duration}
+	}
+	*r = limits
+	return nil
+}
+
+func (r RateLimits) MarshalText() ([]byte, error) {
+	entries := make([]string, 0, len(r))
+	for _, group := range sortedKeys(r) {
+		entries = append(entries, fmt.Sprintf("%s:%d/%s", group, r[group].Requests, r[group].Period))
+	}
+	return []byte(strings.Join(entries, ",")), nil
+}
+
+// Keys holds signing keys by id, written as a comma separated list of
+// "id:secret" pairs. It is used to keep accepting tokens signed with
+// rotated keys until they expire.
+type Keys map[string]string
+
+func (k *Keys) UnmarshalText(text []byte) error {
+	keys := Keys{}
+	if strings.TrimSpace(string(text)) == "" {
+		*k = keys
+		return nil
+	}
+	for _, pair := range strings.Split(string(text), ",") {
+		id, secret, found := strings.Cut(strings.TrimSpace(pair), ":")
+		if !found || id == "" || secret == "" {
+			return fmt.Errorf("must be a list of id:secret pairs")
+		}
+		keys[id] = secret
+	}
+	*k = keys
+	return nil
+}
+
+func (k Keys) MarshalText() ([]byte, error) {
+	pairs := make([]string, 0, len(k))
+	for _, id := range sortedKeys(k) {
+		pairs = append(pairs, id+":"+k[id])
+	}
+	return []byte(strings.Join(pairs, ",")), nil
+}
+
+// Outputs lists where logs are written, as a comma separated list of stdout,
+// stderr and file, such as "stderr,file".
+type Outputs []string
+
+func (o *Outputs) UnmarshalText(text []byte) error {
+	outputs := Outputs{}
+	for _, output := range strings.Split(string(text), ",") {
+		output = strings.TrimSpace(output)
+		switch output {
+		case "stdout", "stderr", "file":
+			outputs = append(outputs, output)
+		case "":
+		default:
+			return fmt.Errorf("has an unknown output %s, must be stdout, stderr or file", output)
+		}
+	}
+	if len(outputs) == 0 {
+		return fmt.Errorf("must list at least one output")
+	}
+	*o = outputs
+	return nil
+}
+
+func (o Outputs) MarshalText() ([]byte, error) {
+	return []byte(strings.Join(o, ",")), nil
+}
+
+// Has reports whether the output is listed.
+func (o Outputs) Has(output string) bool {
+	for _, listed := range o {
+		if listed == output {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys[V any](values map[string]V) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,41 @@
+package di
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	dto "post-tech-challenge-10soat/internal/dto/user"
+	entity "post-tech-challenge-10soat/internal/entities"
+	"post-tech-challenge-10soat/internal/external/postgres"
+	repository "post-tech-challenge-10soat/internal/external/postgres/repositories"
+	"post-tech-challenge-10soat/internal/gateways"
+	"post-tech-challenge-10soat/internal/infrastructure/config"
+	"post-tech-challenge-10soat/internal/usecases/user"
+)
+
+// BootstrapAdmin creates the admin user from AUTH_BOOTSTRAP_ADMIN_USERNAME and
+// AUTH_BOOTSTRAP_ADMIN_PASSWORD, so a fresh environment can issue its first
+// tokens. An existing user with the same username is left untouched.
+func BootstrapAdmin(ctx context.Context, config *config.AUTH, db *postgres.DB) error {
+	if config.BootstrapAdminUsername == "" || config.BootstrapAdminPassword == "" {
+		return nil
+	}
+	createUser := user.NewCreateUserUseCaseImpl(
+		gateways.NewUserGatewayImpl(
+			repository.NewUserRepositoryImpl(db),
+		),
+	)
+	_, err := createUser.Execute(ctx, dto.CreateUserDTO{
+		Username: config.BootstrapAdminUsername,
+		Password: config.BootstrapAdminPassword,
+		Role:     string(entity.RoleAdmin),
+	})
+	if errors.Is(err, entity.ErrConflictingData) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	slog.Info("Created bootstrap admin user", "username", config.BootstrapAdminUsername)
+	return nil
+}
@@ -1,33 +1,75 @@
 package di
 
 import (
+	"log/slog"
 	"post-tech-challenge-10soat/internal/controllers"
+	"post-tech-challenge-10soat/internal/delivery/graph"
 	"post-tech-challenge-10soat/internal/delivery/http/handler"
+	"post-tech-challenge-10soat/internal/delivery/rpc"
+	entity "post-tech-challenge-10soat/internal/entities"
+	"post-tech-challenge-10soat/internal/external/events"
+	healthcheck "post-tech-challenge-10soat/internal/external/health"
+	"post-tech-challenge-10soat/internal/external/metrics"
 	"post-tech-challenge-10soat/internal/external/mongo"
 	repositorymongo "post-tech-challenge-10soat/internal/external/mongo/repositorymongo"
+	notifier "post-tech-challenge-10soat/internal/external/notification"
 	"post-tech-challenge-10soat/internal/external/postgres"
 	repository "post-tech-challenge-10soat/internal/external/postgres/repositories"
+	"post-tech-challenge-10soat/internal/external/ratelimit"
+	"post-tech-challenge-10soat/internal/external/token"
 	"post-tech-challenge-10soat/internal/gateways"
 	"post-tech-challenge-10soat/internal/infrastructure/config"
 	"post-tech-challenge-10soat/internal/infrastructure/logger"
+	interfaces "post-tech-challenge-10soat/internal/interfaces/gateways"
+	"post-tech-challenge-10soat/internal/usecases/apikey"
 	"post-tech-challenge-10soat/internal/usecases/client"
+	"post-tech-challenge-10soat/internal/usecases/health"
+	"post-tech-challenge-10soat/internal/usecases/idempotency"
+	"post-tech-challenge-10soat/internal/usecases/menu"
+	usecasemetrics "post-tech-challenge-10soat/internal/usecases/metrics"
+	"post-tech-challenge-10soat/internal/usecases/notification"
 	"post-tech-challenge-10soat/internal/usecases/order"
 	"post-tech-challenge-10soat/internal/usecases/product"
+	"post-tech-challenge-10soat/internal/usecases/user"
+	"post-tech-challenge-10soat/internal/utils"
+
+	"google.golang.org/grpc"
 )
 
-func Setup(config *config.App, db *postgres.DB, mongo *mongo.MONGO) (
-	handler.HealthHandler,
-	handler.ClientHandler,
-	handler.ProductHandler,
-	handler.OrderHandler) {
-	logger.Set(config)
+// Handlers are the HTTP handlers and middlewares, the GraphQL handler and
+// the gRPC server wired by Setup.
+type Handlers struct {
+	Health      handler.HealthHandler
+	Client      handler.ClientHandler
+	Product     handler.ProductHandler
+	Order       handler.OrderHandler
+	User        handler.UserHandler
+	ApiKey      handler.ApiKeyHandler
+	Session     handler.SessionMiddleware
+	ApiKeyAuth  handler.ApiKeyMiddleware
+	Idempotency handler.IdempotencyMiddleware
+	RateLimit   handler.RateLimitMiddleware
+	Metrics     handler.MetricsHandler
+	LogLevel    handler.LogLevelHandler
+	Menu        handler.MenuHandler
+	Graph       graph.Handler
+	Grpc        *grpc.Server
+}
 
+func Setup(config *config.Container, db *postgres.DB, mongo *mongo.MONGO, background *utils.Background) (*Handlers, error) {
 	// Repositories
 	clientRepo := repositorymongo.NewClientMongoRepositoryImpl(mongo.Database)
 	productRepo := repository.NewProductRepositoryImpl(db)
 	categoryRepo := repository.NewCategoryRepositoryImpl(db)
 	orderRepo := repository.NewOrderRepositoryImpl(db)
 	orderProductRepo := repository.NewOrderProductRepositoryImpl(db)
+	notificationDeliveryRepo := repository.NewNotificationDeliveryRepositoryImpl(db)
+	clientIdentificationRepo := repository.NewClientIdentificationRepositoryImpl(db)
+	clientConsentRepo := repository.NewClientConsentRepositoryImpl(db)
+	userRepo := repository.NewUserRepositoryImpl(db)
+	apiKeyRepo := repository.NewApiKeyRepositoryImpl(db)
+	idempotencyRecordRepo := repository.NewIdempotencyRecordRepositoryImpl(db)
+	menuRepo := repository.NewMenuRepositoryImpl(db)
 	// paymentRepo := repository.NewPaymentRepositoryImpl(db)
 
 	// Gateways
@@ -46,20 +88,147 @@ func Setup(config *config.App, db *postgres.DB, mongo *mongo.MONGO) (
 	orderProductGateway := gateways.NewOrderProductGatewayImpl(
 		orderProductRepo,
 	)
+	notificationDeliveryGateway := gateways.NewNotificationDeliveryGatewayImpl(
+		notificationDeliveryRepo,
+	)
+	clientIdentificationGateway := gateways.NewClientIdentificationGatewayImpl(
+		clientIdentificationRepo,
+	)
+	clientConsentGateway := gateways.NewClientConsentGatewayImpl(
+		clientConsentRepo,
+	)
+	userGateway := gateways.NewUserGatewayImpl(
+		userRepo,
+	)
+	apiKeyGateway := gateways.NewApiKeyGatewayImpl(
+		apiKeyRepo,
+	)
+	idempotencyRecordGateway := gateways.NewIdempotencyRecordGatewayImpl(
+		idempotencyRecordRepo,
+	)
+	menuGateway := gateways.NewMenuGatewayImpl(
+		menuRepo,
+	)
+	tokenGateway := token.NewJwtTokenGatewayImpl(
+		config.AUTH.JwtKeyId,
+		config.AUTH.JwtSecret,
+		config.AUTH.JwtPreviousKeys,
+		config.AUTH.JwtIssuer,
+	)
+	orderEventGateway := events.NewMemoryOrderEventGatewayImpl()
+	metricsGateway := metrics.NewPrometheusMetricsGatewayImpl(db)
+	var rateLimitGateway interfaces.RateLimitGateway = ratelimit.NewMemoryRateLimitGatewayImpl()
+	if config.HTTP.RateLimitStore == "postgres" {
+		rateLimitGateway = ratelimit.NewPostgresRateLimitGatewayImpl(db)
+	}
+	postgresHealthCheckGateway, err := healthcheck.NewPostgresHealthCheckGatewayImpl(db)
+	if err != nil {
+		return nil, err
+	}
+	mongoHealthCheckGateway := healthcheck.NewMongoHealthCheckGatewayImpl(mongo)
+	healthCheckGateways := []interfaces.HealthCheckGateway{
+		postgresHealthCheckGateway,
+		mongoHealthCheckGateway,
+	}
+	if db.Replica() != nil {
+		healthCheckGateways = append(healthCheckGateways, healthcheck.NewPostgresReplicaHealthCheckGatewayImpl(db))
+	}
 	// paymentGateway := gateways.NewPaymentGatewayImpl(
 	// 	paymentRepo,
 	// )
 
+	// Notifiers
+	notifiers, err := notifier.New(config.NOTIFICATION)
+	if err != nil {
+		return nil, err
+	}
+	// Identification codes are only ever sent by email. Without the email
+	// channel their routes are left out, and the rest of the API serves.
+	emailNotifier, err := notifier.Find(notifiers, entity.NotificationChannelEmail)
+	identification := err == nil
+	if !identification {
+		slog.Warn("Client identification is disabled", "reason", err)
+	}
+	notifiers = notification.RequireConsent(notifiers, clientConsentGateway)
+
 	// Usecases
+	checkReadiness := health.NewCheckReadinessUseCaseImpl(
+		healthCheckGateways,
+		config.HTTP.HealthCheckTimeout,
+	)
 	getClientByCpf := client.NewGetClientByCpfUseCaseImpl(
 		clientGateway,
 	)
-	getClientById := client.NewGetClientByCpfUseCaseImpl(
+	getClientById := client.NewGetClientByIdUseCaseImpl(
+		clientGateway,
+	)
+	listClientsByIds := client.NewListClientsByIdsUseCaseImpl(
 		clientGateway,
 	)
 	createClient := client.NewCreateClientUsecaseImpl(
 		clientGateway,
 	)
+	var requestIdentificationCode client.RequestIdentificationCodeUseCase
+	var verifyIdentificationCode client.VerifyIdentificationCodeUseCase
+	if identification {
+		requestIdentificationCode = client.NewRequestIdentificationCodeUseCaseImpl(
+			clientGateway,
+			clientIdentificationGateway,
+			rateLimitGateway,
+			notification.NewConsentNotifier(
+				emailNotifier,
+				clientConsentGateway,
+			),
+			config.AUTH.IdentificationCodeTTL,
+			config.AUTH.IdentificationCodeCooldown,
+			config.AUTH.IdentificationMaxAttempts,
+			config.NOTIFICATION.DefaultLanguage,
+		)
+		verifyIdentificationCode = client.NewVerifyIdentificationCodeUseCaseImpl(
+			clientIdentificationGateway,
+			tokenGateway,
+			config.AUTH.CustomerSessionTTL,
+		)
+	}
+	listClientConsents := client.NewListClientConsentsUseCaseImpl(
+		clientConsentGateway,
+	)
+	grantClientConsent := client.NewGrantClientConsentUseCaseImpl(
+		clientConsentGateway,
+	)
+	revokeClientConsent := client.NewRevokeClientConsentUseCaseImpl(
+		clientConsentGateway,
+	)
+	createUser := user.NewCreateUserUseCaseImpl(
+		userGateway,
+	)
+	issueStaffToken := user.NewIssueStaffTokenUseCaseImpl(
+		userGateway,
+		tokenGateway,
+		config.AUTH.StaffSessionTTL,
+	)
+	createApiKey := apikey.NewCreateApiKeyUseCaseImpl(
+		apiKeyGateway,
+	)
+	listApiKeys := apikey.NewListApiKeysUseCaseImpl(
+		apiKeyGateway,
+	)
+	rotateApiKey := apikey.NewRotateApiKeyUseCaseImpl(
+		apiKeyGateway,
+	)
+	revokeApiKey := apikey.NewRevokeApiKeyUseCaseImpl(
+		apiKeyGateway,
+	)
+	authenticateApiKey := apikey.NewAuthenticateApiKeyUseCaseImpl(
+		apiKeyGateway,
+	)
+	beginIdempotentRequest := idempotency.NewBeginIdempotentRequestUseCaseImpl(
+		idempotencyRecordGateway,
+		config.HTTP.IdempotencyTTL,
+	)
+	finishIdempotentRequest := idempotency.NewFinishIdempotentRequestUseCaseImpl(
+		idempotencyRecordGateway,
+	)
 	createProduct := product.NewCreateProductUsecaseImpl(
 		productGateway,
 		categoryGateway,
@@ -75,49 +244,142 @@ func Setup(config *config.App, db *postgres.DB, mongo *mongo.MONGO) (
 		productGateway,
 		categoryGateway,
 	)
+	listProductsByIds := product.NewListProductsByIdsUseCaseImpl(
+		productGateway,
+		categoryGateway,
+	)
+	importMenu := menu.NewImportMenuUseCaseImpl(
+		productGateway,
+		categoryGateway,
+		menuGateway,
+	)
+	exportMenu := menu.NewExportMenuUseCaseImpl(
+		productGateway,
+		categoryGateway,
+	)
 	// paymentUseCase := payment.NewPaymentCheckoutUsecaseImpl(
 	// 	paymentGateway,
+	// 	metricsGateway,
 	// )
 	createOrder := order.NewCreateOrderUsecaseImpl(
 		productGateway,
 		clientGateway,
 		orderGateway,
-		orderProductGateway,
+		metricsGateway,
 	)
 	listOrders := order.NewListOrdersUseCaseImpl(
 		orderGateway,
 	)
+	listOrderItems := order.NewListOrderItemsUseCaseImpl(
+		orderProductGateway,
+	)
 	getOrderPaymentStatus := order.NewGetOrderPaymentStatusUseCaseImpl(
 		orderGateway,
 	)
+	watchOrderStatus := order.NewWatchOrderStatusUseCaseImpl(
+		orderGateway,
+		orderEventGateway,
+	)
+	notifyOrderStatus := notification.NewNotifyOrderStatusUseCaseImpl(
+		clientGateway,
+		notificationDeliveryGateway,
+		notifiers,
+		config.NOTIFICATION.DefaultLanguage,
+	)
 	updateOrderStatus := order.NewUpdateOrderStatusUseCaseImpl(
 		orderGateway,
+		orderEventGateway,
+		notifyOrderStatus,
+		metricsGateway,
+		config.NOTIFICATION.Timeout,
+		background,
+	)
+	refreshOrdersByStatus := usecasemetrics.NewRefreshOrdersByStatusUseCaseImpl(
+		orderGateway,
+		metricsGateway,
 	)
 
 	// Controllers
+	healthController := controllers.NewHealthController(
+		checkReadiness,
+	)
+	metricsController := controllers.NewMetricsController(
+		refreshOrdersByStatus,
+	)
 	clientController := controllers.NewClientController(
 		getClientByCpf,
 		getClientById,
+		listClientsByIds,
 		createClient,
+		requestIdentificationCode,
+		verifyIdentificationCode,
+		listClientConsents,
+		grantClientConsent,
+		revokeClientConsent,
+	)
+	userController := controllers.NewUserController(
+		createUser,
+		issueStaffToken,
+	)
+	apiKeyController := controllers.NewApiKeyController(
+		createApiKey,
+		listApiKeys,
+		rotateApiKey,
+		revokeApiKey,
+		authenticateApiKey,
+	)
+	idempotencyController := controllers.NewIdempotencyController(
+		beginIdempotentRequest,
+		finishIdempotentRequest,
 	)
 	productController := controllers.NewProductController(
 		createProduct,
 		deleteProduct,
 		updateProduct,
 		listProducts,
+		listProductsByIds,
+	)
+	menuController := controllers.NewMenuController(
+		importMenu,
+		exportMenu,
 	)
 	orderController := controllers.NewOrderController(
 		createOrder,
 		listOrders,
 		getOrderPaymentStatus,
 		updateOrderStatus,
+		listOrderItems,
+		watchOrderStatus,
 	)
 
 	// Handlers
-	healthHandler := handler.NewHealthHandler()
-	clientHandler := handler.NewClientHandler(clientController)
-	productHandler := handler.NewProductHandler(*productController)
-	orderHandler := handler.NewOrderHandler(*orderController)
+	graphHandler, err := graph.NewHandler(*productController, *orderController, clientController)
+	if err != nil {
+		return nil, err
+	}
+	return &Handlers{
+		Health:      handler.NewHealthHandler(healthController),
+		Client:      handler.NewClientHandler(clientController, identification),
+		Product:     handler.NewProductHandler(*productController),
+		Order:       handler.NewOrderHandler(*orderController),
+		User:        handler.NewUserHandler(userController),
+		ApiKey:      handler.NewApiKeyHandler(apiKeyController),
+		Session:     handler.NewSessionMiddleware(tokenGateway),
+		ApiKeyAuth:  handler.NewApiKeyMiddleware(apiKeyController),
+		Idempotency: handler.NewIdempotencyMiddleware(idempotencyController),
+		RateLimit:   handler.NewRateLimitMiddleware(rateLimitGateway, rateLimits(config.HTTP.RateLimits)),
+		Metrics:     handler.NewMetricsHandler(metricsController, metricsGateway, metricsGateway.Handler()),
+		LogLevel:    handler.NewLogLevelHandler(logger.NewLogLevelGatewayImpl()),
+		Menu:        handler.NewMenuHandler(menuController),
+		Graph:       graphHandler,
+		Grpc:        rpc.NewServer(*productController, *orderController, apiKeyController, tokenGateway),
+	}, nil
+}
 
-	return healthHandler, clientHandler, productHandler, orderHandler
+func rateLimits(limits map[string]config.RateLimit) map[string]entity.RateLimit {
+	rateLimits := make(map[string]entity.RateLimit, len(limits))
+	for group, limit := range limits {
+		rateLimits[group] = entity.RateLimit{Requests: limit.Requests, Period: limit.Period}
+	}
+	return rateLimits
 }
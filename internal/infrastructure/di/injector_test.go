@@ -0,0 +1,64 @@
+package di
+
+import (
+	"context"
+	"testing"
+
+	"post-tech-challenge-10soat/internal/external/mongo"
+	"post-tech-challenge-10soat/internal/external/postgres"
+	"post-tech-challenge-10soat/internal/infrastructure/config"
+	"post-tech-challenge-10soat/internal/utils"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	mongodriver "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// setup wires the dependencies over pools that only connect when a query
+// needs one, so no database has to be running.
+func setup(t *testing.T) (*Handlers, error) {
+	t.Setenv("APP_ENV", "development")
+	t.Setenv("DB_HOST", "127.0.0.1")
+	t.Setenv("DB_USER", "postgres")
+	t.Setenv("DB_NAME", "gopos")
+	t.Setenv("MONGO_HOST", "127.0.0.1")
+	t.Setenv("MONGO_DB", "postech")
+	t.Setenv("AUTH_JWT_SECRET", "local-secret-of-at-least-32-bytes")
+	conf, err := config.New("")
+	require.NoError(t, err)
+
+	pool, err := pgxpool.New(context.Background(), "postgres://postgres@127.0.0.1:5432/gopos")
+	require.NoError(t, err)
+	t.Cleanup(pool.Close)
+	client, err := mongodriver.Connect(context.Background(), options.Client().ApplyURI("mongodb://127.0.0.1:27017"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Disconnect(context.Background()) })
+
+	return Setup(conf, &postgres.DB{Pool: pool}, &mongo.MONGO{Client: client, Database: client.Database(conf.MONGO.Name)}, &utils.Background{})
+}
+
+func TestSetup_DefaultConfig(t *testing.T) {
+	// Act
+	handlers, err := setup(t)
+
+	// Assert
+	require.NoError(t, err)
+	assert.False(t, handlers.Client.IdentificationEnabled())
+	assert.NotNil(t, handlers.Grpc)
+}
+
+func TestSetup_EmailChannel(t *testing.T) {
+	// Setup
+	t.Setenv("NOTIFICATION_CHANNELS", "log,email")
+	t.Setenv("NOTIFICATION_SMTP_HOST", "smtp.internal")
+	t.Setenv("NOTIFICATION_SMTP_FROM", "no-reply@email.com")
+
+	// Act
+	handlers, err := setup(t)
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, handlers.Client.IdentificationEnabled())
+}
@@ -0,0 +1,34 @@
+package di
+
+import (
+	"post-tech-challenge-10soat/internal/controllers"
+	"post-tech-challenge-10soat/internal/external/postgres"
+	repository "post-tech-challenge-10soat/internal/external/postgres/repositories"
+	"post-tech-challenge-10soat/internal/gateways"
+	"post-tech-challenge-10soat/internal/usecases/menu"
+)
+
+// NewMenuController wires the menu import and export on their own, for the
+// menu command, which only needs Postgres.
+func NewMenuController(db *postgres.DB) controllers.MenuController {
+	productGateway := gateways.NewProductGatewayImpl(
+		repository.NewProductRepositoryImpl(db),
+	)
+	categoryGateway := gateways.NewCategoryGatewayImpl(
+		repository.NewCategoryRepositoryImpl(db),
+	)
+	menuGateway := gateways.NewMenuGatewayImpl(
+		repository.NewMenuRepositoryImpl(db),
+	)
+	return controllers.NewMenuController(
+		menu.NewImportMenuUseCaseImpl(
+			productGateway,
+			categoryGateway,
+			menuGateway,
+		),
+		menu.NewExportMenuUseCaseImpl(
+			productGateway,
+			categoryGateway,
+		),
+	)
+}
@@ -0,0 +1,49 @@
+package logger
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// level is shared by every output of the default logger, so changing it
+// takes effect on the next record without rebuilding the handlers.
+var level = new(slog.LevelVar)
+
+// Level returns the minimum level logged, such as "info".
+func Level() string {
+	return strings.ToLower(level.Level().String())
+}
+
+// SetLevel changes the minimum level logged to debug, info, warn or error.
+func SetLevel(name string) error {
+	switch name {
+	case "debug":
+		level.Set(slog.LevelDebug)
+	case "info":
+		level.Set(slog.LevelInfo)
+	case "warn":
+		level.Set(slog.LevelWarn)
+	case "error":
+		level.Set(slog.LevelError)
+	default:
+		return fmt.Errorf("unknown log level %s", name)
+	}
+	return nil
+}
+
+// LogLevelGatewayImpl exposes the level of the default logger to the
+// handlers that change it at runtime.
+type LogLevelGatewayImpl struct{}
+
+func NewLogLevelGatewayImpl() *LogLevelGatewayImpl {
+	return &LogLevelGatewayImpl{}
+}
+
+func (g *LogLevelGatewayImpl) Level() string {
+	return Level()
+}
+
+func (g *LogLevelGatewayImpl) SetLevel(level string) error {
+	return SetLevel(level)
+}
@@ -0,0 +1,98 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+)
+
+const mask = "****"
+
+// Personal data and credentials are masked wherever they show up in a
+// message or an attribute, such as a CPF in the path of the access log or
+// an email in an error returned by a repository. Tokens are masked before
+// the rest so their characters are never taken for a CPF.
+var redactions = []struct {
+	pattern     *regexp.Regexp
+	replacement string
+}{
+	{regexp.MustCompile(`(?i)\b(bearer|basic)\s+[A-Za-z0-9._~+/=-]+`), "$1 " + mask},
+	{regexp.MustCompile(`\beyJ[A-Za-z0-9_-]*\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`), mask},
+	{regexp.MustCompile(`\b(ptk_[0-9a-f]+_)[0-9a-f]+`), "${1}" + mask},
+	{regexp.MustCompile(`\b([A-Za-z0-9])[A-Za-z0-9._%+-]*@([A-Za-z0-9-]+(\.[A-Za-z0-9-]+)+)`), "${1}" + mask + "@$2"},
+	{regexp.MustCompile(`\b\d{3}\.?\d{3}\.?\d{3}-?\d{2}\b`), "***.***.***-**"},
+}
+
+// sensitiveKeys name attributes whose whole value is a credential.
+var sensitiveKeys = []string{"password", "secret", "token", "authorization", "cookie", "api_key", "apikey"}
+
+// redactHandler masks personal data and credentials in every record before
+// passing it on, so no output ever receives them.
+type redactHandler struct {
+	slog.Handler
+}
+
+func (h redactHandler) Handle(ctx context.Context, record slog.Record) error {
+	redacted := slog.NewRecord(record.Time, record.Level, redactString(record.Message), record.PC)
+	record.Attrs(func(attr slog.Attr) bool {
+		redacted.AddAttrs(redactAttr(attr))
+		return true
+	})
+	return h.Handler.Handle(ctx, redacted)
+}
+
+func (h redactHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return redactHandler{h.Handler.WithAttrs(redactAttrs(attrs))}
+}
+
+func (h redactHandler) WithGroup(name string) slog.Handler {
+	return redactHandler{h.Handler.WithGroup(name)}
+}
+
+func redactAttrs(attrs []slog.Attr) []slog.Attr {
+	redacted := make([]slog.Attr, len(attrs))
+	for i, attr := range attrs {
+		redacted[i] = redactAttr(attr)
+	}
+	return redacted
+}
+
+func redactAttr(attr slog.Attr) slog.Attr {
+	value := attr.Value.Resolve()
+	if isSensitiveKey(attr.Key) {
+		return slog.String(attr.Key, mask)
+	}
+	switch value.Kind() {
+	case slog.KindString:
+		return slog.String(attr.Key, redactString(value.String()))
+	case slog.KindGroup:
+		return slog.Attr{Key: attr.Key, Value: slog.GroupValue(redactAttrs(value.Group())...)}
+	case slog.KindAny:
+		// Errors and structs are only turned into text when they hold
+		// something to mask, the others keep the encoding of the handler.
+		text := fmt.Sprint(value.Any())
+		if redacted := redactString(text); redacted != text {
+			return slog.String(attr.Key, redacted)
+		}
+	}
+	return slog.Attr{Key: attr.Key, Value: value}
+}
+
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, sensitive := range sensitiveKeys {
+		if strings.Contains(key, sensitive) {
+			return true
+		}
+	}
+	return false
+}
+
+func redactString(text string) string {
+	for _, redaction := range redactions {
+		text = redaction.pattern.ReplaceAllString(text, redaction.replacement)
+	}
+	return text
+}
@@ -1,6 +1,7 @@
 package logger
 
 import (
+	"io"
 	"log/slog"
 	"os"
 	"post-tech-challenge-10soat/internal/infrastructure/config"
@@ -9,27 +10,44 @@ import (
 	"gopkg.in/natefinch/lumberjack.v2"
 )
 
-var logger *slog.Logger
+// Set makes the default logger write to every output in the configured
+// format, with the level held by the package so it can be changed at
+// runtime. Records are redacted before reaching any output.
+func Set(config *config.LOG) error {
+	if err := SetLevel(config.Level); err != nil {
+		return err
+	}
+	options := &slog.HandlerOptions{Level: level}
+	handlers := make([]slog.Handler, 0, len(config.Outputs))
+	for _, output := range config.Outputs {
+		handlers = append(handlers, newHandler(config.Format, writer(config, output), options))
+	}
+	slog.SetDefault(slog.New(
+		contextHandler{redactHandler{slogmulti.Fanout(handlers...)}},
+	))
+	return nil
+}
 
-func Set(config *config.App) {
-	logger = slog.New(
-		slog.NewTextHandler(os.Stderr, nil),
-	)
-	if config.Env == "production" {
-		logRotate := &lumberjack.Logger{
-			Filename:   "log/app.log",
-			MaxSize:    100, // megabytes
-			MaxBackups: 3,
-			MaxAge:     28, // days
-			Compress:   true,
-		}
+func newHandler(format string, w io.Writer, options *slog.HandlerOptions) slog.Handler {
+	if format == "json" {
+		return slog.NewJSONHandler(w, options)
+	}
+	return slog.NewTextHandler(w, options)
+}
 
-		logger = slog.New(
-			slogmulti.Fanout(
-				slog.NewJSONHandler(logRotate, nil),
-				slog.NewTextHandler(os.Stderr, nil),
-			),
-		)
+func writer(config *config.LOG, output string) io.Writer {
+	switch output {
+	case "stdout":
+		return os.Stdout
+	case "file":
+		return &lumberjack.Logger{
+			Filename:   config.File,
+			MaxSize:    config.FileMaxSize, // megabytes
+			MaxBackups: config.FileMaxBackups,
+			MaxAge:     config.FileMaxAge, // days
+			Compress:   config.FileCompress,
+		}
+	default:
+		return os.Stderr
 	}
-	slog.SetDefault(logger)
 }
@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"context"
+	entity "post-tech-challenge-10soat/internal/entities"
+	"time"
+)
+
+type ApiKeyGateway interface {
+	CreateApiKey(ctx context.Context, apiKey entity.ApiKey) (entity.ApiKey, error)
+	GetApiKeyById(ctx context.Context, id string) (entity.ApiKey, error)
+	GetApiKeyByPrefix(ctx context.Context, prefix string) (entity.ApiKey, error)
+	ListApiKeys(ctx context.Context) ([]entity.ApiKey, error)
+	RevokeApiKey(ctx context.Context, id string) (entity.ApiKey, error)
+	TouchApiKey(ctx context.Context, id string, usedAt time.Time) error
+}
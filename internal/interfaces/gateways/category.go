@@ -7,4 +7,7 @@ import (
 
 type CategoryGateway interface {
 	GetCategoryById(ctx context.Context, categoryId string) (entity.Category, error)
+	ListCategoriesByIds(ctx context.Context, ids []string) ([]entity.Category, error)
+	ListCategories(ctx context.Context) ([]entity.Category, error)
+	ListCategoriesByNames(ctx context.Context, names []string) ([]entity.Category, error)
 }
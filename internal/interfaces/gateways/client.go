@@ -9,4 +9,5 @@ type ClientGateway interface {
 	CreateClient(ctx context.Context, client entity.Client) (entity.Client, error)
 	GetClientByCpf(ctx context.Context, cpf string) (entity.Client, error)
 	GetClientById(ctx context.Context, id string) (entity.Client, error)
+	ListClientsByIds(ctx context.Context, ids []string) ([]entity.Client, error)
 }
@@ -0,0 +1,12 @@
+package interfaces
+
+import (
+	"context"
+	entity "post-tech-challenge-10soat/internal/entities"
+)
+
+type ClientConsentGateway interface {
+	CreateClientConsent(ctx context.Context, consent entity.ClientConsent) (entity.ClientConsent, error)
+	GetClientConsent(ctx context.Context, clientId string, purpose entity.ConsentPurpose) (entity.ClientConsent, error)
+	ListClientConsents(ctx context.Context, clientId string) ([]entity.ClientConsent, error)
+}
@@ -0,0 +1,14 @@
+package interfaces
+
+import (
+	"context"
+	entity "post-tech-challenge-10soat/internal/entities"
+)
+
+type ClientIdentificationGateway interface {
+	CreateClientIdentification(ctx context.Context, identification entity.ClientIdentification) (entity.ClientIdentification, error)
+	GetClientIdentificationById(ctx context.Context, id string) (entity.ClientIdentification, error)
+	IncrementClientIdentificationAttempts(ctx context.Context, id string) (entity.ClientIdentification, error)
+	VerifyClientIdentification(ctx context.Context, id string) (entity.ClientIdentification, error)
+	ExpirePendingClientIdentifications(ctx context.Context, clientId string) error
+}
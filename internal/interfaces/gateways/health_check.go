@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"context"
+)
+
+type HealthCheckGateway interface {
+	Name() string
+	// Required tells whether the instance can take traffic without the
+	// dependency.
+	Required() bool
+	Check(ctx context.Context) error
+}
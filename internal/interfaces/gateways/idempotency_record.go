@@ -0,0 +1,14 @@
+package interfaces
+
+import (
+	"context"
+	entity "post-tech-challenge-10soat/internal/entities"
+)
+
+type IdempotencyRecordGateway interface {
+	CreateIdempotencyRecord(ctx context.Context, record entity.IdempotencyRecord) (entity.IdempotencyRecord, error)
+	GetIdempotencyRecord(ctx context.Context, principal string, key string) (entity.IdempotencyRecord, error)
+	CompleteIdempotencyRecord(ctx context.Context, record entity.IdempotencyRecord) error
+	DeleteIdempotencyRecord(ctx context.Context, id string) error
+	DeleteExpiredIdempotencyRecords(ctx context.Context) error
+}
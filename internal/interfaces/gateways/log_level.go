@@ -0,0 +1,8 @@
+package interfaces
+
+// LogLevelGateway reads and changes the minimum level logged while the
+// application runs.
+type LogLevelGateway interface {
+	Level() string
+	SetLevel(level string) error
+}
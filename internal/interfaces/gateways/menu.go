@@ -0,0 +1,12 @@
+package interfaces
+
+import (
+	"context"
+	entity "post-tech-challenge-10soat/internal/entities"
+)
+
+// MenuGateway applies an import at once, creating the categories before the
+// items that refer to them.
+type MenuGateway interface {
+	ImportMenu(ctx context.Context, categories []string, items []entity.MenuItem) error
+}
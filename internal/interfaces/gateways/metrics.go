@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	entity "post-tech-challenge-10soat/internal/entities"
+	"time"
+)
+
+// MetricsGateway records what the API serves and how orders move, so use
+// cases report business events without depending on the metrics backend.
+type MetricsGateway interface {
+	ObserveHttpRequest(method string, route string, status int, duration time.Duration)
+	OrderCreated()
+	OrderStatusChanged(from entity.OrderStatus, to entity.OrderStatus)
+	PaymentFinished(approved bool)
+	SetOrdersByStatus(counts map[entity.OrderStatus]int)
+}
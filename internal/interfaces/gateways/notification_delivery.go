@@ -0,0 +1,10 @@
+package interfaces
+
+import (
+	"context"
+	entity "post-tech-challenge-10soat/internal/entities"
+)
+
+type NotificationDeliveryGateway interface {
+	CreateNotificationDelivery(ctx context.Context, delivery entity.NotificationDelivery) (entity.NotificationDelivery, error)
+}
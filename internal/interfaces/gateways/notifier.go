@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"context"
+	entity "post-tech-challenge-10soat/internal/entities"
+)
+
+// Notifier is the port implemented by every outbound notification channel.
+type Notifier interface {
+	Channel() string
+	Recipient(notification entity.Notification) string
+	Send(ctx context.Context, notification entity.Notification) error
+}
@@ -6,9 +6,11 @@ import (
 )
 
 type OrderGateway interface {
-	CreateOrder(ctx context.Context, order entity.Order) (entity.Order, error)
+	// CreateOrder creates the order along with its products, all or none.
+	CreateOrder(ctx context.Context, order entity.Order, products []entity.OrderProduct) (entity.Order, error)
 	DeleteOrder(ctx context.Context, id string) error
 	ListOrders(ctx context.Context, limit uint64) ([]entity.Order, error)
 	GetOrderById(ctx context.Context, id string) (entity.Order, error)
-	UpdateOrderStatus(ctx context.Context, id string, status string) (entity.Order, error)
+	UpdateOrderStatus(ctx context.Context, id string, status string, version int) (entity.Order, error)
+	CountOrdersByStatus(ctx context.Context) (map[entity.OrderStatus]int, error)
 }
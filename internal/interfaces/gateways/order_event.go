@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"context"
+	entity "post-tech-challenge-10soat/internal/entities"
+)
+
+type OrderEventGateway interface {
+	PublishOrderStatus(ctx context.Context, order entity.Order)
+	// SubscribeOrderStatus delivers orders published after the call until the
+	// context is done, when the channel is closed.
+	SubscribeOrderStatus(ctx context.Context) <-chan entity.Order
+}
@@ -7,4 +7,5 @@ import (
 
 type OrderProductGateway interface {
 	CreateOrderProduct(ctx context.Context, orderProduct entity.OrderProduct) (entity.OrderProduct, error)
+	ListOrderProductsByOrderIds(ctx context.Context, orderIds []string) ([]entity.OrderProduct, error)
 }
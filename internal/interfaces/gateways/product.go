@@ -7,6 +7,8 @@ import (
 
 type ProductGateway interface {
 	ListProducts(ctx context.Context, categoryId string) ([]entity.Product, error)
+	ListProductsByIds(ctx context.Context, ids []string) ([]entity.Product, error)
+	ListProductsBySkus(ctx context.Context, skus []string) ([]entity.Product, error)
 	GetProductById(ctx context.Context, id string) (entity.Product, error)
 	CreateProduct(ctx context.Context, product entity.Product) (entity.Product, error)
 	UpdateProduct(ctx context.Context, product entity.Product) (entity.Product, error)
@@ -0,0 +1,10 @@
+package interfaces
+
+import (
+	"context"
+	entity "post-tech-challenge-10soat/internal/entities"
+)
+
+type RateLimitGateway interface {
+	Take(ctx context.Context, key string, limit entity.RateLimit) (entity.RateLimitDecision, error)
+}
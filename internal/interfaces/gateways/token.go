@@ -0,0 +1,11 @@
+package interfaces
+
+import (
+	"context"
+	entity "post-tech-challenge-10soat/internal/entities"
+)
+
+type TokenGateway interface {
+	IssueToken(ctx context.Context, session entity.Session) (entity.Token, error)
+	ParseToken(ctx context.Context, token string) (entity.Session, error)
+}
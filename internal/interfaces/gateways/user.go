@@ -0,0 +1,11 @@
+package interfaces
+
+import (
+	"context"
+	entity "post-tech-challenge-10soat/internal/entities"
+)
+
+type UserGateway interface {
+	CreateUser(ctx context.Context, user entity.User) (entity.User, error)
+	GetUserByUsername(ctx context.Context, username string) (entity.User, error)
+}
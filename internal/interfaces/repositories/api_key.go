@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"context"
+	dto "post-tech-challenge-10soat/internal/dto/apikey"
+	"time"
+)
+
+type ApiKeyRepository interface {
+	CreateApiKey(ctx context.Context, apiKey dto.StoreApiKeyDTO) (dto.ApiKeyDTO, error)
+	GetApiKeyById(ctx context.Context, id string) (dto.ApiKeyDTO, error)
+	GetApiKeyByPrefix(ctx context.Context, prefix string) (dto.ApiKeyDTO, error)
+	ListApiKeys(ctx context.Context) ([]dto.ApiKeyDTO, error)
+	RevokeApiKey(ctx context.Context, id string) (dto.ApiKeyDTO, error)
+	TouchApiKey(ctx context.Context, id string, usedAt time.Time) error
+}
@@ -7,4 +7,7 @@ import (
 
 type CategoryRepository interface {
 	GetCategoryById(ctx context.Context, categoryId string) (dto.CategoryDTO, error)
+	ListCategoriesByIds(ctx context.Context, ids []string) ([]dto.CategoryDTO, error)
+	ListCategories(ctx context.Context) ([]dto.CategoryDTO, error)
+	ListCategoriesByNames(ctx context.Context, names []string) ([]dto.CategoryDTO, error)
 }
@@ -9,4 +9,5 @@ type ClientRepository interface {
 	CreateClient(ctx context.Context, client dto.CreateClientDTO) (dto.ClientDTO, error)
 	GetClientByCpf(ctx context.Context, cpf string) (dto.ClientDTO, error)
 	GetClientById(ctx context.Context, id string) (dto.ClientDTO, error)
+	ListClientsByIds(ctx context.Context, ids []string) ([]dto.ClientDTO, error)
 }
@@ -0,0 +1,12 @@
+package interfaces
+
+import (
+	"context"
+	dto "post-tech-challenge-10soat/internal/dto/consent"
+)
+
+type ClientConsentRepository interface {
+	CreateClientConsent(ctx context.Context, consent dto.CreateClientConsentDTO) (dto.ClientConsentDTO, error)
+	GetClientConsent(ctx context.Context, clientId string, purpose string) (dto.ClientConsentDTO, error)
+	ListClientConsents(ctx context.Context, clientId string) ([]dto.ClientConsentDTO, error)
+}
@@ -0,0 +1,14 @@
+package interfaces
+
+import (
+	"context"
+	dto "post-tech-challenge-10soat/internal/dto/client"
+)
+
+type ClientIdentificationRepository interface {
+	CreateClientIdentification(ctx context.Context, identification dto.CreateClientIdentificationDTO) (dto.ClientIdentificationDTO, error)
+	GetClientIdentificationById(ctx context.Context, id string) (dto.ClientIdentificationDTO, error)
+	IncrementClientIdentificationAttempts(ctx context.Context, id string) (dto.ClientIdentificationDTO, error)
+	VerifyClientIdentification(ctx context.Context, id string) (dto.ClientIdentificationDTO, error)
+	ExpirePendingClientIdentifications(ctx context.Context, clientId string) error
+}
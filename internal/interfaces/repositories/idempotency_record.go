@@ -0,0 +1,14 @@
+package interfaces
+
+import (
+	"context"
+	dto "post-tech-challenge-10soat/internal/dto/idempotency"
+)
+
+type IdempotencyRecordRepository interface {
+	CreateIdempotencyRecord(ctx context.Context, record dto.CreateIdempotencyRecordDTO) (dto.IdempotencyRecordDTO, error)
+	GetIdempotencyRecord(ctx context.Context, principal string, key string) (dto.IdempotencyRecordDTO, error)
+	CompleteIdempotencyRecord(ctx context.Context, record dto.CompleteIdempotencyRecordDTO) error
+	DeleteIdempotencyRecord(ctx context.Context, id string) error
+	DeleteExpiredIdempotencyRecords(ctx context.Context) error
+}
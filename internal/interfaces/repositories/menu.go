@@ -0,0 +1,10 @@
+package interfaces
+
+import (
+	"context"
+	dto "post-tech-challenge-10soat/internal/dto/product"
+)
+
+type MenuRepository interface {
+	ImportMenu(ctx context.Context, categories []string, products []dto.ImportProductDTO) error
+}
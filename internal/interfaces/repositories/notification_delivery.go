@@ -0,0 +1,10 @@
+package interfaces
+
+import (
+	"context"
+	dto "post-tech-challenge-10soat/internal/dto/notification"
+)
+
+type NotificationDeliveryRepository interface {
+	CreateNotificationDelivery(ctx context.Context, delivery dto.CreateNotificationDeliveryDTO) (dto.NotificationDeliveryDTO, error)
+}
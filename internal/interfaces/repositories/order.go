@@ -10,5 +10,6 @@ type OrderRepository interface {
 	DeleteOrder(ctx context.Context, id string) error
 	ListOrders(ctx context.Context, limit uint64) ([]dto.OrderDTO, error)
 	GetOrderById(ctx context.Context, id string) (dto.OrderDTO, error)
-	UpdateOrderStatus(ctx context.Context, id string, status string) (dto.OrderDTO, error)
+	UpdateOrderStatus(ctx context.Context, id string, status string, version int) (dto.OrderDTO, error)
+	CountOrdersByStatus(ctx context.Context) (map[string]int, error)
 }
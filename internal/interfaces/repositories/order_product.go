@@ -7,4 +7,5 @@ import (
 
 type OrderProductRepository interface {
 	CreateOrderProduct(ctx context.Context, orderProduct dto.CreateOrderProductDTO) (dto.OrderProductDTO, error)
+	ListOrderProductsByOrderIds(ctx context.Context, orderIds []string) ([]dto.OrderProductDTO, error)
 }
@@ -7,6 +7,8 @@ import (
 
 type ProductRepository interface {
 	ListProducts(ctx context.Context, categoryId string) ([]dto.ProductDTO, error)
+	ListProductsByIds(ctx context.Context, ids []string) ([]dto.ProductDTO, error)
+	ListProductsBySkus(ctx context.Context, skus []string) ([]dto.ProductDTO, error)
 	GetProductById(ctx context.Context, id string) (dto.ProductDTO, error)
 	CreateProduct(ctx context.Context, product dto.CreateProductDTO) (dto.ProductDTO, error)
 	UpdateProduct(ctx context.Context, product dto.UpdateProductDTO) (dto.ProductDTO, error)
@@ -0,0 +1,11 @@
+package interfaces
+
+import (
+	"context"
+	dto "post-tech-challenge-10soat/internal/dto/user"
+)
+
+type UserRepository interface {
+	CreateUser(ctx context.Context, user dto.StoreUserDTO) (dto.UserDTO, error)
+	GetUserByUsername(ctx context.Context, username string) (dto.UserDTO, error)
+}
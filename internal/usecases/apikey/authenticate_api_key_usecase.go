@@ -0,0 +1,10 @@
+package apikey
+
+import (
+	"context"
+	entity "post-tech-challenge-10soat/internal/entities"
+)
+
+type AuthenticateApiKeyUseCase interface {
+	Execute(ctx context.Context, key string) (entity.Device, error)
+}
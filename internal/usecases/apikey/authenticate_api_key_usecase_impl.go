@@ -0,0 +1,61 @@
+package apikey
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"log/slog"
+	entity "post-tech-challenge-10soat/internal/entities"
+	interfaces "post-tech-challenge-10soat/internal/interfaces/gateways"
+	"time"
+)
+
+// lastUsedResolution limits how often last_used_at is written for a busy key.
+const lastUsedResolution = time.Minute
+
+var errInvalidApiKey = entity.NewDomainError(entity.ErrUnauthorized, entity.ErrCodeInvalidApiKey, "invalid api key")
+
+type AuthenticateApiKeyUseCaseImpl struct {
+	apiKeyGateway interfaces.ApiKeyGateway
+}
+
+func NewAuthenticateApiKeyUseCaseImpl(apiKeyGateway interfaces.ApiKeyGateway) AuthenticateApiKeyUseCase {
+	return &AuthenticateApiKeyUseCaseImpl{
+		apiKeyGateway,
+	}
+}
+
+func (s AuthenticateApiKeyUseCaseImpl) Execute(ctx context.Context, key string) (entity.Device, error) {
+	prefix, ok := parseApiKey(key)
+	if !ok {
+		return entity.Device{}, errInvalidApiKey
+	}
+	apiKey, err := s.apiKeyGateway.GetApiKeyByPrefix(ctx, prefix)
+	if err != nil {
+		if errors.Is(err, entity.ErrDataNotFound) {
+			return entity.Device{}, errInvalidApiKey
+		}
+		return entity.Device{}, fmt.Errorf("failed to get api key - %w", err)
+	}
+	if subtle.ConstantTimeCompare([]byte(hashApiKey(key)), []byte(apiKey.KeyHash)) != 1 {
+		return entity.Device{}, errInvalidApiKey
+	}
+	now := time.Now().UTC()
+	if apiKey.IsRevoked() {
+		return entity.Device{}, fmt.Errorf("%w: api key revoked", entity.ErrUnauthorized)
+	}
+	if apiKey.IsExpired(now) {
+		return entity.Device{}, fmt.Errorf("%w: api key expired", entity.ErrUnauthorized)
+	}
+	if now.Sub(apiKey.LastUsedAt) >= lastUsedResolution {
+		if err := s.apiKeyGateway.TouchApiKey(ctx, apiKey.Id, now); err != nil {
+			slog.ErrorContext(ctx, "Error updating api key last use", "api_key_id", apiKey.Id, "error", err)
+		}
+	}
+	return entity.Device{
+		ApiKeyId: apiKey.Id,
+		Name:     apiKey.Name,
+		Scopes:   apiKey.Scopes,
+	}, nil
+}
@@ -0,0 +1,104 @@
+package apikey
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	dto "post-tech-challenge-10soat/internal/dto/apikey"
+	entity "post-tech-challenge-10soat/internal/entities"
+	interfaces "post-tech-challenge-10soat/internal/interfaces/gateways"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mockApiKeyGateway struct {
+	interfaces.ApiKeyGateway
+	apiKeys map[string]entity.ApiKey
+	touched []string
+}
+
+func (m *mockApiKeyGateway) CreateApiKey(ctx context.Context, apiKey entity.ApiKey) (entity.ApiKey, error) {
+	apiKey.Id = "key-" + apiKey.Prefix
+	m.apiKeys[apiKey.Prefix] = apiKey
+	return apiKey, nil
+}
+
+func (m *mockApiKeyGateway) GetApiKeyByPrefix(ctx context.Context, prefix string) (entity.ApiKey, error) {
+	apiKey, ok := m.apiKeys[prefix]
+	if !ok {
+		return entity.ApiKey{}, entity.ErrDataNotFound
+	}
+	return apiKey, nil
+}
+
+func (m *mockApiKeyGateway) TouchApiKey(ctx context.Context, id string, usedAt time.Time) error {
+	m.touched = append(m.touched, id)
+	return nil
+}
+
+func newMockApiKeyGateway() *mockApiKeyGateway {
+	return &mockApiKeyGateway{apiKeys: map[string]entity.ApiKey{}}
+}
+
+func TestCreateAndAuthenticateApiKey(t *testing.T) {
+	gateway := newMockApiKeyGateway()
+	created, err := NewCreateApiKeyUseCaseImpl(gateway).Execute(context.Background(), createApiKeyDTO("totem-01", "catalog:read", "orders:write"))
+	assert.NoError(t, err)
+	assert.NotEmpty(t, created.Secret)
+	assert.NotEqual(t, created.Secret, created.KeyHash)
+
+	device, err := NewAuthenticateApiKeyUseCaseImpl(gateway).Execute(context.Background(), created.Secret)
+
+	assert.NoError(t, err)
+	assert.Equal(t, created.Id, device.ApiKeyId)
+	assert.Equal(t, "totem-01", device.Name)
+	assert.True(t, device.HasScope(entity.ApiKeyScopeOrdersWrite))
+	assert.False(t, device.HasScope(entity.ApiKeyScopeCatalogWrite))
+	assert.Equal(t, []string{created.Id}, gateway.touched)
+}
+
+func TestCreateApiKey_UnknownScope(t *testing.T) {
+	_, err := NewCreateApiKeyUseCaseImpl(newMockApiKeyGateway()).Execute(context.Background(), createApiKeyDTO("totem-01", "orders:delete"))
+
+	assert.ErrorIs(t, err, entity.ErrInvalidData)
+}
+
+func TestAuthenticateApiKey_Rejected(t *testing.T) {
+	gateway := newMockApiKeyGateway()
+	created, err := NewCreateApiKeyUseCaseImpl(gateway).Execute(context.Background(), createApiKeyDTO("totem-01", "catalog:read"))
+	assert.NoError(t, err)
+	usecase := NewAuthenticateApiKeyUseCaseImpl(gateway)
+
+	tests := []struct {
+		name   string
+		key    string
+		update func(apiKey *entity.ApiKey)
+	}{
+		{name: "malformed", key: "not-a-key"},
+		{name: "unknown prefix", key: "ptk_000000000000_abc"},
+		{name: "wrong secret", key: "ptk_" + created.Prefix + "_abc"},
+		{name: "revoked", key: created.Secret, update: func(apiKey *entity.ApiKey) { apiKey.RevokedAt = time.Now() }},
+		{name: "expired", key: created.Secret, update: func(apiKey *entity.ApiKey) { apiKey.ExpiresAt = time.Now().Add(-time.Minute) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stored := gateway.apiKeys[created.Prefix]
+			if tt.update != nil {
+				updated := stored
+				tt.update(&updated)
+				gateway.apiKeys[created.Prefix] = updated
+				defer func() { gateway.apiKeys[created.Prefix] = stored }()
+			}
+
+			_, err := usecase.Execute(context.Background(), tt.key)
+
+			assert.ErrorIs(t, err, entity.ErrUnauthorized)
+		})
+	}
+}
+
+func createApiKeyDTO(name string, scopes ...string) dto.CreateApiKeyDTO {
+	return dto.CreateApiKeyDTO{Name: name, Scopes: scopes}
+}
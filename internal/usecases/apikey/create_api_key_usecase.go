@@ -0,0 +1,11 @@
+package apikey
+
+import (
+	"context"
+	dto "post-tech-challenge-10soat/internal/dto/apikey"
+	entity "post-tech-challenge-10soat/internal/entities"
+)
+
+type CreateApiKeyUseCase interface {
+	Execute(ctx context.Context, createApiKey dto.CreateApiKeyDTO) (entity.ApiKey, error)
+}
@@ -0,0 +1,35 @@
+package apikey
+
+import (
+	"context"
+	"fmt"
+	dto "post-tech-challenge-10soat/internal/dto/apikey"
+	entity "post-tech-challenge-10soat/internal/entities"
+	interfaces "post-tech-challenge-10soat/internal/interfaces/gateways"
+	"time"
+)
+
+type CreateApiKeyUseCaseImpl struct {
+	apiKeyGateway interfaces.ApiKeyGateway
+}
+
+func NewCreateApiKeyUseCaseImpl(apiKeyGateway interfaces.ApiKeyGateway) CreateApiKeyUseCase {
+	return &CreateApiKeyUseCaseImpl{
+		apiKeyGateway,
+	}
+}
+
+func (s CreateApiKeyUseCaseImpl) Execute(ctx context.Context, createApiKey dto.CreateApiKeyDTO) (entity.ApiKey, error) {
+	scopes, err := parseScopes(createApiKey.Scopes)
+	if err != nil {
+		return entity.ApiKey{}, err
+	}
+	if !createApiKey.ExpiresAt.IsZero() && !createApiKey.ExpiresAt.After(time.Now()) {
+		return entity.ApiKey{}, fmt.Errorf("%w: expiry must be in the future", entity.ErrInvalidData)
+	}
+	return issueApiKey(ctx, s.apiKeyGateway, entity.ApiKey{
+		Name:      createApiKey.Name,
+		Scopes:    scopes,
+		ExpiresAt: createApiKey.ExpiresAt,
+	})
+}
@@ -0,0 +1,79 @@
+package apikey
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	entity "post-tech-challenge-10soat/internal/entities"
+	interfaces "post-tech-challenge-10soat/internal/interfaces/gateways"
+	"strings"
+)
+
+// Keys look like "ptk_<prefix>_<secret>". The prefix is stored in clear to
+// find the key, the whole key is only kept as a hash.
+const (
+	keyScheme      = "ptk"
+	keyPrefixBytes = 6
+	keySecretBytes = 32
+)
+
+func generateApiKey() (prefix string, key string, err error) {
+	prefixBytes := make([]byte, keyPrefixBytes)
+	secretBytes := make([]byte, keySecretBytes)
+	if _, err := rand.Read(prefixBytes); err != nil {
+		return "", "", err
+	}
+	if _, err := rand.Read(secretBytes); err != nil {
+		return "", "", err
+	}
+	prefix = hex.EncodeToString(prefixBytes)
+	return prefix, fmt.Sprintf("%s_%s_%s", keyScheme, prefix, hex.EncodeToString(secretBytes)), nil
+}
+
+// hashApiKey does not need a slow hash, the key has 256 bits of entropy.
+func hashApiKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+func parseApiKey(key string) (string, bool) {
+	parts := strings.Split(key, "_")
+	if len(parts) != 3 || parts[0] != keyScheme || parts[1] == "" || parts[2] == "" {
+		return "", false
+	}
+	return parts[1], true
+}
+
+func parseScopes(values []string) ([]entity.ApiKeyScope, error) {
+	if len(values) == 0 {
+		return nil, fmt.Errorf("%w: at least one scope is required", entity.ErrInvalidData)
+	}
+	scopes := make([]entity.ApiKeyScope, 0, len(values))
+	for _, value := range values {
+		scope := entity.ApiKeyScope(value)
+		if !scope.IsValid() {
+			return nil, fmt.Errorf("%w: unknown scope '%s'", entity.ErrInvalidData, value)
+		}
+		scopes = append(scopes, scope)
+	}
+	return scopes, nil
+}
+
+// issueApiKey stores a new key and returns it with the secret, which is never
+// available again.
+func issueApiKey(ctx context.Context, apiKeyGateway interfaces.ApiKeyGateway, apiKey entity.ApiKey) (entity.ApiKey, error) {
+	prefix, key, err := generateApiKey()
+	if err != nil {
+		return entity.ApiKey{}, err
+	}
+	apiKey.Prefix = prefix
+	apiKey.KeyHash = hashApiKey(key)
+	created, err := apiKeyGateway.CreateApiKey(ctx, apiKey)
+	if err != nil {
+		return entity.ApiKey{}, fmt.Errorf("failed to create api key - %w", err)
+	}
+	created.Secret = key
+	return created, nil
+}
@@ -0,0 +1,10 @@
+package apikey
+
+import (
+	"context"
+	entity "post-tech-challenge-10soat/internal/entities"
+)
+
+type ListApiKeysUseCase interface {
+	Execute(ctx context.Context) ([]entity.ApiKey, error)
+}
@@ -0,0 +1,26 @@
+package apikey
+
+import (
+	"context"
+	"fmt"
+	entity "post-tech-challenge-10soat/internal/entities"
+	interfaces "post-tech-challenge-10soat/internal/interfaces/gateways"
+)
+
+type ListApiKeysUseCaseImpl struct {
+	apiKeyGateway interfaces.ApiKeyGateway
+}
+
+func NewListApiKeysUseCaseImpl(apiKeyGateway interfaces.ApiKeyGateway) ListApiKeysUseCase {
+	return &ListApiKeysUseCaseImpl{
+		apiKeyGateway,
+	}
+}
+
+func (s ListApiKeysUseCaseImpl) Execute(ctx context.Context) ([]entity.ApiKey, error) {
+	apiKeys, err := s.apiKeyGateway.ListApiKeys(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api keys - %w", err)
+	}
+	return apiKeys, nil
+}
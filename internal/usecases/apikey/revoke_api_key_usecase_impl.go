@@ -0,0 +1,26 @@
+package apikey
+
+import (
+	"context"
+	"fmt"
+	entity "post-tech-challenge-10soat/internal/entities"
+	interfaces "post-tech-challenge-10soat/internal/interfaces/gateways"
+)
+
+type RevokeApiKeyUseCaseImpl struct {
+	apiKeyGateway interfaces.ApiKeyGateway
+}
+
+func NewRevokeApiKeyUseCaseImpl(apiKeyGateway interfaces.ApiKeyGateway) RevokeApiKeyUseCase {
+	return &RevokeApiKeyUseCaseImpl{
+		apiKeyGateway,
+	}
+}
+
+func (s RevokeApiKeyUseCaseImpl) Execute(ctx context.Context, id string) (entity.ApiKey, error) {
+	apiKey, err := s.apiKeyGateway.RevokeApiKey(ctx, id)
+	if err != nil {
+		return entity.ApiKey{}, fmt.Errorf("failed to revoke api key - %w", err)
+	}
+	return apiKey, nil
+}
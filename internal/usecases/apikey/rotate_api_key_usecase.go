@@ -0,0 +1,10 @@
+package apikey
+
+import (
+	"context"
+	entity "post-tech-challenge-10soat/internal/entities"
+)
+
+type RotateApiKeyUseCase interface {
+	Execute(ctx context.Context, id string) (entity.ApiKey, error)
+}
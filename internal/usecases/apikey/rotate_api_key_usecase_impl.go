@@ -0,0 +1,42 @@
+package apikey
+
+import (
+	"context"
+	"fmt"
+	entity "post-tech-challenge-10soat/internal/entities"
+	interfaces "post-tech-challenge-10soat/internal/interfaces/gateways"
+)
+
+type RotateApiKeyUseCaseImpl struct {
+	apiKeyGateway interfaces.ApiKeyGateway
+}
+
+func NewRotateApiKeyUseCaseImpl(apiKeyGateway interfaces.ApiKeyGateway) RotateApiKeyUseCase {
+	return &RotateApiKeyUseCaseImpl{
+		apiKeyGateway,
+	}
+}
+
+// Execute issues a new key with the same name, scopes and expiry and revokes
+// the old one, so the device only needs its secret replaced.
+func (s RotateApiKeyUseCaseImpl) Execute(ctx context.Context, id string) (entity.ApiKey, error) {
+	current, err := s.apiKeyGateway.GetApiKeyById(ctx, id)
+	if err != nil {
+		return entity.ApiKey{}, fmt.Errorf("failed to get api key - %w", err)
+	}
+	if current.IsRevoked() {
+		return entity.ApiKey{}, fmt.Errorf("%w: api key is revoked", entity.ErrConflictingData)
+	}
+	rotated, err := issueApiKey(ctx, s.apiKeyGateway, entity.ApiKey{
+		Name:      current.Name,
+		Scopes:    current.Scopes,
+		ExpiresAt: current.ExpiresAt,
+	})
+	if err != nil {
+		return entity.ApiKey{}, err
+	}
+	if _, err := s.apiKeyGateway.RevokeApiKey(ctx, current.Id); err != nil {
+		return entity.ApiKey{}, fmt.Errorf("failed to revoke rotated api key - %w", err)
+	}
+	return rotated, nil
+}
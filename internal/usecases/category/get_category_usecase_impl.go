@@ -20,7 +20,7 @@ func NewGetCategoryUsecase(gateway interfaces.CategoryGateway) GetCategoryUseCas
 func (s *GetCategoryUsecaseImpl) Execute(ctx context.Context, id string) (entity.Category, error) {
 	category, err := s.gateway.GetCategoryById(ctx, id)
 	if err != nil {
-		return entity.Category{}, fmt.Errorf("failed to get category by id - %s", err.Error())
+		return entity.Category{}, fmt.Errorf("failed to get category by id - %w", err)
 	}
 	return category, nil
 }
@@ -3,10 +3,10 @@ package category
 import (
 	"context"
 	"errors"
-	"testing"
-
 	entity "post-tech-challenge-10soat/internal/entities"
 	interfaces "post-tech-challenge-10soat/internal/interfaces/gateways"
+	"testing"
+
 	"github.com/stretchr/testify/assert"
 )
 
@@ -22,13 +22,13 @@ func (m *mockCategoryGateway) GetCategoryById(ctx context.Context, id string) (e
 func TestGetCategoryUsecaseImpl_Execute_Success(t *testing.T) {
 	mockGateway := &mockCategoryGateway{
 		GetCategoryByIdFunc: func(ctx context.Context, id string) (entity.Category, error) {
-			return entity.Category{ID: "1", Name: "Bebidas"}, nil
+			return entity.Category{Id: "1", Name: "Bebidas"}, nil
 		},
 	}
 	usecase := NewGetCategoryUsecase(mockGateway)
 	cat, err := usecase.Execute(context.Background(), "1")
 	assert.NoError(t, err)
-	assert.Equal(t, "1", cat.ID)
+	assert.Equal(t, "1", cat.Id)
 	assert.Equal(t, "Bebidas", cat.Name)
 }
 
@@ -0,0 +1,33 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	dto "post-tech-challenge-10soat/internal/dto/consent"
+	entity "post-tech-challenge-10soat/internal/entities"
+	interfaces "post-tech-challenge-10soat/internal/interfaces/gateways"
+)
+
+// recordClientConsent appends a grant or revocation for the session's client,
+// keeping the history required to prove when and how consent was given.
+func recordClientConsent(ctx context.Context, consentGateway interfaces.ClientConsentGateway, consent dto.UpdateClientConsentDTO, granted bool) (entity.ClientConsent, error) {
+	clientId, err := sessionClientId(ctx)
+	if err != nil {
+		return entity.ClientConsent{}, err
+	}
+	purpose := entity.ConsentPurpose(consent.Purpose)
+	if !purpose.IsValid() {
+		return entity.ClientConsent{}, fmt.Errorf("%w: unknown consent purpose '%s'", entity.ErrInvalidData, consent.Purpose)
+	}
+	created, err := consentGateway.CreateClientConsent(ctx, entity.ClientConsent{
+		ClientId:      clientId,
+		Purpose:       purpose,
+		Granted:       granted,
+		Source:        consent.Source,
+		PolicyVersion: consent.PolicyVersion,
+	})
+	if err != nil {
+		return entity.ClientConsent{}, fmt.Errorf("failed to record client consent - %w", err)
+	}
+	return created, nil
+}
@@ -20,13 +20,14 @@ func NewCreateClientUsecaseImpl(gateway interfaces.ClientGateway) CreateClientUs
 
 func (s CreateClientUseCaseImpl) Execute(ctx context.Context, createClientDTO dto.CreateClientDTO) (entity.Client, error) {
 	newClient := entity.Client{
-		Cpf:   createClientDTO.Cpf,
-		Name:  createClientDTO.Name,
-		Email: createClientDTO.Email,
+		Cpf:      createClientDTO.Cpf,
+		Name:     createClientDTO.Name,
+		Email:    createClientDTO.Email,
+		Language: createClientDTO.Language,
 	}
 	client, err := s.gateway.CreateClient(ctx, newClient)
 	if err != nil {
-		return entity.Client{}, fmt.Errorf("failed to create client - %s", err.Error())
+		return entity.Client{}, fmt.Errorf("failed to create client - %w", err)
 	}
 	return client, nil
 }
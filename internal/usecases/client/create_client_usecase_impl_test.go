@@ -3,27 +3,37 @@ package client
 import (
 	"context"
 	"errors"
-	"testing"
-
 	dto "post-tech-challenge-10soat/internal/dto/client"
 	entity "post-tech-challenge-10soat/internal/entities"
 	interfaces "post-tech-challenge-10soat/internal/interfaces/gateways"
+	"testing"
+
 	"github.com/stretchr/testify/assert"
 )
 
 type mockClientGateway struct {
 	interfaces.ClientGateway
-	CreateClientFunc func(ctx context.Context, client entity.Client) (entity.Client, error)
+	CreateClientFunc   func(ctx context.Context, client entity.Client) (entity.Client, error)
+	GetClientByCpfFunc func(ctx context.Context, cpf string) (entity.Client, error)
+	GetClientByIdFunc  func(ctx context.Context, id string) (entity.Client, error)
 }
 
 func (m *mockClientGateway) CreateClient(ctx context.Context, client entity.Client) (entity.Client, error) {
 	return m.CreateClientFunc(ctx, client)
 }
 
+func (m *mockClientGateway) GetClientByCpf(ctx context.Context, cpf string) (entity.Client, error) {
+	return m.GetClientByCpfFunc(ctx, cpf)
+}
+
+func (m *mockClientGateway) GetClientById(ctx context.Context, id string) (entity.Client, error) {
+	return m.GetClientByIdFunc(ctx, id)
+}
+
 func TestCreateClientUseCaseImpl_Execute_Success(t *testing.T) {
 	mockGateway := &mockClientGateway{
 		CreateClientFunc: func(ctx context.Context, client entity.Client) (entity.Client, error) {
-			client.ID = "123"
+			client.Id = "123"
 			return client, nil
 		},
 	}
@@ -35,7 +45,7 @@ func TestCreateClientUseCaseImpl_Execute_Success(t *testing.T) {
 	}
 	result, err := usecase.Execute(context.Background(), input)
 	assert.NoError(t, err)
-	assert.Equal(t, "123", result.ID)
+	assert.Equal(t, "123", result.Id)
 	assert.Equal(t, input.Cpf, result.Cpf)
 	assert.Equal(t, input.Name, result.Name)
 	assert.Equal(t, input.Email, result.Email)
@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	entity "post-tech-challenge-10soat/internal/entities"
 	interfaces "post-tech-challenge-10soat/internal/interfaces/gateways"
@@ -20,7 +21,13 @@ func NewGetClientByCpfUseCaseImpl(gateway interfaces.ClientGateway) GetClientByC
 func (s GetClientByCpfUseCaseImpl) Execute(ctx context.Context, cpf string) (entity.Client, error) {
 	client, err := s.gateway.GetClientByCpf(ctx, cpf)
 	if err != nil {
-		return entity.Client{}, fmt.Errorf("failed to get client by cpf - %s", err.Error())
+		if errors.Is(err, entity.ErrDataNotFound) {
+			return entity.Client{}, entity.ErrClientNotFound
+		}
+		return entity.Client{}, fmt.Errorf("failed to get client by cpf - %w", err)
+	}
+	if err := authorizeClient(ctx, client.Id); err != nil {
+		return entity.Client{}, err
 	}
 	return client, nil
 }
@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	entity "post-tech-challenge-10soat/internal/entities"
 	interfaces "post-tech-challenge-10soat/internal/interfaces/gateways"
@@ -20,7 +21,10 @@ func NewGetClientByIdUseCaseImpl(gateway interfaces.ClientGateway) GetClientById
 func (s GetClientByIdUseCaseImpl) Execute(ctx context.Context, id string) (entity.Client, error) {
 	client, err := s.gateway.GetClientById(ctx, id)
 	if err != nil {
-		return entity.Client{}, fmt.Errorf("failed to get client by id - %s", err.Error())
+		if errors.Is(err, entity.ErrDataNotFound) {
+			return entity.Client{}, entity.ErrClientNotFound
+		}
+		return entity.Client{}, fmt.Errorf("failed to get client by id - %w", err)
 	}
 	return client, nil
 }
@@ -0,0 +1,22 @@
+package client
+
+import (
+	"context"
+	dto "post-tech-challenge-10soat/internal/dto/consent"
+	entity "post-tech-challenge-10soat/internal/entities"
+	interfaces "post-tech-challenge-10soat/internal/interfaces/gateways"
+)
+
+type GrantClientConsentUseCaseImpl struct {
+	consentGateway interfaces.ClientConsentGateway
+}
+
+func NewGrantClientConsentUseCaseImpl(consentGateway interfaces.ClientConsentGateway) GrantClientConsentUseCase {
+	return &GrantClientConsentUseCaseImpl{
+		consentGateway,
+	}
+}
+
+func (s GrantClientConsentUseCaseImpl) Execute(ctx context.Context, consent dto.UpdateClientConsentDTO) (entity.ClientConsent, error) {
+	return recordClientConsent(ctx, s.consentGateway, consent, true)
+}
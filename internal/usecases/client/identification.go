@@ -0,0 +1,65 @@
+package client
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	entity "post-tech-challenge-10soat/internal/entities"
+	"strings"
+)
+
+const identificationCodeDigits = 6
+
+func generateIdentificationCode() (string, error) {
+	max := big.NewInt(1)
+	for i := 0; i < identificationCodeDigits; i++ {
+		max.Mul(max, big.NewInt(10))
+	}
+	n, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%0*d", identificationCodeDigits, n), nil
+}
+
+// hashIdentificationCode binds the code to the client so a stored hash is
+// useless for any other identification.
+func hashIdentificationCode(clientId string, code string) string {
+	sum := sha256.Sum256([]byte(clientId + ":" + code))
+	return hex.EncodeToString(sum[:])
+}
+
+func maskEmail(email string) string {
+	local, domain, found := strings.Cut(email, "@")
+	if !found || local == "" {
+		return ""
+	}
+	return local[:1] + strings.Repeat("*", max(len(local)-1, 3)) + "@" + domain
+}
+
+// authorizeClient ensures the caller holds a customer session for the client.
+func authorizeClient(ctx context.Context, clientId string) error {
+	session, ok := entity.SessionFromContext(ctx)
+	if !ok {
+		return entity.ErrUnauthorized
+	}
+	if session.Role != entity.RoleCustomer || session.Subject != clientId {
+		return entity.ErrForbidden
+	}
+	return nil
+}
+
+// sessionClientId returns the client identified by the customer session.
+func sessionClientId(ctx context.Context) (string, error) {
+	session, ok := entity.SessionFromContext(ctx)
+	if !ok {
+		return "", entity.ErrUnauthorized
+	}
+	if session.Role != entity.RoleCustomer {
+		return "", entity.ErrForbidden
+	}
+	return session.Subject, nil
+}
@@ -0,0 +1,10 @@
+package client
+
+import (
+	"context"
+	entity "post-tech-challenge-10soat/internal/entities"
+)
+
+type ListClientConsentsUseCase interface {
+	Execute(ctx context.Context) ([]entity.ClientConsent, error)
+}
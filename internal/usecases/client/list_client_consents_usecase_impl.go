@@ -0,0 +1,30 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	entity "post-tech-challenge-10soat/internal/entities"
+	interfaces "post-tech-challenge-10soat/internal/interfaces/gateways"
+)
+
+type ListClientConsentsUseCaseImpl struct {
+	consentGateway interfaces.ClientConsentGateway
+}
+
+func NewListClientConsentsUseCaseImpl(consentGateway interfaces.ClientConsentGateway) ListClientConsentsUseCase {
+	return &ListClientConsentsUseCaseImpl{
+		consentGateway,
+	}
+}
+
+func (s ListClientConsentsUseCaseImpl) Execute(ctx context.Context) ([]entity.ClientConsent, error) {
+	clientId, err := sessionClientId(ctx)
+	if err != nil {
+		return nil, err
+	}
+	consents, err := s.consentGateway.ListClientConsents(ctx, clientId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list client consents - %w", err)
+	}
+	return consents, nil
+}
@@ -0,0 +1,10 @@
+package client
+
+import (
+	"context"
+	entity "post-tech-challenge-10soat/internal/entities"
+)
+
+type ListClientsByIdsUseCase interface {
+	Execute(ctx context.Context, ids []string) ([]entity.Client, error)
+}
@@ -0,0 +1,23 @@
+package client
+
+import (
+	"context"
+	entity "post-tech-challenge-10soat/internal/entities"
+	interfaces "post-tech-challenge-10soat/internal/interfaces/gateways"
+)
+
+type ListClientsByIdsUseCaseImpl struct {
+	gateway interfaces.ClientGateway
+}
+
+func NewListClientsByIdsUseCaseImpl(gateway interfaces.ClientGateway) ListClientsByIdsUseCase {
+	return &ListClientsByIdsUseCaseImpl{
+		gateway,
+	}
+}
+
+// Execute loads the clients with one query, for callers that batch lookups.
+// Unknown ids are left out of the result.
+func (s ListClientsByIdsUseCaseImpl) Execute(ctx context.Context, ids []string) ([]entity.Client, error) {
+	return s.gateway.ListClientsByIds(ctx, ids)
+}
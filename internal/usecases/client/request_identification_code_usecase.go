@@ -0,0 +1,10 @@
+package client
+
+import (
+	"context"
+	entity "post-tech-challenge-10soat/internal/entities"
+)
+
+type RequestIdentificationCodeUseCase interface {
+	Execute(ctx context.Context, cpf string) (entity.ClientIdentification, error)
+}
@@ -0,0 +1,106 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"math"
+	entity "post-tech-challenge-10soat/internal/entities"
+	interfaces "post-tech-challenge-10soat/internal/interfaces/gateways"
+	"post-tech-challenge-10soat/internal/usecases/notification"
+	"time"
+)
+
+type RequestIdentificationCodeUseCaseImpl struct {
+	clientGateway         interfaces.ClientGateway
+	identificationGateway interfaces.ClientIdentificationGateway
+	rateLimitGateway      interfaces.RateLimitGateway
+	notifier              interfaces.Notifier
+	codeTTL               time.Duration
+	cooldown              time.Duration
+	maxAttempts           int
+	defaultLanguage       string
+}
+
+func NewRequestIdentificationCodeUseCaseImpl(
+	clientGateway interfaces.ClientGateway,
+	identificationGateway interfaces.ClientIdentificationGateway,
+	rateLimitGateway interfaces.RateLimitGateway,
+	notifier interfaces.Notifier,
+	codeTTL time.Duration,
+	cooldown time.Duration,
+	maxAttempts int,
+	defaultLanguage string,
+) RequestIdentificationCodeUseCase {
+	return &RequestIdentificationCodeUseCaseImpl{
+		clientGateway,
+		identificationGateway,
+		rateLimitGateway,
+		notifier,
+		codeTTL,
+		cooldown,
+		maxAttempts,
+		defaultLanguage,
+	}
+}
+
+func (s RequestIdentificationCodeUseCaseImpl) Execute(ctx context.Context, cpf string) (entity.ClientIdentification, error) {
+	client, err := s.clientGateway.GetClientByCpf(ctx, cpf)
+	if err != nil {
+		return entity.ClientIdentification{}, fmt.Errorf("failed to get client by cpf - %w", err)
+	}
+	if client.Email == "" {
+		return entity.ClientIdentification{}, fmt.Errorf("%w: client has no registered email", entity.ErrForbidden)
+	}
+	// A new code comes with fresh attempts, so issuing is throttled per client
+	// to keep the attempt limit meaningful. Unlike the HTTP rate limit, a store
+	// failure rejects the request.
+	decision, err := s.rateLimitGateway.Take(ctx, "identification-code:"+client.Id, entity.RateLimit{Requests: 1, Period: s.cooldown})
+	if err != nil {
+		return entity.ClientIdentification{}, fmt.Errorf("failed to throttle identification codes - %w", err)
+	}
+	if !decision.Allowed {
+		return entity.ClientIdentification{}, fmt.Errorf("%w: retry in %d seconds", entity.ErrRateLimited, int(math.Ceil(decision.RetryAfter.Seconds())))
+	}
+	// Only the most recent code is valid for a client.
+	if err := s.identificationGateway.ExpirePendingClientIdentifications(ctx, client.Id); err != nil {
+		return entity.ClientIdentification{}, fmt.Errorf("failed to expire previous codes - %w", err)
+	}
+	code, err := generateIdentificationCode()
+	if err != nil {
+		return entity.ClientIdentification{}, err
+	}
+	identification, err := s.identificationGateway.CreateClientIdentification(ctx, entity.ClientIdentification{
+		ClientId:    client.Id,
+		CodeHash:    hashIdentificationCode(client.Id, code),
+		MaxAttempts: s.maxAttempts,
+		ExpiresAt:   time.Now().UTC().Add(s.codeTTL),
+	})
+	if err != nil {
+		return entity.ClientIdentification{}, fmt.Errorf("failed to create identification - %w", err)
+	}
+	language, subject, body, err := notification.Render(
+		entity.NotificationEventIdentificationCode,
+		[]string{client.Language, s.defaultLanguage},
+		notification.TemplateData{
+			ClientName:       client.Name,
+			Code:             code,
+			ExpiresInMinutes: int(s.codeTTL.Minutes()),
+		},
+	)
+	if err != nil {
+		return entity.ClientIdentification{}, err
+	}
+	err = s.notifier.Send(ctx, entity.Notification{
+		Event:      entity.NotificationEventIdentificationCode,
+		Client:     client,
+		Language:   language,
+		Subject:    subject,
+		Body:       body,
+		OccurredAt: time.Now(),
+	})
+	if err != nil {
+		return entity.ClientIdentification{}, fmt.Errorf("failed to send identification code - %w", err)
+	}
+	identification.Destination = maskEmail(client.Email)
+	return identification, nil
+}
@@ -0,0 +1,72 @@
+package client
+
+import (
+	"context"
+	entity "post-tech-challenge-10soat/internal/entities"
+	interfaces "post-tech-challenge-10soat/internal/interfaces/gateways"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeRateLimitGateway struct {
+	buckets map[string]entity.TokenBucket
+}
+
+func (f *fakeRateLimitGateway) Take(ctx context.Context, key string, limit entity.RateLimit) (entity.RateLimitDecision, error) {
+	bucket, decision := f.buckets[key].Take(limit, time.Now())
+	f.buckets[key] = bucket
+	return decision, nil
+}
+
+type fakeIssuingGateway struct {
+	interfaces.ClientIdentificationGateway
+	created []entity.ClientIdentification
+}
+
+func (f *fakeIssuingGateway) ExpirePendingClientIdentifications(ctx context.Context, clientId string) error {
+	return nil
+}
+
+func (f *fakeIssuingGateway) CreateClientIdentification(ctx context.Context, identification entity.ClientIdentification) (entity.ClientIdentification, error) {
+	f.created = append(f.created, identification)
+	return identification, nil
+}
+
+type fakeNotifier struct {
+	interfaces.Notifier
+	sent int
+}
+
+func (f *fakeNotifier) Send(ctx context.Context, notification entity.Notification) error {
+	f.sent++
+	return nil
+}
+
+func TestRequestIdentificationCodeUseCaseImpl_Execute_Cooldown(t *testing.T) {
+	// Setup
+	clientGateway := &mockClientGateway{
+		GetClientByCpfFunc: func(ctx context.Context, cpf string) (entity.Client, error) {
+			return entity.Client{Id: "client-" + cpf, Cpf: cpf, Name: "Ana", Email: "ana@email.com"}, nil
+		},
+	}
+	identificationGateway := &fakeIssuingGateway{}
+	notifier := &fakeNotifier{}
+	rateLimitGateway := &fakeRateLimitGateway{buckets: map[string]entity.TokenBucket{}}
+	usecase := NewRequestIdentificationCodeUseCaseImpl(clientGateway, identificationGateway, rateLimitGateway, notifier, time.Minute, time.Minute, 5, "pt-BR")
+
+	// Execute
+	first, firstErr := usecase.Execute(context.Background(), "12345678900")
+	_, secondErr := usecase.Execute(context.Background(), "12345678900")
+	_, otherErr := usecase.Execute(context.Background(), "98765432100")
+
+	// Assert
+	assert.NoError(t, firstErr)
+	assert.Equal(t, "a***@email.com", first.Destination)
+	assert.ErrorIs(t, secondErr, entity.ErrRateLimited)
+	assert.Contains(t, secondErr.Error(), "retry in 60 seconds")
+	assert.NoError(t, otherErr)
+	assert.Len(t, identificationGateway.created, 2)
+	assert.Equal(t, 2, notifier.sent)
+}
@@ -0,0 +1,11 @@
+package client
+
+import (
+	"context"
+	dto "post-tech-challenge-10soat/internal/dto/consent"
+	entity "post-tech-challenge-10soat/internal/entities"
+)
+
+type RevokeClientConsentUseCase interface {
+	Execute(ctx context.Context, consent dto.UpdateClientConsentDTO) (entity.ClientConsent, error)
+}
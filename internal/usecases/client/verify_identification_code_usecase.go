@@ -0,0 +1,10 @@
+package client
+
+import (
+	"context"
+	entity "post-tech-challenge-10soat/internal/entities"
+)
+
+type VerifyIdentificationCodeUseCase interface {
+	Execute(ctx context.Context, identificationId string, code string) (entity.Token, error)
+}
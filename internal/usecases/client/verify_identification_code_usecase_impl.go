@@ -0,0 +1,81 @@
+package client
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	entity "post-tech-challenge-10soat/internal/entities"
+	interfaces "post-tech-challenge-10soat/internal/interfaces/gateways"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type VerifyIdentificationCodeUseCaseImpl struct {
+	identificationGateway interfaces.ClientIdentificationGateway
+	tokenGateway          interfaces.TokenGateway
+	sessionTTL            time.Duration
+}
+
+func NewVerifyIdentificationCodeUseCaseImpl(
+	identificationGateway interfaces.ClientIdentificationGateway,
+	tokenGateway interfaces.TokenGateway,
+	sessionTTL time.Duration,
+) VerifyIdentificationCodeUseCase {
+	return &VerifyIdentificationCodeUseCaseImpl{
+		identificationGateway,
+		tokenGateway,
+		sessionTTL,
+	}
+}
+
+func (s VerifyIdentificationCodeUseCaseImpl) Execute(ctx context.Context, identificationId string, code string) (entity.Token, error) {
+	if uuid.Validate(identificationId) != nil {
+		return entity.Token{}, entity.ErrDataNotFound
+	}
+	// The attempt is spent before the code is compared, so parallel guesses
+	// cannot all be checked against the same remaining attempt.
+	identification, err := s.identificationGateway.IncrementClientIdentificationAttempts(ctx, identificationId)
+	if err != nil {
+		if errors.Is(err, entity.ErrDataNotFound) {
+			return entity.Token{}, s.rejection(ctx, identificationId)
+		}
+		return entity.Token{}, fmt.Errorf("failed to register attempt - %w", err)
+	}
+	expected := hashIdentificationCode(identification.ClientId, code)
+	if subtle.ConstantTimeCompare([]byte(expected), []byte(identification.CodeHash)) != 1 {
+		if !identification.HasAttemptsLeft() {
+			return entity.Token{}, fmt.Errorf("%w: too many attempts", entity.ErrForbidden)
+		}
+		return entity.Token{}, fmt.Errorf("%w: invalid code", entity.ErrUnauthorized)
+	}
+	identification, err = s.identificationGateway.VerifyClientIdentification(ctx, identification.Id)
+	if err != nil {
+		if errors.Is(err, entity.ErrDataNotFound) {
+			return entity.Token{}, fmt.Errorf("%w: code is no longer valid", entity.ErrUnauthorized)
+		}
+		return entity.Token{}, fmt.Errorf("failed to verify identification - %w", err)
+	}
+	return s.tokenGateway.IssueToken(ctx, entity.Session{
+		Subject:   identification.ClientId,
+		Role:      entity.RoleCustomer,
+		ExpiresAt: time.Now().UTC().Add(s.sessionTTL),
+	})
+}
+
+// rejection tells why no attempt could be spent on the identification.
+func (s VerifyIdentificationCodeUseCaseImpl) rejection(ctx context.Context, identificationId string) error {
+	identification, err := s.identificationGateway.GetClientIdentificationById(ctx, identificationId)
+	if err != nil {
+		return fmt.Errorf("failed to get identification - %w", err)
+	}
+	switch {
+	case identification.IsVerified():
+		return fmt.Errorf("%w: code already used", entity.ErrUnauthorized)
+	case identification.IsExpired(time.Now().UTC()):
+		return fmt.Errorf("%w: code expired", entity.ErrUnauthorized)
+	default:
+		return fmt.Errorf("%w: too many attempts", entity.ErrForbidden)
+	}
+}
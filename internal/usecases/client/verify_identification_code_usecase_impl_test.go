@@ -0,0 +1,148 @@
+package client
+
+import (
+	"context"
+	entity "post-tech-challenge-10soat/internal/entities"
+	interfaces "post-tech-challenge-10soat/internal/interfaces/gateways"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const identificationId = "3b9d6a2e-4f51-4c8a-9e0b-7d2c1f6a5b43"
+
+// fakeIdentificationGateway applies the same guards as the repository, under
+// a lock standing in for the single UPDATE statement.
+type fakeIdentificationGateway struct {
+	interfaces.ClientIdentificationGateway
+	mu             sync.Mutex
+	identification entity.ClientIdentification
+}
+
+func (f *fakeIdentificationGateway) GetClientIdentificationById(ctx context.Context, id string) (entity.ClientIdentification, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.identification, nil
+}
+
+func (f *fakeIdentificationGateway) IncrementClientIdentificationAttempts(ctx context.Context, id string) (entity.ClientIdentification, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.identification.IsVerified() || f.identification.IsExpired(time.Now().UTC()) || !f.identification.HasAttemptsLeft() {
+		return entity.ClientIdentification{}, entity.ErrDataNotFound
+	}
+	f.identification.Attempts++
+	return f.identification, nil
+}
+
+func (f *fakeIdentificationGateway) VerifyClientIdentification(ctx context.Context, id string) (entity.ClientIdentification, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.identification.IsVerified() || f.identification.Attempts > f.identification.MaxAttempts {
+		return entity.ClientIdentification{}, entity.ErrDataNotFound
+	}
+	f.identification.VerifiedAt = time.Now().UTC()
+	return f.identification, nil
+}
+
+type fakeTokenGateway struct {
+	interfaces.TokenGateway
+}
+
+func (f *fakeTokenGateway) IssueToken(ctx context.Context, session entity.Session) (entity.Token, error) {
+	return entity.Token{AccessToken: session.Subject}, nil
+}
+
+func newFakeIdentificationGateway(maxAttempts int) *fakeIdentificationGateway {
+	return &fakeIdentificationGateway{identification: entity.ClientIdentification{
+		Id:          identificationId,
+		ClientId:    "client-1",
+		CodeHash:    hashIdentificationCode("client-1", "123456"),
+		MaxAttempts: maxAttempts,
+		ExpiresAt:   time.Now().UTC().Add(time.Minute),
+	}}
+}
+
+func TestVerifyIdentificationCodeUseCaseImpl_Execute_Success(t *testing.T) {
+	// Setup
+	gateway := newFakeIdentificationGateway(3)
+	usecase := NewVerifyIdentificationCodeUseCaseImpl(gateway, &fakeTokenGateway{}, time.Minute)
+
+	// Execute
+	token, err := usecase.Execute(context.Background(), identificationId, "123456")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "client-1", token.AccessToken)
+	_, err = usecase.Execute(context.Background(), identificationId, "123456")
+	assert.ErrorIs(t, err, entity.ErrUnauthorized)
+}
+
+func TestVerifyIdentificationCodeUseCaseImpl_Execute_LastAttempt(t *testing.T) {
+	// Setup
+	gateway := newFakeIdentificationGateway(2)
+	usecase := NewVerifyIdentificationCodeUseCaseImpl(gateway, &fakeTokenGateway{}, time.Minute)
+
+	// Execute
+	_, wrongErr := usecase.Execute(context.Background(), identificationId, "000000")
+	token, err := usecase.Execute(context.Background(), identificationId, "123456")
+
+	// Assert
+	assert.ErrorIs(t, wrongErr, entity.ErrUnauthorized)
+	assert.NoError(t, err)
+	assert.Equal(t, "client-1", token.AccessToken)
+}
+
+func TestVerifyIdentificationCodeUseCaseImpl_Execute_TooManyAttempts(t *testing.T) {
+	// Setup
+	gateway := newFakeIdentificationGateway(2)
+	usecase := NewVerifyIdentificationCodeUseCaseImpl(gateway, &fakeTokenGateway{}, time.Minute)
+
+	// Execute
+	_, firstErr := usecase.Execute(context.Background(), identificationId, "000000")
+	_, secondErr := usecase.Execute(context.Background(), identificationId, "000001")
+	_, err := usecase.Execute(context.Background(), identificationId, "123456")
+
+	// Assert
+	assert.ErrorIs(t, firstErr, entity.ErrUnauthorized)
+	assert.ErrorIs(t, secondErr, entity.ErrForbidden)
+	assert.ErrorIs(t, err, entity.ErrForbidden)
+	assert.Equal(t, 2, gateway.identification.Attempts)
+}
+
+func TestVerifyIdentificationCodeUseCaseImpl_Execute_ConcurrentGuesses(t *testing.T) {
+	// Setup
+	gateway := newFakeIdentificationGateway(3)
+	usecase := NewVerifyIdentificationCodeUseCaseImpl(gateway, &fakeTokenGateway{}, time.Minute)
+
+	// Execute
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = usecase.Execute(context.Background(), identificationId, "000000")
+		}()
+	}
+	wg.Wait()
+
+	// Assert
+	assert.Equal(t, 3, gateway.identification.Attempts)
+}
+
+func TestVerifyIdentificationCodeUseCaseImpl_Execute_Expired(t *testing.T) {
+	// Setup
+	gateway := newFakeIdentificationGateway(3)
+	gateway.identification.ExpiresAt = time.Now().UTC().Add(-time.Second)
+	usecase := NewVerifyIdentificationCodeUseCaseImpl(gateway, &fakeTokenGateway{}, time.Minute)
+
+	// Execute
+	_, err := usecase.Execute(context.Background(), identificationId, "123456")
+
+	// Assert
+	assert.ErrorIs(t, err, entity.ErrUnauthorized)
+	assert.Contains(t, err.Error(), "code expired")
+	assert.Zero(t, gateway.identification.Attempts)
+}
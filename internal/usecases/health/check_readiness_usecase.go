@@ -0,0 +1,10 @@
+package health
+
+import (
+	"context"
+	entity "post-tech-challenge-10soat/internal/entities"
+)
+
+type CheckReadinessUseCase interface {
+	Execute(ctx context.Context) entity.HealthReport
+}
@@ -0,0 +1,88 @@
+package health
+
+import (
+	"context"
+	"log/slog"
+	entity "post-tech-challenge-10soat/internal/entities"
+	interfaces "post-tech-challenge-10soat/internal/interfaces/gateways"
+	"sync"
+	"time"
+)
+
+type CheckReadinessUseCaseImpl struct {
+	checks  []interfaces.HealthCheckGateway
+	timeout time.Duration
+	// last holds the status of each dependency in the previous check, to
+	// log when one drops or comes back.
+	last *sync.Map
+}
+
+func NewCheckReadinessUseCaseImpl(checks []interfaces.HealthCheckGateway, timeout time.Duration) CheckReadinessUseCase {
+	return &CheckReadinessUseCaseImpl{
+		checks,
+		timeout,
+		&sync.Map{},
+	}
+}
+
+// Execute checks every dependency at the same time, each bounded by the
+// timeout, so a hanging dependency is reported as down instead of holding
+// the probe.
+func (s CheckReadinessUseCaseImpl) Execute(ctx context.Context) entity.HealthReport {
+	dependencies := make([]entity.DependencyHealth, len(s.checks))
+	var wg sync.WaitGroup
+	for i, check := range s.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			dependencies[i] = s.check(ctx, check)
+		}()
+	}
+	wg.Wait()
+	report := entity.HealthReport{
+		Status:       entity.HealthStatusUp,
+		Dependencies: dependencies,
+	}
+	for _, dependency := range dependencies {
+		s.logChange(ctx, dependency)
+		if dependency.Status == entity.HealthStatusUp {
+			continue
+		}
+		if dependency.Required {
+			report.Status = entity.HealthStatusDown
+		} else if report.Status == entity.HealthStatusUp {
+			report.Status = entity.HealthStatusDegraded
+		}
+	}
+	return report
+}
+
+func (s CheckReadinessUseCaseImpl) check(ctx context.Context, check interfaces.HealthCheckGateway) entity.DependencyHealth {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+	start := time.Now()
+	err := check.Check(ctx)
+	dependency := entity.DependencyHealth{
+		Name:     check.Name(),
+		Required: check.Required(),
+		Status:   entity.HealthStatusUp,
+		Latency:  time.Since(start),
+	}
+	if err != nil {
+		dependency.Status = entity.HealthStatusDown
+		dependency.Error = err.Error()
+	}
+	return dependency
+}
+
+// logChange logs a dependency that dropped or came back since the previous
+// check. The drivers reconnect by themselves, this only tells when.
+func (s CheckReadinessUseCaseImpl) logChange(ctx context.Context, dependency entity.DependencyHealth) {
+	last, checked := s.last.Swap(dependency.Name, dependency.Status)
+	switch {
+	case dependency.Status == entity.HealthStatusDown && last != entity.HealthStatusDown:
+		slog.WarnContext(ctx, "Dependency down", "dependency", dependency.Name, "required", dependency.Required, "error", dependency.Error)
+	case dependency.Status == entity.HealthStatusUp && checked && last != entity.HealthStatusUp:
+		slog.InfoContext(ctx, "Dependency restored", "dependency", dependency.Name)
+	}
+}
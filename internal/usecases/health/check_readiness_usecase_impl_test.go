@@ -0,0 +1,126 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	entity "post-tech-challenge-10soat/internal/entities"
+	interfaces "post-tech-challenge-10soat/internal/interfaces/gateways"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeHealthCheckGateway struct {
+	name  string
+	check func(ctx context.Context) error
+}
+
+func (f fakeHealthCheckGateway) Name() string {
+	return f.name
+}
+
+func (f fakeHealthCheckGateway) Required() bool {
+	return true
+}
+
+func (f fakeHealthCheckGateway) Check(ctx context.Context) error {
+	return f.check(ctx)
+}
+
+type optionalHealthCheckGateway struct {
+	fakeHealthCheckGateway
+}
+
+func (f optionalHealthCheckGateway) Required() bool {
+	return false
+}
+
+func TestCheckReadinessUseCase_AllUp(t *testing.T) {
+	usecase := NewCheckReadinessUseCaseImpl([]interfaces.HealthCheckGateway{
+		fakeHealthCheckGateway{"postgres", func(ctx context.Context) error { return nil }},
+		fakeHealthCheckGateway{"mongo", func(ctx context.Context) error { return nil }},
+	}, time.Second)
+
+	report := usecase.Execute(context.Background())
+
+	assert.True(t, report.IsUp())
+	assert.Len(t, report.Dependencies, 2)
+	assert.Equal(t, "postgres", report.Dependencies[0].Name)
+	assert.Equal(t, "mongo", report.Dependencies[1].Name)
+}
+
+func TestCheckReadinessUseCase_DependencyDown(t *testing.T) {
+	usecase := NewCheckReadinessUseCaseImpl([]interfaces.HealthCheckGateway{
+		fakeHealthCheckGateway{"postgres", func(ctx context.Context) error { return errors.New("migration version is 16, expected 17") }},
+		fakeHealthCheckGateway{"mongo", func(ctx context.Context) error { return nil }},
+	}, time.Second)
+
+	report := usecase.Execute(context.Background())
+
+	assert.Equal(t, entity.HealthStatusDown, report.Status)
+	assert.Equal(t, entity.HealthStatusDown, report.Dependencies[0].Status)
+	assert.Equal(t, "migration version is 16, expected 17", report.Dependencies[0].Error)
+	assert.Equal(t, entity.HealthStatusUp, report.Dependencies[1].Status)
+}
+
+func TestCheckReadinessUseCase_TimesOutHangingDependency(t *testing.T) {
+	usecase := NewCheckReadinessUseCaseImpl([]interfaces.HealthCheckGateway{
+		fakeHealthCheckGateway{"mongo", func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}},
+	}, 10*time.Millisecond)
+
+	start := time.Now()
+	report := usecase.Execute(context.Background())
+
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Equal(t, entity.HealthStatusDown, report.Status)
+	assert.Equal(t, context.DeadlineExceeded.Error(), report.Dependencies[0].Error)
+}
+
+func TestCheckReadinessUseCase_OptionalDependencyDown(t *testing.T) {
+	usecase := NewCheckReadinessUseCaseImpl([]interfaces.HealthCheckGateway{
+		fakeHealthCheckGateway{"postgres", func(ctx context.Context) error { return nil }},
+		optionalHealthCheckGateway{fakeHealthCheckGateway{"mongo", func(ctx context.Context) error { return errors.New("server selection timeout") }}},
+	}, time.Second)
+
+	report := usecase.Execute(context.Background())
+
+	assert.Equal(t, entity.HealthStatusDegraded, report.Status)
+	assert.True(t, report.IsReady())
+	assert.True(t, report.Dependencies[0].Required)
+	assert.False(t, report.Dependencies[1].Required)
+	assert.Equal(t, entity.HealthStatusDown, report.Dependencies[1].Status)
+}
+
+func TestCheckReadinessUseCase_RequiredDependencyDownWins(t *testing.T) {
+	usecase := NewCheckReadinessUseCaseImpl([]interfaces.HealthCheckGateway{
+		optionalHealthCheckGateway{fakeHealthCheckGateway{"mongo", func(ctx context.Context) error { return errors.New("server selection timeout") }}},
+		fakeHealthCheckGateway{"postgres", func(ctx context.Context) error { return errors.New("connection refused") }},
+	}, time.Second)
+
+	report := usecase.Execute(context.Background())
+
+	assert.Equal(t, entity.HealthStatusDown, report.Status)
+	assert.False(t, report.IsReady())
+}
+
+func TestCheckReadinessUseCase_DependencyRestored(t *testing.T) {
+	var down bool
+	usecase := NewCheckReadinessUseCaseImpl([]interfaces.HealthCheckGateway{
+		fakeHealthCheckGateway{"postgres", func(ctx context.Context) error {
+			if down {
+				return errors.New("connection refused")
+			}
+			return nil
+		}},
+	}, time.Second)
+
+	down = true
+	assert.Equal(t, entity.HealthStatusDown, usecase.Execute(context.Background()).Status)
+	down = false
+	assert.Equal(t, entity.HealthStatusUp, usecase.Execute(context.Background()).Status)
+}
@@ -0,0 +1,12 @@
+package idempotency
+
+import (
+	"context"
+	entity "post-tech-challenge-10soat/internal/entities"
+)
+
+type BeginIdempotentRequestUseCase interface {
+	// Execute reserves the key and returns replay=false, or returns the stored
+	// response with replay=true when the same request was already completed.
+	Execute(ctx context.Context, request entity.IdempotencyRecord) (record entity.IdempotencyRecord, replay bool, err error)
+}
@@ -0,0 +1,68 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	entity "post-tech-challenge-10soat/internal/entities"
+	interfaces "post-tech-challenge-10soat/internal/interfaces/gateways"
+	"sync"
+	"time"
+)
+
+// purgeInterval bounds how often expired records are deleted, piggybacking on
+// incoming requests instead of running a dedicated job.
+const purgeInterval = time.Hour
+
+type BeginIdempotentRequestUseCaseImpl struct {
+	recordGateway interfaces.IdempotencyRecordGateway
+	ttl           time.Duration
+	purgeMutex    *sync.Mutex
+	lastPurge     *time.Time
+}
+
+func NewBeginIdempotentRequestUseCaseImpl(recordGateway interfaces.IdempotencyRecordGateway, ttl time.Duration) BeginIdempotentRequestUseCase {
+	return &BeginIdempotentRequestUseCaseImpl{
+		recordGateway,
+		ttl,
+		&sync.Mutex{},
+		&time.Time{},
+	}
+}
+
+func (s BeginIdempotentRequestUseCaseImpl) Execute(ctx context.Context, request entity.IdempotencyRecord) (entity.IdempotencyRecord, bool, error) {
+	s.purgeExpired(ctx)
+	request.ExpiresAt = time.Now().UTC().Add(s.ttl)
+	record, err := s.recordGateway.CreateIdempotencyRecord(ctx, request)
+	if err == nil {
+		return record, false, nil
+	}
+	if !errors.Is(err, entity.ErrConflictingData) {
+		return entity.IdempotencyRecord{}, false, fmt.Errorf("failed to reserve idempotency key - %w", err)
+	}
+	existing, err := s.recordGateway.GetIdempotencyRecord(ctx, request.Principal, request.Key)
+	if err != nil {
+		return entity.IdempotencyRecord{}, false, fmt.Errorf("failed to get idempotency key - %w", err)
+	}
+	if existing.RequestHash != request.RequestHash {
+		return entity.IdempotencyRecord{}, false, entity.NewDomainError(entity.ErrConflictingData, entity.ErrCodeIdempotencyKeyReused, "idempotency key was used with a different request")
+	}
+	if !existing.IsCompleted() {
+		return entity.IdempotencyRecord{}, false, entity.NewDomainError(entity.ErrConflictingData, entity.ErrCodeIdempotencyKeyInUse, "a request with this idempotency key is still being processed")
+	}
+	return existing, true, nil
+}
+
+func (s BeginIdempotentRequestUseCaseImpl) purgeExpired(ctx context.Context) {
+	s.purgeMutex.Lock()
+	if time.Since(*s.lastPurge) < purgeInterval {
+		s.purgeMutex.Unlock()
+		return
+	}
+	*s.lastPurge = time.Now()
+	s.purgeMutex.Unlock()
+	if err := s.recordGateway.DeleteExpiredIdempotencyRecords(ctx); err != nil {
+		slog.ErrorContext(ctx, "Error purging expired idempotency keys", "error", err)
+	}
+}
@@ -0,0 +1,79 @@
+package idempotency
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	entity "post-tech-challenge-10soat/internal/entities"
+	interfaces "post-tech-challenge-10soat/internal/interfaces/gateways"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mockRecordGateway struct {
+	interfaces.IdempotencyRecordGateway
+	existing *entity.IdempotencyRecord
+}
+
+func (m *mockRecordGateway) CreateIdempotencyRecord(ctx context.Context, record entity.IdempotencyRecord) (entity.IdempotencyRecord, error) {
+	if m.existing != nil {
+		return entity.IdempotencyRecord{}, entity.ErrConflictingData
+	}
+	record.Id = "r1"
+	return record, nil
+}
+
+func (m *mockRecordGateway) GetIdempotencyRecord(ctx context.Context, principal string, key string) (entity.IdempotencyRecord, error) {
+	return *m.existing, nil
+}
+
+func (m *mockRecordGateway) DeleteExpiredIdempotencyRecords(ctx context.Context) error {
+	return nil
+}
+
+func request() entity.IdempotencyRecord {
+	return entity.IdempotencyRecord{Principal: "api_key:k1", Key: "key-1", RequestHash: "hash-1"}
+}
+
+func TestBeginIdempotentRequestUseCaseImpl_Execute_Reserves(t *testing.T) {
+	usecase := NewBeginIdempotentRequestUseCaseImpl(&mockRecordGateway{}, time.Hour)
+
+	record, replay, err := usecase.Execute(context.Background(), request())
+
+	assert.NoError(t, err)
+	assert.False(t, replay)
+	assert.Equal(t, "r1", record.Id)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), record.ExpiresAt, time.Minute)
+}
+
+func TestBeginIdempotentRequestUseCaseImpl_Execute_Replays(t *testing.T) {
+	existing := request()
+	existing.StatusCode = 200
+	existing.CompletedAt = time.Now()
+	usecase := NewBeginIdempotentRequestUseCaseImpl(&mockRecordGateway{existing: &existing}, time.Hour)
+
+	record, replay, err := usecase.Execute(context.Background(), request())
+
+	assert.NoError(t, err)
+	assert.True(t, replay)
+	assert.Equal(t, 200, record.StatusCode)
+}
+
+func TestBeginIdempotentRequestUseCaseImpl_Execute_Conflicts(t *testing.T) {
+	differentBody := request()
+	differentBody.RequestHash = "hash-2"
+	differentBody.CompletedAt = time.Now()
+	inProgress := request()
+
+	for name, existing := range map[string]entity.IdempotencyRecord{"different body": differentBody, "in progress": inProgress} {
+		t.Run(name, func(t *testing.T) {
+			usecase := NewBeginIdempotentRequestUseCaseImpl(&mockRecordGateway{existing: &existing}, time.Hour)
+
+			_, replay, err := usecase.Execute(context.Background(), request())
+
+			assert.ErrorIs(t, err, entity.ErrConflictingData)
+			assert.False(t, replay)
+		})
+	}
+}
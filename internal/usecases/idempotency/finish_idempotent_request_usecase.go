@@ -0,0 +1,10 @@
+package idempotency
+
+import (
+	"context"
+	entity "post-tech-challenge-10soat/internal/entities"
+)
+
+type FinishIdempotentRequestUseCase interface {
+	Execute(ctx context.Context, record entity.IdempotencyRecord) error
+}
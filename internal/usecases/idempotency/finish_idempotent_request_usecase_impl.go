@@ -0,0 +1,34 @@
+package idempotency
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	entity "post-tech-challenge-10soat/internal/entities"
+	interfaces "post-tech-challenge-10soat/internal/interfaces/gateways"
+)
+
+type FinishIdempotentRequestUseCaseImpl struct {
+	recordGateway interfaces.IdempotencyRecordGateway
+}
+
+func NewFinishIdempotentRequestUseCaseImpl(recordGateway interfaces.IdempotencyRecordGateway) FinishIdempotentRequestUseCase {
+	return &FinishIdempotentRequestUseCaseImpl{
+		recordGateway,
+	}
+}
+
+// Execute stores the response for replays. Server errors release the key
+// instead, so the client can retry the request.
+func (s FinishIdempotentRequestUseCaseImpl) Execute(ctx context.Context, record entity.IdempotencyRecord) error {
+	if record.StatusCode >= http.StatusInternalServerError {
+		if err := s.recordGateway.DeleteIdempotencyRecord(ctx, record.Id); err != nil {
+			return fmt.Errorf("failed to release idempotency key - %w", err)
+		}
+		return nil
+	}
+	if err := s.recordGateway.CompleteIdempotencyRecord(ctx, record); err != nil {
+		return fmt.Errorf("failed to store idempotent response - %w", err)
+	}
+	return nil
+}
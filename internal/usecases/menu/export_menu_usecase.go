@@ -0,0 +1,10 @@
+package menu
+
+import (
+	"context"
+	entity "post-tech-challenge-10soat/internal/entities"
+)
+
+type ExportMenuUseCase interface {
+	Execute(ctx context.Context) (entity.Menu, error)
+}
@@ -0,0 +1,61 @@
+package menu
+
+import (
+	"context"
+	"fmt"
+	entity "post-tech-challenge-10soat/internal/entities"
+	interfaces "post-tech-challenge-10soat/internal/interfaces/gateways"
+	"sort"
+)
+
+type ExportMenuUseCaseImpl struct {
+	productGateway  interfaces.ProductGateway
+	categoryGateway interfaces.CategoryGateway
+}
+
+func NewExportMenuUseCaseImpl(
+	productGateway interfaces.ProductGateway,
+	categoryGateway interfaces.CategoryGateway,
+) ExportMenuUseCase {
+	return &ExportMenuUseCaseImpl{
+		productGateway,
+		categoryGateway,
+	}
+}
+
+// Execute lists every category by name and every product grouped by
+// category, in the order they were created, so the result can be imported
+// in another store.
+func (s ExportMenuUseCaseImpl) Execute(ctx context.Context) (entity.Menu, error) {
+	categories, err := s.categoryGateway.ListCategories(ctx)
+	if err != nil {
+		return entity.Menu{}, fmt.Errorf("cannot list categories - %w", err)
+	}
+	products, err := s.productGateway.ListProducts(ctx, "")
+	if err != nil {
+		return entity.Menu{}, fmt.Errorf("cannot list products - %w", err)
+	}
+	menu := entity.Menu{
+		Categories: make([]string, 0, len(categories)),
+		Items:      make([]entity.MenuItem, 0, len(products)),
+	}
+	categoryNames := make(map[string]string, len(categories))
+	for _, category := range categories {
+		categoryNames[category.Id] = category.Name
+		menu.Categories = append(menu.Categories, category.Name)
+	}
+	for _, product := range products {
+		menu.Items = append(menu.Items, entity.MenuItem{
+			Sku:         product.Sku,
+			Name:        product.Name,
+			Description: product.Description,
+			Image:       product.Image,
+			Value:       product.Value,
+			Category:    categoryNames[product.CategoryId],
+		})
+	}
+	sort.SliceStable(menu.Items, func(i, j int) bool {
+		return menu.Items[i].Category < menu.Items[j].Category
+	})
+	return menu, nil
+}
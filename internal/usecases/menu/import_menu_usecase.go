@@ -0,0 +1,10 @@
+package menu
+
+import (
+	"context"
+	entity "post-tech-challenge-10soat/internal/entities"
+)
+
+type ImportMenuUseCase interface {
+	Execute(ctx context.Context, menu entity.Menu, dryRun bool) (entity.MenuImport, error)
+}
@@ -0,0 +1,181 @@
+package menu
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/url"
+	entity "post-tech-challenge-10soat/internal/entities"
+	interfaces "post-tech-challenge-10soat/internal/interfaces/gateways"
+	"regexp"
+)
+
+const (
+	// maxMenuItems bounds the work of a single request.
+	maxMenuItems = 5000
+	// maxProductValue is the largest value a numeric(10, 2) column holds.
+	maxProductValue = 99_999_999.99
+)
+
+// skuPattern keeps SKUs easy to type in a spreadsheet.
+var skuPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+type ImportMenuUseCaseImpl struct {
+	productGateway  interfaces.ProductGateway
+	categoryGateway interfaces.CategoryGateway
+	menuGateway     interfaces.MenuGateway
+}
+
+func NewImportMenuUseCaseImpl(
+	productGateway interfaces.ProductGateway,
+	categoryGateway interfaces.CategoryGateway,
+	menuGateway interfaces.MenuGateway,
+) ImportMenuUseCase {
+	return &ImportMenuUseCaseImpl{
+		productGateway,
+		categoryGateway,
+		menuGateway,
+	}
+}
+
+// Execute compares every item with the product of the same SKU to report
+// whether it is created, updated or left unchanged, and then applies the
+// changes unless it is a dry run or any row is invalid. Categories missing
+// from the store are created.
+func (s ImportMenuUseCaseImpl) Execute(ctx context.Context, menu entity.Menu, dryRun bool) (entity.MenuImport, error) {
+	if len(menu.Items) == 0 && len(menu.Categories) == 0 {
+		return entity.MenuImport{}, fmt.Errorf("%w: the menu has no categories or products", entity.ErrInvalidData)
+	}
+	if len(menu.Items) > maxMenuItems {
+		return entity.MenuImport{}, fmt.Errorf("%w: the menu has more than %d products", entity.ErrInvalidData, maxMenuItems)
+	}
+
+	skus := make([]string, 0, len(menu.Items))
+	names := append([]string{}, menu.Categories...)
+	for _, item := range menu.Items {
+		skus = append(skus, item.Sku)
+		names = append(names, item.Category)
+	}
+	categories, err := s.categoryGateway.ListCategoriesByNames(ctx, names)
+	if err != nil {
+		return entity.MenuImport{}, fmt.Errorf("cannot list categories - %w", err)
+	}
+	products, err := s.productGateway.ListProductsBySkus(ctx, skus)
+	if err != nil {
+		return entity.MenuImport{}, fmt.Errorf("cannot list products - %w", err)
+	}
+	// Categories are listed oldest first, the one products end up in when
+	// names repeat.
+	categoryIds := make(map[string]string, len(categories))
+	for _, category := range categories {
+		if _, found := categoryIds[category.Name]; !found {
+			categoryIds[category.Name] = category.Id
+		}
+	}
+	productsBySku := make(map[string]entity.Product, len(products))
+	for _, product := range products {
+		productsBySku[product.Sku] = product
+	}
+
+	report := entity.MenuImport{DryRun: dryRun}
+	addCategory := func(name string) {
+		if _, found := categoryIds[name]; !found {
+			categoryIds[name] = ""
+			report.CreatedCategories = append(report.CreatedCategories, name)
+		}
+	}
+	for _, name := range menu.Categories {
+		addCategory(name)
+	}
+	var changes []entity.MenuItem
+	rowsBySku := make(map[string]int, len(menu.Items))
+	for _, item := range menu.Items {
+		row := entity.MenuImportRow{Row: item.Row, Sku: item.Sku, Errors: item.Errors}
+		// A row that could not be read is only reported as such.
+		if len(row.Errors) == 0 {
+			row.Errors = validateMenuItem(item)
+		}
+		if first, found := rowsBySku[item.Sku]; found && item.Sku != "" {
+			row.Errors = append(row.Errors, fmt.Sprintf("sku repeats the one in row %d", first))
+		} else {
+			rowsBySku[item.Sku] = item.Row
+		}
+		if len(row.Errors) > 0 {
+			row.Action = entity.MenuImportActionInvalid
+			report.Invalid++
+			report.Rows = append(report.Rows, row)
+			continue
+		}
+
+		addCategory(item.Category)
+		product, found := productsBySku[item.Sku]
+		switch {
+		case !found:
+			row.Action = entity.MenuImportActionCreate
+			report.Created++
+			changes = append(changes, item)
+		case changed(product, item, categoryIds[item.Category]):
+			row.Action = entity.MenuImportActionUpdate
+			report.Updated++
+			changes = append(changes, item)
+		default:
+			row.Action = entity.MenuImportActionUnchanged
+			report.Unchanged++
+		}
+		report.Rows = append(report.Rows, row)
+	}
+
+	if dryRun || report.Invalid > 0 {
+		return report, nil
+	}
+	if len(changes) > 0 || len(report.CreatedCategories) > 0 {
+		if err := s.menuGateway.ImportMenu(ctx, report.CreatedCategories, changes); err != nil {
+			return entity.MenuImport{}, fmt.Errorf("cannot import menu - %w", err)
+		}
+	}
+	report.Applied = true
+	slog.InfoContext(ctx, "Menu imported",
+		"categories_created", len(report.CreatedCategories),
+		"products_created", report.Created,
+		"products_updated", report.Updated,
+		"products_unchanged", report.Unchanged,
+	)
+	return report, nil
+}
+
+func validateMenuItem(item entity.MenuItem) []string {
+	var errs []string
+	if item.Sku == "" {
+		errs = append(errs, "sku is required")
+	} else if !skuPattern.MatchString(item.Sku) {
+		errs = append(errs, "sku must have up to 64 letters, digits, '.', '_' or '-'")
+	}
+	if item.Name == "" {
+		errs = append(errs, "name is required")
+	}
+	if item.Category == "" {
+		errs = append(errs, "category is required")
+	}
+	if item.Value <= 0 {
+		errs = append(errs, "value must be greater than 0")
+	} else if item.Value > maxProductValue {
+		errs = append(errs, fmt.Sprintf("value must be at most %.2f", maxProductValue))
+	}
+	if item.Image != "" {
+		image, err := url.ParseRequestURI(item.Image)
+		if err != nil || (image.Scheme != "http" && image.Scheme != "https") {
+			errs = append(errs, "image must be an http or https URL")
+		}
+	}
+	return errs
+}
+
+// changed compares values in cents, as the database keeps two decimals.
+func changed(product entity.Product, item entity.MenuItem, categoryId string) bool {
+	return product.Name != item.Name ||
+		product.Description != item.Description ||
+		product.Image != item.Image ||
+		math.Round(product.Value*100) != math.Round(item.Value*100) ||
+		product.CategoryId != categoryId
+}
@@ -0,0 +1,162 @@
+package menu
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	entity "post-tech-challenge-10soat/internal/entities"
+	interfaces "post-tech-challenge-10soat/internal/interfaces/gateways"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeProductGateway struct {
+	interfaces.ProductGateway
+	products []entity.Product
+}
+
+func (f fakeProductGateway) ListProductsBySkus(ctx context.Context, skus []string) ([]entity.Product, error) {
+	return f.products, nil
+}
+
+type fakeCategoryGateway struct {
+	interfaces.CategoryGateway
+	categories []entity.Category
+}
+
+func (f fakeCategoryGateway) ListCategoriesByNames(ctx context.Context, names []string) ([]entity.Category, error) {
+	return f.categories, nil
+}
+
+type fakeMenuGateway struct {
+	calls      int
+	categories []string
+	items      []entity.MenuItem
+	err        error
+}
+
+func (f *fakeMenuGateway) ImportMenu(ctx context.Context, categories []string, items []entity.MenuItem) error {
+	f.calls++
+	f.categories = categories
+	f.items = items
+	return f.err
+}
+
+func newImportMenuTestUseCase(menuGateway *fakeMenuGateway) ImportMenuUseCase {
+	return NewImportMenuUseCaseImpl(
+		fakeProductGateway{products: []entity.Product{
+			{Id: "p1", Sku: "LANCHE-1", Name: "Lanche 1", Description: "Lanche com bacon", Value: 15.9, CategoryId: "c1"},
+			{Id: "p2", Sku: "BEBIDA-1", Name: "Bebida 1", Value: 12.9, CategoryId: "c2"},
+		}},
+		fakeCategoryGateway{categories: []entity.Category{
+			{Id: "c1", Name: "Lanche"},
+			{Id: "c2", Name: "Bebida"},
+		}},
+		menuGateway,
+	)
+}
+
+func newImportTestMenu() entity.Menu {
+	return entity.Menu{
+		Categories: []string{"Lanche", "Bebida"},
+		Items: []entity.MenuItem{
+			{Row: 2, Sku: "LANCHE-1", Name: "Lanche 1", Description: "Lanche com bacon", Value: 15.90, Category: "Lanche"},
+			{Row: 3, Sku: "BEBIDA-1", Name: "Bebida 1", Value: 13.90, Category: "Bebida"},
+			{Row: 4, Sku: "SOBREMESA-1", Name: "Sobremesa 1", Value: 18.90, Category: "Sobremesa"},
+		},
+	}
+}
+
+func TestImportMenuUseCase_AppliesChanges(t *testing.T) {
+	// Setup
+	menuGateway := &fakeMenuGateway{}
+	usecase := newImportMenuTestUseCase(menuGateway)
+
+	// Act
+	report, err := usecase.Execute(context.Background(), newImportTestMenu(), false)
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, report.Applied)
+	assert.Equal(t, []string{"Sobremesa"}, report.CreatedCategories)
+	assert.Equal(t, 1, report.Created)
+	assert.Equal(t, 1, report.Updated)
+	assert.Equal(t, 1, report.Unchanged)
+	assert.Equal(t, []entity.MenuImportRow{
+		{Row: 2, Sku: "LANCHE-1", Action: entity.MenuImportActionUnchanged},
+		{Row: 3, Sku: "BEBIDA-1", Action: entity.MenuImportActionUpdate},
+		{Row: 4, Sku: "SOBREMESA-1", Action: entity.MenuImportActionCreate},
+	}, report.Rows)
+	assert.Equal(t, 1, menuGateway.calls)
+	assert.Equal(t, []string{"Sobremesa"}, menuGateway.categories)
+	assert.Len(t, menuGateway.items, 2)
+}
+
+func TestImportMenuUseCase_DryRunDoesNotApply(t *testing.T) {
+	// Setup
+	menuGateway := &fakeMenuGateway{}
+	usecase := newImportMenuTestUseCase(menuGateway)
+
+	// Act
+	report, err := usecase.Execute(context.Background(), newImportTestMenu(), true)
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, report.DryRun)
+	assert.False(t, report.Applied)
+	assert.Equal(t, 1, report.Created)
+	assert.Zero(t, menuGateway.calls)
+}
+
+func TestImportMenuUseCase_ReportsInvalidRows(t *testing.T) {
+	// Setup
+	menuGateway := &fakeMenuGateway{}
+	usecase := newImportMenuTestUseCase(menuGateway)
+	menu := newImportTestMenu()
+	menu.Items = append(menu.Items,
+		entity.MenuItem{Row: 5, Sku: "LANCHE 2", Value: 0, Category: "Lanche", Image: "ftp://imagens/lanche.png"},
+		entity.MenuItem{Row: 6, Sku: "LANCHE-1", Name: "Lanche 1", Value: 15.9, Category: "Lanche"},
+		entity.MenuItem{Row: 7, Sku: "LANCHE-3", Errors: []string{"value is not a number"}},
+	)
+
+	// Act
+	report, err := usecase.Execute(context.Background(), menu, false)
+
+	// Assert
+	require.NoError(t, err)
+	assert.False(t, report.Applied)
+	assert.Equal(t, 3, report.Invalid)
+	assert.Equal(t, []string{
+		"sku must have up to 64 letters, digits, '.', '_' or '-'",
+		"name is required",
+		"value must be greater than 0",
+		"image must be an http or https URL",
+	}, report.Rows[3].Errors)
+	assert.Equal(t, []string{"sku repeats the one in row 2"}, report.Rows[4].Errors)
+	assert.Equal(t, []string{"value is not a number"}, report.Rows[5].Errors)
+	assert.Zero(t, menuGateway.calls)
+}
+
+func TestImportMenuUseCase_EmptyMenu(t *testing.T) {
+	// Setup
+	usecase := newImportMenuTestUseCase(&fakeMenuGateway{})
+
+	// Act
+	_, err := usecase.Execute(context.Background(), entity.Menu{}, false)
+
+	// Assert
+	assert.ErrorIs(t, err, entity.ErrInvalidData)
+}
+
+func TestImportMenuUseCase_GatewayError(t *testing.T) {
+	// Setup
+	usecase := newImportMenuTestUseCase(&fakeMenuGateway{err: errors.New("connection refused")})
+
+	// Act
+	_, err := usecase.Execute(context.Background(), newImportTestMenu(), false)
+
+	// Assert
+	assert.ErrorContains(t, err, "cannot import menu - connection refused")
+}
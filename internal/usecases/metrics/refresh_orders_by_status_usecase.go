@@ -0,0 +1,7 @@
+package metrics
+
+import "context"
+
+type RefreshOrdersByStatusUseCase interface {
+	Execute(ctx context.Context) error
+}
@@ -0,0 +1,39 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	entity "post-tech-challenge-10soat/internal/entities"
+	interfaces "post-tech-challenge-10soat/internal/interfaces/gateways"
+)
+
+type RefreshOrdersByStatusUseCaseImpl struct {
+	orderGateway   interfaces.OrderGateway
+	metricsGateway interfaces.MetricsGateway
+}
+
+func NewRefreshOrdersByStatusUseCaseImpl(
+	orderGateway interfaces.OrderGateway,
+	metricsGateway interfaces.MetricsGateway,
+) RefreshOrdersByStatusUseCase {
+	return &RefreshOrdersByStatusUseCaseImpl{
+		orderGateway,
+		metricsGateway,
+	}
+}
+
+// Execute counts the orders in each status and publishes the counts, with
+// zero for statuses without orders so a drained status does not keep its
+// last value.
+func (u RefreshOrdersByStatusUseCaseImpl) Execute(ctx context.Context) error {
+	counts, err := u.orderGateway.CountOrdersByStatus(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot count orders by status - %w", err)
+	}
+	ordersByStatus := make(map[entity.OrderStatus]int, len(entity.OrderStatuses))
+	for _, status := range entity.OrderStatuses {
+		ordersByStatus[status] = counts[status]
+	}
+	u.metricsGateway.SetOrdersByStatus(ordersByStatus)
+	return nil
+}
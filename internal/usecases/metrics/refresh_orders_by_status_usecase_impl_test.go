@@ -0,0 +1,69 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	entity "post-tech-challenge-10soat/internal/entities"
+	interfaces "post-tech-challenge-10soat/internal/interfaces/gateways"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeOrderGateway struct {
+	interfaces.OrderGateway
+	counts map[entity.OrderStatus]int
+	err    error
+}
+
+func (f fakeOrderGateway) CountOrdersByStatus(ctx context.Context) (map[entity.OrderStatus]int, error) {
+	return f.counts, f.err
+}
+
+type fakeMetricsGateway struct {
+	ordersByStatus map[entity.OrderStatus]int
+}
+
+func (f *fakeMetricsGateway) ObserveHttpRequest(method string, route string, status int, duration time.Duration) {
+}
+
+func (f *fakeMetricsGateway) OrderCreated() {}
+
+func (f *fakeMetricsGateway) OrderStatusChanged(from entity.OrderStatus, to entity.OrderStatus) {}
+
+func (f *fakeMetricsGateway) PaymentFinished(approved bool) {}
+
+func (f *fakeMetricsGateway) SetOrdersByStatus(counts map[entity.OrderStatus]int) {
+	f.ordersByStatus = counts
+}
+
+func TestRefreshOrdersByStatusUseCase_FillsMissingStatuses(t *testing.T) {
+	metricsGateway := &fakeMetricsGateway{}
+	usecase := NewRefreshOrdersByStatusUseCaseImpl(fakeOrderGateway{counts: map[entity.OrderStatus]int{
+		entity.OrderStatusReceived:  3,
+		entity.OrderStatusPreparing: 1,
+	}}, metricsGateway)
+
+	err := usecase.Execute(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[entity.OrderStatus]int{
+		entity.OrderStatusPaymentPending: 0,
+		entity.OrderStatusReceived:       3,
+		entity.OrderStatusPreparing:      1,
+		entity.OrderStatusReady:          0,
+		entity.OrderStatusCompleted:      0,
+	}, metricsGateway.ordersByStatus)
+}
+
+func TestRefreshOrdersByStatusUseCase_KeepsLastValuesOnError(t *testing.T) {
+	metricsGateway := &fakeMetricsGateway{}
+	usecase := NewRefreshOrdersByStatusUseCaseImpl(fakeOrderGateway{err: errors.New("connection refused")}, metricsGateway)
+
+	err := usecase.Execute(context.Background())
+
+	assert.ErrorContains(t, err, "connection refused")
+	assert.Nil(t, metricsGateway.ordersByStatus)
+}
@@ -0,0 +1,51 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	entity "post-tech-challenge-10soat/internal/entities"
+	interfaces "post-tech-challenge-10soat/internal/interfaces/gateways"
+)
+
+// ConsentNotifier only lets a notification through when the client has
+// granted the consent its event requires. Refusals are reported as
+// entity.ErrNotificationSkipped so they are recorded as skipped deliveries.
+type ConsentNotifier struct {
+	interfaces.Notifier
+	consentGateway interfaces.ClientConsentGateway
+}
+
+func NewConsentNotifier(notifier interfaces.Notifier, consentGateway interfaces.ClientConsentGateway) interfaces.Notifier {
+	return &ConsentNotifier{
+		notifier,
+		consentGateway,
+	}
+}
+
+// RequireConsent wraps every notifier so no channel can bypass the check.
+func RequireConsent(notifiers []interfaces.Notifier, consentGateway interfaces.ClientConsentGateway) []interfaces.Notifier {
+	guarded := make([]interfaces.Notifier, 0, len(notifiers))
+	for _, notifier := range notifiers {
+		guarded = append(guarded, NewConsentNotifier(notifier, consentGateway))
+	}
+	return guarded
+}
+
+func (n ConsentNotifier) Send(ctx context.Context, notification entity.Notification) error {
+	purpose, required := notification.Event.ConsentPurpose()
+	if !required {
+		return n.Notifier.Send(ctx, notification)
+	}
+	consent, err := n.consentGateway.GetClientConsent(ctx, notification.Client.Id, purpose)
+	if err != nil {
+		if errors.Is(err, entity.ErrDataNotFound) {
+			return fmt.Errorf("%w: no %s consent", entity.ErrNotificationSkipped, purpose)
+		}
+		return fmt.Errorf("cannot check client consent - %w", err)
+	}
+	if !consent.Granted {
+		return fmt.Errorf("%w: %s consent revoked", entity.ErrNotificationSkipped, purpose)
+	}
+	return n.Notifier.Send(ctx, notification)
+}
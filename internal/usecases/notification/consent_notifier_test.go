@@ -0,0 +1,98 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	entity "post-tech-challenge-10soat/internal/entities"
+	interfaces "post-tech-challenge-10soat/internal/interfaces/gateways"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mockConsentGateway struct {
+	interfaces.ClientConsentGateway
+	GetClientConsentFunc func(ctx context.Context, clientId string, purpose entity.ConsentPurpose) (entity.ClientConsent, error)
+}
+
+func (m *mockConsentGateway) GetClientConsent(ctx context.Context, clientId string, purpose entity.ConsentPurpose) (entity.ClientConsent, error) {
+	return m.GetClientConsentFunc(ctx, clientId, purpose)
+}
+
+func newConsentGateway(consent entity.ClientConsent, err error) *mockConsentGateway {
+	return &mockConsentGateway{
+		GetClientConsentFunc: func(ctx context.Context, clientId string, purpose entity.ConsentPurpose) (entity.ClientConsent, error) {
+			return consent, err
+		},
+	}
+}
+
+func TestConsentNotifier_Send_Granted(t *testing.T) {
+	email := &mockNotifier{channel: entity.NotificationChannelEmail}
+	consents := newConsentGateway(entity.ClientConsent{Purpose: entity.ConsentPurposeOrderNotifications, Granted: true}, nil)
+	notifier := NewConsentNotifier(email, consents)
+
+	err := notifier.Send(context.Background(), entity.Notification{Event: entity.NotificationEventOrderReady, Client: entity.Client{Id: "c1"}})
+
+	assert.NoError(t, err)
+	assert.Len(t, email.sent, 1)
+	assert.Equal(t, entity.NotificationChannelEmail, notifier.Channel())
+}
+
+func TestConsentNotifier_Send_Revoked(t *testing.T) {
+	email := &mockNotifier{channel: entity.NotificationChannelEmail}
+	consents := newConsentGateway(entity.ClientConsent{Purpose: entity.ConsentPurposeOrderNotifications, Granted: false}, nil)
+	notifier := NewConsentNotifier(email, consents)
+
+	err := notifier.Send(context.Background(), entity.Notification{Event: entity.NotificationEventOrderReady, Client: entity.Client{Id: "c1"}})
+
+	assert.ErrorIs(t, err, entity.ErrNotificationSkipped)
+	assert.Empty(t, email.sent)
+}
+
+func TestConsentNotifier_Send_NeverGranted(t *testing.T) {
+	email := &mockNotifier{channel: entity.NotificationChannelEmail}
+	notifier := NewConsentNotifier(email, newConsentGateway(entity.ClientConsent{}, entity.ErrDataNotFound))
+
+	err := notifier.Send(context.Background(), entity.Notification{Event: entity.NotificationEventOrderPreparing, Client: entity.Client{Id: "c1"}})
+
+	assert.ErrorIs(t, err, entity.ErrNotificationSkipped)
+	assert.Empty(t, email.sent)
+}
+
+func TestConsentNotifier_Send_LookupFails(t *testing.T) {
+	email := &mockNotifier{channel: entity.NotificationChannelEmail}
+	notifier := NewConsentNotifier(email, newConsentGateway(entity.ClientConsent{}, errors.New("connection refused")))
+
+	err := notifier.Send(context.Background(), entity.Notification{Event: entity.NotificationEventOrderReady, Client: entity.Client{Id: "c1"}})
+
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, entity.ErrNotificationSkipped)
+	assert.Empty(t, email.sent)
+}
+
+func TestConsentNotifier_Send_NoConsentRequired(t *testing.T) {
+	email := &mockNotifier{channel: entity.NotificationChannelEmail}
+	notifier := NewConsentNotifier(email, &mockConsentGateway{})
+
+	err := notifier.Send(context.Background(), entity.Notification{Event: entity.NotificationEventIdentificationCode, Client: entity.Client{Id: "c1"}})
+
+	assert.NoError(t, err)
+	assert.Len(t, email.sent, 1)
+}
+
+func TestNotifyOrderStatusUseCaseImpl_Execute_WithoutConsent(t *testing.T) {
+	client := entity.Client{Id: "c1", Name: "Maria", Email: "maria@email.com"}
+	deliveries := &mockDeliveryGateway{}
+	email := &mockNotifier{channel: entity.NotificationChannelEmail}
+	notifiers := RequireConsent([]interfaces.Notifier{email}, newConsentGateway(entity.ClientConsent{}, entity.ErrDataNotFound))
+	usecase := NewNotifyOrderStatusUseCaseImpl(newClientGateway(client), deliveries, notifiers, "pt-BR")
+
+	err := usecase.Execute(context.Background(), entity.Order{Id: "o1", Number: 42, ClientId: "c1", Status: entity.OrderStatusReady})
+
+	assert.NoError(t, err)
+	assert.Empty(t, email.sent)
+	assert.Len(t, deliveries.deliveries, 1)
+	assert.Equal(t, entity.NotificationDeliverySkipped, deliveries.deliveries[0].Status)
+}
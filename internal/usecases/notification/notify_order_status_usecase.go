@@ -0,0 +1,10 @@
+package notification
+
+import (
+	"context"
+	entity "post-tech-challenge-10soat/internal/entities"
+)
+
+type NotifyOrderStatusUseCase interface {
+	Execute(ctx context.Context, order entity.Order) error
+}
@@ -0,0 +1,89 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	entity "post-tech-challenge-10soat/internal/entities"
+	interfaces "post-tech-challenge-10soat/internal/interfaces/gateways"
+	"time"
+)
+
+var orderStatusEvents = map[entity.OrderStatus]entity.NotificationEvent{
+	entity.OrderStatusPreparing: entity.NotificationEventOrderPreparing,
+	entity.OrderStatusReady:     entity.NotificationEventOrderReady,
+}
+
+type NotifyOrderStatusUseCaseImpl struct {
+	clientGateway   interfaces.ClientGateway
+	deliveryGateway interfaces.NotificationDeliveryGateway
+	notifiers       []interfaces.Notifier
+	defaultLanguage string
+}
+
+func NewNotifyOrderStatusUseCaseImpl(
+	clientGateway interfaces.ClientGateway,
+	deliveryGateway interfaces.NotificationDeliveryGateway,
+	notifiers []interfaces.Notifier,
+	defaultLanguage string,
+) NotifyOrderStatusUseCase {
+	return &NotifyOrderStatusUseCaseImpl{
+		clientGateway,
+		deliveryGateway,
+		notifiers,
+		defaultLanguage,
+	}
+}
+
+func (u NotifyOrderStatusUseCaseImpl) Execute(ctx context.Context, order entity.Order) error {
+	event, ok := orderStatusEvents[order.Status]
+	if !ok || order.ClientId == "" {
+		return nil
+	}
+	client, err := u.clientGateway.GetClientById(ctx, order.ClientId)
+	if err != nil {
+		return fmt.Errorf("cannot notify client of order - %w", err)
+	}
+	language, subject, body, err := Render(event, []string{client.Language, u.defaultLanguage}, TemplateData{
+		ClientName:  client.Name,
+		OrderNumber: order.Number,
+	})
+	if err != nil {
+		return err
+	}
+	notification := entity.Notification{
+		Event:       event,
+		OrderId:     order.Id,
+		OrderNumber: order.Number,
+		Client:      client,
+		Language:    language,
+		Subject:     subject,
+		Body:        body,
+		OccurredAt:  time.Now(),
+	}
+	var errs []error
+	for _, notifier := range u.notifiers {
+		delivery := entity.NotificationDelivery{
+			OrderId:   order.Id,
+			ClientId:  client.Id,
+			Event:     event,
+			Channel:   notifier.Channel(),
+			Recipient: notifier.Recipient(notification),
+			Status:    entity.NotificationDeliverySent,
+		}
+		if err := notifier.Send(ctx, notification); err != nil {
+			delivery.Status = entity.NotificationDeliveryFailed
+			delivery.Error = err.Error()
+			if errors.Is(err, entity.ErrNotificationSkipped) {
+				delivery.Status = entity.NotificationDeliverySkipped
+			} else {
+				errs = append(errs, fmt.Errorf("%s channel - %w", notifier.Channel(), err))
+			}
+		}
+		if _, err := u.deliveryGateway.CreateNotificationDelivery(ctx, delivery); err != nil {
+			slog.ErrorContext(ctx, "Error recording notification delivery", "channel", delivery.Channel, "order_id", order.Id, "error", err)
+		}
+	}
+	return errors.Join(errs...)
+}
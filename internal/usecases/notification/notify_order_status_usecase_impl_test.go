@@ -0,0 +1,124 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	entity "post-tech-challenge-10soat/internal/entities"
+	interfaces "post-tech-challenge-10soat/internal/interfaces/gateways"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mockClientGateway struct {
+	interfaces.ClientGateway
+	GetClientByIdFunc func(ctx context.Context, id string) (entity.Client, error)
+}
+
+func (m *mockClientGateway) GetClientById(ctx context.Context, id string) (entity.Client, error) {
+	return m.GetClientByIdFunc(ctx, id)
+}
+
+type mockDeliveryGateway struct {
+	deliveries []entity.NotificationDelivery
+}
+
+func (m *mockDeliveryGateway) CreateNotificationDelivery(ctx context.Context, delivery entity.NotificationDelivery) (entity.NotificationDelivery, error) {
+	m.deliveries = append(m.deliveries, delivery)
+	return delivery, nil
+}
+
+type mockNotifier struct {
+	channel string
+	err     error
+	sent    []entity.Notification
+}
+
+func (m *mockNotifier) Channel() string {
+	return m.channel
+}
+
+func (m *mockNotifier) Recipient(notification entity.Notification) string {
+	return notification.Client.Email
+}
+
+func (m *mockNotifier) Send(ctx context.Context, notification entity.Notification) error {
+	m.sent = append(m.sent, notification)
+	return m.err
+}
+
+func newClientGateway(client entity.Client) *mockClientGateway {
+	return &mockClientGateway{
+		GetClientByIdFunc: func(ctx context.Context, id string) (entity.Client, error) {
+			return client, nil
+		},
+	}
+}
+
+func TestNotifyOrderStatusUseCaseImpl_Execute_OrderReady(t *testing.T) {
+	client := entity.Client{Id: "c1", Name: "Maria", Email: "maria@email.com", Language: "en"}
+	deliveries := &mockDeliveryGateway{}
+	email := &mockNotifier{channel: entity.NotificationChannelEmail}
+	log := &mockNotifier{channel: entity.NotificationChannelLog}
+	usecase := NewNotifyOrderStatusUseCaseImpl(newClientGateway(client), deliveries, []interfaces.Notifier{email, log}, "pt-BR")
+
+	err := usecase.Execute(context.Background(), entity.Order{Id: "o1", Number: 42, ClientId: "c1", Status: entity.OrderStatusReady})
+
+	assert.NoError(t, err)
+	assert.Len(t, email.sent, 1)
+	assert.Len(t, log.sent, 1)
+	assert.Equal(t, entity.NotificationEventOrderReady, email.sent[0].Event)
+	assert.Equal(t, "en", email.sent[0].Language)
+	assert.Equal(t, "Order #42 is ready!", email.sent[0].Subject)
+	assert.Contains(t, email.sent[0].Body, "Hi Maria")
+	assert.Len(t, deliveries.deliveries, 2)
+	for _, delivery := range deliveries.deliveries {
+		assert.Equal(t, entity.NotificationDeliverySent, delivery.Status)
+		assert.Equal(t, "o1", delivery.OrderId)
+	}
+}
+
+func TestNotifyOrderStatusUseCaseImpl_Execute_FallbackLanguage(t *testing.T) {
+	client := entity.Client{Id: "c1", Name: "Maria", Language: "fr"}
+	notifier := &mockNotifier{channel: entity.NotificationChannelLog}
+	usecase := NewNotifyOrderStatusUseCaseImpl(newClientGateway(client), &mockDeliveryGateway{}, []interfaces.Notifier{notifier}, "es")
+
+	err := usecase.Execute(context.Background(), entity.Order{Id: "o1", Number: 7, ClientId: "c1", Status: entity.OrderStatusReady})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "es", notifier.sent[0].Language)
+	assert.Equal(t, "¡Pedido #7 listo!", notifier.sent[0].Subject)
+}
+
+func TestNotifyOrderStatusUseCaseImpl_Execute_RecordsFailures(t *testing.T) {
+	client := entity.Client{Id: "c1", Name: "Maria"}
+	deliveries := &mockDeliveryGateway{}
+	email := &mockNotifier{channel: entity.NotificationChannelEmail, err: entity.ErrNotificationSkipped}
+	webhook := &mockNotifier{channel: entity.NotificationChannelWebhook, err: errors.New("connection refused")}
+	usecase := NewNotifyOrderStatusUseCaseImpl(newClientGateway(client), deliveries, []interfaces.Notifier{email, webhook}, "pt-BR")
+
+	err := usecase.Execute(context.Background(), entity.Order{Id: "o1", ClientId: "c1", Status: entity.OrderStatusReady})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "connection refused")
+	assert.Len(t, deliveries.deliveries, 2)
+	assert.Equal(t, entity.NotificationDeliverySkipped, deliveries.deliveries[0].Status)
+	assert.Equal(t, entity.NotificationDeliveryFailed, deliveries.deliveries[1].Status)
+	assert.Equal(t, "connection refused", deliveries.deliveries[1].Error)
+}
+
+func TestNotifyOrderStatusUseCaseImpl_Execute_IgnoresAnonymousAndUnmappedStatus(t *testing.T) {
+	notifier := &mockNotifier{channel: entity.NotificationChannelLog}
+	clientGateway := &mockClientGateway{
+		GetClientByIdFunc: func(ctx context.Context, id string) (entity.Client, error) {
+			t.Fatal("client should not be loaded")
+			return entity.Client{}, nil
+		},
+	}
+	usecase := NewNotifyOrderStatusUseCaseImpl(clientGateway, &mockDeliveryGateway{}, []interfaces.Notifier{notifier}, "pt-BR")
+
+	assert.NoError(t, usecase.Execute(context.Background(), entity.Order{Id: "o1", Status: entity.OrderStatusReady}))
+	assert.NoError(t, usecase.Execute(context.Background(), entity.Order{Id: "o2", ClientId: "c1", Status: entity.OrderStatusCompleted}))
+	assert.Empty(t, notifier.sent)
+}
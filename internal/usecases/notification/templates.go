@@ -0,0 +1,104 @@
+package notification
+
+import (
+	"bytes"
+	"fmt"
+	entity "post-tech-challenge-10soat/internal/entities"
+	"text/template"
+)
+
+const fallbackLanguage = "pt-BR"
+
+type messageTemplate struct {
+	Subject string
+	Body    string
+}
+
+type TemplateData struct {
+	ClientName       string
+	OrderNumber      int
+	Code             string
+	ExpiresInMinutes int
+}
+
+var templates = map[entity.NotificationEvent]map[string]messageTemplate{
+	entity.NotificationEventOrderPreparing: {
+		"pt-BR": {
+			Subject: "Pedido #{{.OrderNumber}} em preparação",
+			Body:    "Olá {{.ClientName}}, seu pedido #{{.OrderNumber}} já está sendo preparado.",
+		},
+		"en": {
+			Subject: "Order #{{.OrderNumber}} is being prepared",
+			Body:    "Hi {{.ClientName}}, your order #{{.OrderNumber}} is being prepared.",
+		},
+		"es": {
+			Subject: "Pedido #{{.OrderNumber}} en preparación",
+			Body:    "Hola {{.ClientName}}, tu pedido #{{.OrderNumber}} ya se está preparando.",
+		},
+	},
+	entity.NotificationEventOrderReady: {
+		"pt-BR": {
+			Subject: "Pedido #{{.OrderNumber}} pronto!",
+			Body:    "Olá {{.ClientName}}, seu pedido #{{.OrderNumber}} está pronto. Retire no balcão.",
+		},
+		"en": {
+			Subject: "Order #{{.OrderNumber}} is ready!",
+			Body:    "Hi {{.ClientName}}, your order #{{.OrderNumber}} is ready. Please pick it up at the counter.",
+		},
+		"es": {
+			Subject: "¡Pedido #{{.OrderNumber}} listo!",
+			Body:    "Hola {{.ClientName}}, tu pedido #{{.OrderNumber}} está listo. Retíralo en el mostrador.",
+		},
+	},
+	entity.NotificationEventIdentificationCode: {
+		"pt-BR": {
+			Subject: "Seu código de identificação",
+			Body:    "Olá {{.ClientName}}, seu código de identificação é {{.Code}}. Ele expira em {{.ExpiresInMinutes}} minutos.",
+		},
+		"en": {
+			Subject: "Your identification code",
+			Body:    "Hi {{.ClientName}}, your identification code is {{.Code}}. It expires in {{.ExpiresInMinutes}} minutes.",
+		},
+		"es": {
+			Subject: "Tu código de identificación",
+			Body:    "Hola {{.ClientName}}, tu código de identificación es {{.Code}}. Expira en {{.ExpiresInMinutes}} minutos.",
+		},
+	},
+}
+
+// Render picks the template for the event in the requested language, falling
+// back to the default language and then to pt-BR.
+func Render(event entity.NotificationEvent, languages []string, data TemplateData) (string, string, string, error) {
+	byLanguage, ok := templates[event]
+	if !ok {
+		return "", "", "", fmt.Errorf("no template for event '%s'", event)
+	}
+	for _, language := range append(languages, fallbackLanguage) {
+		tmpl, ok := byLanguage[language]
+		if !ok {
+			continue
+		}
+		subject, err := execute(tmpl.Subject, data)
+		if err != nil {
+			return "", "", "", err
+		}
+		body, err := execute(tmpl.Body, data)
+		if err != nil {
+			return "", "", "", err
+		}
+		return language, subject, body, nil
+	}
+	return "", "", "", fmt.Errorf("no template for event '%s'", event)
+}
+
+func execute(text string, data TemplateData) (string, error) {
+	tmpl, err := template.New("notification").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
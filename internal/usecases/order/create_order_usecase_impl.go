@@ -2,99 +2,88 @@ package order
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	dto "post-tech-challenge-10soat/internal/dto/order"
 	entity "post-tech-challenge-10soat/internal/entities"
 	interfaces "post-tech-challenge-10soat/internal/interfaces/gateways"
-
-	"github.com/google/uuid"
 )
 
 type CreateOrderUsecaseImpl struct {
-	productGateway      interfaces.ProductGateway
-	clientGateway       interfaces.ClientGateway
-	orderGateway        interfaces.OrderGateway
-	orderProductGateway interfaces.OrderProductGateway
+	productGateway interfaces.ProductGateway
+	clientGateway  interfaces.ClientGateway
+	orderGateway   interfaces.OrderGateway
+	metricsGateway interfaces.MetricsGateway
 }
 
 func NewCreateOrderUsecaseImpl(
 	productGateway interfaces.ProductGateway,
 	clientGateway interfaces.ClientGateway,
 	orderGateway interfaces.OrderGateway,
-	orderProductGateway interfaces.OrderProductGateway,
+	metricsGateway interfaces.MetricsGateway,
 ) CreateOrderUseCase {
 	return &CreateOrderUsecaseImpl{
 		productGateway,
 		clientGateway,
 		orderGateway,
-		orderProductGateway,
+		metricsGateway,
 	}
 }
 
 func (s CreateOrderUsecaseImpl) Execute(ctx context.Context, createOrder dto.CreateOrderDTO) (entity.Order, error) {
 	var totalValue float64
+	orderProducts := make([]entity.OrderProduct, 0, len(createOrder.Products))
 	for _, orderProduct := range createOrder.Products {
 		product, err := s.productGateway.GetProductById(ctx, orderProduct.ProductId)
 		if err != nil {
-			if err == entity.ErrDataNotFound {
-				return entity.Order{}, err
+			if errors.Is(err, entity.ErrDataNotFound) {
+				return entity.Order{}, entity.ErrProductNotFound
 			}
-			return entity.Order{}, fmt.Errorf("cannot create order because has invalid product - %s", err.Error())
+			return entity.Order{}, fmt.Errorf("cannot create order because has invalid product - %w", err)
 		}
 		subTotal := product.Value * float64(orderProduct.Quantity)
 		totalValue += subTotal
+		orderProducts = append(orderProducts, entity.OrderProduct{
+			ProductId:   product.Id,
+			Quantity:    orderProduct.Quantity,
+			SubTotal:    subTotal,
+			Observation: orderProduct.Observation,
+		})
 	}
 
 	orderInfo := entity.Order{
 		Status: entity.OrderStatusPaymentPending,
 		Total:  totalValue,
 	}
-	if createOrder.ClientId != "" && uuid.Validate(createOrder.ClientId) == nil {
+	if createOrder.ClientId != "" {
+		// Only an identified customer can attach an order to itself.
+		session, ok := entity.SessionFromContext(ctx)
+		if !ok {
+			return entity.Order{}, entity.ErrUnauthorized
+		}
+		if session.Role != entity.RoleCustomer || session.Subject != createOrder.ClientId {
+			return entity.Order{}, entity.ErrForbidden
+		}
 		client, err := s.clientGateway.GetClientById(ctx, createOrder.ClientId)
 		if err != nil {
-			if err == entity.ErrDataNotFound {
-				return entity.Order{}, err
+			if errors.Is(err, entity.ErrDataNotFound) {
+				return entity.Order{}, entity.ErrClientNotFound
 			}
-			return entity.Order{}, fmt.Errorf("cannot create order because has invalid client - %s", err.Error())
+			return entity.Order{}, fmt.Errorf("cannot create order because has invalid client - %w", err)
 		}
 		orderInfo.ClientId = client.Id
 	} else {
 		orderInfo.ClientId = ""
 	}
-	order, err := s.orderGateway.CreateOrder(ctx, orderInfo)
+	// The order and its products are written together, so a product
+	// deleted meanwhile fails the whole order instead of leaving it empty.
+	order, err := s.orderGateway.CreateOrder(ctx, orderInfo, orderProducts)
 	if err != nil {
-		if err == entity.ErrDataNotFound {
-			return entity.Order{}, err
-		}
-		return entity.Order{}, fmt.Errorf("cannot create order - %s", err.Error())
-	}
-	for _, orderProduct := range createOrder.Products {
-		product, err := s.productGateway.GetProductById(ctx, orderProduct.ProductId)
-		if err != nil {
-			if err == entity.ErrDataNotFound {
-				return entity.Order{}, err
-			}
-			return entity.Order{}, fmt.Errorf("cannot create order because has invalid product - %s", err.Error())
-		}
-		subTotal := product.Value * float64(orderProduct.Quantity)
-		orderProductInfo := entity.OrderProduct{
-			OrderId:     order.Id,
-			ProductId:   product.Id,
-			Quantity:    orderProduct.Quantity,
-			SubTotal:    subTotal,
-			Observation: orderProduct.Observation,
-		}
-		_, err = s.orderProductGateway.CreateOrderProduct(ctx, orderProductInfo)
-		if err != nil {
-			if err == entity.ErrDataNotFound {
-				return entity.Order{}, err
-			}
-			err := s.orderGateway.DeleteOrder(ctx, order.Id)
-			if err != nil {
-				return entity.Order{}, err
-			}
-			return entity.Order{}, fmt.Errorf("cannot complete order - %s", err.Error())
+		if errors.Is(err, entity.ErrDataNotFound) {
+			return entity.Order{}, entity.ErrProductNotFound
 		}
+		return entity.Order{}, fmt.Errorf("cannot create order - %w", err)
 	}
+	s.metricsGateway.OrderCreated()
 	return order, nil
 }
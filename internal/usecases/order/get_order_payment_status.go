@@ -2,6 +2,7 @@ package order
 
 import (
 	"context"
+	entity "post-tech-challenge-10soat/internal/entities"
 )
 
 type PaymentStatus string
@@ -11,6 +12,15 @@ const (
 	PaymentApproved PaymentStatus = "payment_approved"
 )
 
+// PaymentStatusOf tells whether the order was paid, for callers already
+// holding it.
+func PaymentStatusOf(order entity.Order) PaymentStatus {
+	if order.PaymentId != "" {
+		return PaymentApproved
+	}
+	return PaymentPending
+}
+
 type OrderPaymentStatus struct {
 	PaymentStatus PaymentStatus
 }
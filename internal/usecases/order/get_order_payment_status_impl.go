@@ -2,6 +2,8 @@ package order
 
 import (
 	"context"
+	"errors"
+	entity "post-tech-challenge-10soat/internal/entities"
 	interfaces "post-tech-challenge-10soat/internal/interfaces/gateways"
 )
 
@@ -18,13 +20,12 @@ func NewGetOrderPaymentStatusUseCaseImpl(orderGateway interfaces.OrderGateway) G
 func (u GetOrderPaymentStatusUseCaseImpl) Execute(ctx context.Context, id string) (OrderPaymentStatus, error) {
 	order, err := u.orderGateway.GetOrderById(ctx, id)
 	if err != nil {
+		if errors.Is(err, entity.ErrDataNotFound) {
+			return OrderPaymentStatus{}, entity.ErrOrderNotFound
+		}
 		return OrderPaymentStatus{}, err
 	}
-	var paymentStatus PaymentStatus = PaymentPending
-	if order.PaymentId != "" {
-		paymentStatus = PaymentApproved
-	}
 	return OrderPaymentStatus{
-		PaymentStatus: paymentStatus,
+		PaymentStatus: PaymentStatusOf(order),
 	}, nil
 }
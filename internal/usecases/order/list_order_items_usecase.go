@@ -0,0 +1,10 @@
+package order
+
+import (
+	"context"
+	entity "post-tech-challenge-10soat/internal/entities"
+)
+
+type ListOrderItemsUseCase interface {
+	Execute(ctx context.Context, orderIds []string) ([]entity.OrderProduct, error)
+}
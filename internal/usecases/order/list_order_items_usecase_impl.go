@@ -0,0 +1,25 @@
+package order
+
+import (
+	"context"
+	entity "post-tech-challenge-10soat/internal/entities"
+	interfaces "post-tech-challenge-10soat/internal/interfaces/gateways"
+)
+
+type ListOrderItemsUseCaseImpl struct {
+	orderProductGateway interfaces.OrderProductGateway
+}
+
+func NewListOrderItemsUseCaseImpl(orderProductGateway interfaces.OrderProductGateway) ListOrderItemsUseCase {
+	return &ListOrderItemsUseCaseImpl{
+		orderProductGateway,
+	}
+}
+
+func (l ListOrderItemsUseCaseImpl) Execute(ctx context.Context, orderIds []string) ([]entity.OrderProduct, error) {
+	items, err := l.orderProductGateway.ListOrderProductsByOrderIds(ctx, orderIds)
+	if err != nil {
+		return []entity.OrderProduct{}, err
+	}
+	return items, nil
+}
@@ -3,75 +3,149 @@ package order
 import (
 	"context"
 	"errors"
-	"testing"
-
 	dto "post-tech-challenge-10soat/internal/dto/order"
 	entity "post-tech-challenge-10soat/internal/entities"
 	interfaces "post-tech-challenge-10soat/internal/interfaces/gateways"
+	"post-tech-challenge-10soat/internal/utils"
+	"testing"
+	"time"
+
 	"github.com/stretchr/testify/assert"
 )
 
 type mockOrderGateway struct {
 	interfaces.OrderGateway
-	CreateOrderFunc func(ctx context.Context, order entity.Order) (entity.Order, error)
-	ListOrdersFunc func(ctx context.Context, limit uint64) ([]entity.Order, error)
-	UpdateOrderStatusFunc func(ctx context.Context, id string, status string) (entity.Order, error)
-	GetOrderPaymentStatusFunc func(ctx context.Context, id string) (OrderPaymentStatus, error)
+	CreateOrderFunc       func(ctx context.Context, order entity.Order, products []entity.OrderProduct) (entity.Order, error)
+	ListOrdersFunc        func(ctx context.Context, limit uint64) ([]entity.Order, error)
+	GetOrderByIdFunc      func(ctx context.Context, id string) (entity.Order, error)
+	UpdateOrderStatusFunc func(ctx context.Context, id string, status string, version int) (entity.Order, error)
 }
 
-func (m *mockOrderGateway) CreateOrder(ctx context.Context, order entity.Order) (entity.Order, error) {
-	return m.CreateOrderFunc(ctx, order)
+func (m *mockOrderGateway) CreateOrder(ctx context.Context, order entity.Order, products []entity.OrderProduct) (entity.Order, error) {
+	return m.CreateOrderFunc(ctx, order, products)
 }
 func (m *mockOrderGateway) ListOrders(ctx context.Context, limit uint64) ([]entity.Order, error) {
 	return m.ListOrdersFunc(ctx, limit)
 }
-func (m *mockOrderGateway) UpdateOrderStatus(ctx context.Context, id string, status string) (entity.Order, error) {
-	return m.UpdateOrderStatusFunc(ctx, id, status)
+func (m *mockOrderGateway) GetOrderById(ctx context.Context, id string) (entity.Order, error) {
+	return m.GetOrderByIdFunc(ctx, id)
+}
+func (m *mockOrderGateway) UpdateOrderStatus(ctx context.Context, id string, status string, version int) (entity.Order, error) {
+	return m.UpdateOrderStatusFunc(ctx, id, status, version)
+}
+
+type mockProductGateway struct {
+	interfaces.ProductGateway
+	products map[string]entity.Product
+}
+
+func (m *mockProductGateway) GetProductById(ctx context.Context, id string) (entity.Product, error) {
+	product, ok := m.products[id]
+	if !ok {
+		return entity.Product{}, entity.ErrDataNotFound
+	}
+	return product, nil
+}
+
+type mockOrderEventGateway struct {
+	interfaces.OrderEventGateway
+	published []entity.Order
+}
+
+func (m *mockOrderEventGateway) PublishOrderStatus(ctx context.Context, order entity.Order) {
+	m.published = append(m.published, order)
 }
-func (m *mockOrderGateway) GetOrderPaymentStatus(ctx context.Context, id string) (OrderPaymentStatus, error) {
-	return m.GetOrderPaymentStatusFunc(ctx, id)
+
+type mockNotifyOrderStatus struct {
+	notified chan entity.Order
+}
+
+func (m *mockNotifyOrderStatus) Execute(ctx context.Context, order entity.Order) error {
+	m.notified <- order
+	return nil
 }
 
+type mockMetricsGateway struct {
+	interfaces.MetricsGateway
+}
+
+func (m *mockMetricsGateway) OrderCreated() {}
+
+func (m *mockMetricsGateway) OrderStatusChanged(from entity.OrderStatus, to entity.OrderStatus) {}
+
 func TestCreateOrderUsecaseImpl_Execute_Success(t *testing.T) {
+	var created []entity.OrderProduct
 	mockGateway := &mockOrderGateway{
-		CreateOrderFunc: func(ctx context.Context, order entity.Order) (entity.Order, error) {
-			order.ID = "1"
+		CreateOrderFunc: func(ctx context.Context, order entity.Order, products []entity.OrderProduct) (entity.Order, error) {
+			order.Id = "1"
+			created = products
 			return order, nil
 		},
 	}
-	usecase := NewCreateOrderUsecaseImpl(mockGateway)
-	input := dto.CreateOrderDTO{ClientID: "c1"}
+	productGateway := &mockProductGateway{products: map[string]entity.Product{"p1": {Id: "p1", Value: 10}}}
+	usecase := NewCreateOrderUsecaseImpl(productGateway, nil, mockGateway, &mockMetricsGateway{})
+	input := dto.CreateOrderDTO{Products: []dto.CreateOrderProduct{{ProductId: "p1", Quantity: 2, Observation: "Sem gelo"}}}
 	order, err := usecase.Execute(context.Background(), input)
 	assert.NoError(t, err)
-	assert.Equal(t, "1", order.ID)
-	assert.Equal(t, "c1", order.ClientID)
+	assert.Equal(t, "1", order.Id)
+	assert.Equal(t, entity.OrderStatusPaymentPending, order.Status)
+	assert.Equal(t, 20.0, order.Total)
+	assert.Equal(t, []entity.OrderProduct{{ProductId: "p1", Quantity: 2, SubTotal: 20, Observation: "Sem gelo"}}, created)
 }
 
 func TestCreateOrderUsecaseImpl_Execute_Error(t *testing.T) {
 	expectedErr := errors.New("fail")
 	mockGateway := &mockOrderGateway{
-		CreateOrderFunc: func(ctx context.Context, order entity.Order) (entity.Order, error) {
+		CreateOrderFunc: func(ctx context.Context, order entity.Order, products []entity.OrderProduct) (entity.Order, error) {
 			return entity.Order{}, expectedErr
 		},
 	}
-	usecase := NewCreateOrderUsecaseImpl(mockGateway)
-	input := dto.CreateOrderDTO{ClientID: "c1"}
+	productGateway := &mockProductGateway{products: map[string]entity.Product{"p1": {Id: "p1", Value: 10}}}
+	usecase := NewCreateOrderUsecaseImpl(productGateway, nil, mockGateway, &mockMetricsGateway{})
+	input := dto.CreateOrderDTO{Products: []dto.CreateOrderProduct{{ProductId: "p1", Quantity: 1}}}
+	order, err := usecase.Execute(context.Background(), input)
+	assert.ErrorIs(t, err, expectedErr)
+	assert.Contains(t, err.Error(), "cannot create order")
+	assert.Equal(t, entity.Order{}, order)
+}
+
+func TestCreateOrderUsecaseImpl_Execute_ProductNotFound(t *testing.T) {
+	usecase := NewCreateOrderUsecaseImpl(&mockProductGateway{}, nil, &mockOrderGateway{}, &mockMetricsGateway{})
+	input := dto.CreateOrderDTO{Products: []dto.CreateOrderProduct{{ProductId: "p1", Quantity: 1}}}
+	order, err := usecase.Execute(context.Background(), input)
+	assert.ErrorIs(t, err, entity.ErrProductNotFound)
+	assert.Equal(t, entity.Order{}, order)
+}
+
+func TestCreateOrderUsecaseImpl_Execute_ProductDeletedMeanwhile(t *testing.T) {
+	mockGateway := &mockOrderGateway{
+		CreateOrderFunc: func(ctx context.Context, order entity.Order, products []entity.OrderProduct) (entity.Order, error) {
+			return entity.Order{}, entity.ErrDataNotFound
+		},
+	}
+	productGateway := &mockProductGateway{products: map[string]entity.Product{"p1": {Id: "p1", Value: 10}}}
+	usecase := NewCreateOrderUsecaseImpl(productGateway, nil, mockGateway, &mockMetricsGateway{})
+	input := dto.CreateOrderDTO{Products: []dto.CreateOrderProduct{{ProductId: "p1", Quantity: 1}}}
 	order, err := usecase.Execute(context.Background(), input)
-	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "failed to create order")
+	assert.ErrorIs(t, err, entity.ErrProductNotFound)
 	assert.Equal(t, entity.Order{}, order)
 }
 
 func TestListOrdersUseCaseImpl_Execute_Success(t *testing.T) {
+	now := time.Now()
 	mockGateway := &mockOrderGateway{
 		ListOrdersFunc: func(ctx context.Context, limit uint64) ([]entity.Order, error) {
-			return []entity.Order{{ID: "1"}, {ID: "2"}}, nil
+			return []entity.Order{
+				{Id: "1", Status: entity.OrderStatusReceived, CreatedAt: now},
+				{Id: "2", Status: entity.OrderStatusReady, CreatedAt: now.Add(time.Minute)},
+				{Id: "3", Status: entity.OrderStatusReceived, CreatedAt: now.Add(-time.Minute)},
+			}, nil
 		},
 	}
 	usecase := NewListOrdersUseCaseImpl(mockGateway)
-	orders, err := usecase.Execute(context.Background(), 2)
+	orders, err := usecase.Execute(context.Background(), 3)
 	assert.NoError(t, err)
-	assert.Len(t, orders, 2)
+	assert.Equal(t, []string{"2", "3", "1"}, []string{orders[0].Id, orders[1].Id, orders[2].Id})
 }
 
 func TestListOrdersUseCaseImpl_Execute_Error(t *testing.T) {
@@ -83,58 +157,79 @@ func TestListOrdersUseCaseImpl_Execute_Error(t *testing.T) {
 	}
 	usecase := NewListOrdersUseCaseImpl(mockGateway)
 	orders, err := usecase.Execute(context.Background(), 2)
-	assert.Error(t, err)
-	assert.Nil(t, orders)
+	assert.ErrorIs(t, err, expectedErr)
+	assert.Empty(t, orders)
 }
 
 func TestUpdateOrderStatusUseCaseImpl_Execute_Success(t *testing.T) {
 	mockGateway := &mockOrderGateway{
-		UpdateOrderStatusFunc: func(ctx context.Context, id string, status string) (entity.Order, error) {
-			return entity.Order{ID: id, Status: status}, nil
+		GetOrderByIdFunc: func(ctx context.Context, id string) (entity.Order, error) {
+			return entity.Order{Id: id, Status: entity.OrderStatusReceived, Version: 1}, nil
+		},
+		UpdateOrderStatusFunc: func(ctx context.Context, id string, status string, version int) (entity.Order, error) {
+			return entity.Order{Id: id, Status: entity.OrderStatus(status), Version: version + 1}, nil
 		},
 	}
-	usecase := NewUpdateOrderStatusUseCaseImpl(mockGateway)
-	order, err := usecase.Execute(context.Background(), "1", "PAID")
+	eventGateway := &mockOrderEventGateway{}
+	notifier := &mockNotifyOrderStatus{notified: make(chan entity.Order, 1)}
+	usecase := NewUpdateOrderStatusUseCaseImpl(mockGateway, eventGateway, notifier, &mockMetricsGateway{}, time.Second, &utils.Background{})
+	order, err := usecase.Execute(context.Background(), "1", "preparing", 1)
 	assert.NoError(t, err)
-	assert.Equal(t, "1", order.ID)
-	assert.Equal(t, "PAID", order.Status)
+	assert.Equal(t, entity.OrderStatusPreparing, order.Status)
+	assert.Equal(t, 2, order.Version)
+	assert.Equal(t, []entity.Order{order}, eventGateway.published)
+	select {
+	case notified := <-notifier.notified:
+		assert.Equal(t, order, notified)
+	case <-time.After(time.Second):
+		t.Fatal("customer was not notified")
+	}
 }
 
-func TestUpdateOrderStatusUseCaseImpl_Execute_Error(t *testing.T) {
-	expectedErr := errors.New("fail")
+func TestUpdateOrderStatusUseCaseImpl_Execute_VersionMismatch(t *testing.T) {
 	mockGateway := &mockOrderGateway{
-		UpdateOrderStatusFunc: func(ctx context.Context, id string, status string) (entity.Order, error) {
-			return entity.Order{}, expectedErr
+		GetOrderByIdFunc: func(ctx context.Context, id string) (entity.Order, error) {
+			return entity.Order{Id: id, Status: entity.OrderStatusReceived, Version: 2}, nil
 		},
 	}
-	usecase := NewUpdateOrderStatusUseCaseImpl(mockGateway)
-	order, err := usecase.Execute(context.Background(), "1", "PAID")
-	assert.Error(t, err)
+	usecase := NewUpdateOrderStatusUseCaseImpl(mockGateway, &mockOrderEventGateway{}, &mockNotifyOrderStatus{}, &mockMetricsGateway{}, time.Second, &utils.Background{})
+	order, err := usecase.Execute(context.Background(), "1", "preparing", 1)
+	assert.ErrorIs(t, err, entity.ErrVersionMismatch)
+	assert.Equal(t, entity.Order{}, order)
+}
+
+func TestUpdateOrderStatusUseCaseImpl_Execute_InvalidTransition(t *testing.T) {
+	mockGateway := &mockOrderGateway{
+		GetOrderByIdFunc: func(ctx context.Context, id string) (entity.Order, error) {
+			return entity.Order{Id: id, Status: entity.OrderStatusReceived, Version: 1}, nil
+		},
+	}
+	usecase := NewUpdateOrderStatusUseCaseImpl(mockGateway, &mockOrderEventGateway{}, &mockNotifyOrderStatus{}, &mockMetricsGateway{}, time.Second, &utils.Background{})
+	order, err := usecase.Execute(context.Background(), "1", "completed", 1)
+	assert.ErrorIs(t, err, entity.ErrConflictingData)
 	assert.Equal(t, entity.Order{}, order)
 }
 
 func TestGetOrderPaymentStatusUseCaseImpl_Execute_Success(t *testing.T) {
 	mockGateway := &mockOrderGateway{
-		GetOrderPaymentStatusFunc: func(ctx context.Context, id string) (OrderPaymentStatus, error) {
-			return OrderPaymentStatus{OrderID: id, Status: "PAID"}, nil
+		GetOrderByIdFunc: func(ctx context.Context, id string) (entity.Order, error) {
+			return entity.Order{Id: id, PaymentId: "p1"}, nil
 		},
 	}
 	usecase := NewGetOrderPaymentStatusUseCaseImpl(mockGateway)
 	status, err := usecase.Execute(context.Background(), "1")
 	assert.NoError(t, err)
-	assert.Equal(t, "1", status.OrderID)
-	assert.Equal(t, "PAID", status.Status)
+	assert.Equal(t, PaymentApproved, status.PaymentStatus)
 }
 
-func TestGetOrderPaymentStatusUseCaseImpl_Execute_Error(t *testing.T) {
-	expectedErr := errors.New("fail")
+func TestGetOrderPaymentStatusUseCaseImpl_Execute_NotFound(t *testing.T) {
 	mockGateway := &mockOrderGateway{
-		GetOrderPaymentStatusFunc: func(ctx context.Context, id string) (OrderPaymentStatus, error) {
-			return OrderPaymentStatus{}, expectedErr
+		GetOrderByIdFunc: func(ctx context.Context, id string) (entity.Order, error) {
+			return entity.Order{}, entity.ErrDataNotFound
 		},
 	}
 	usecase := NewGetOrderPaymentStatusUseCaseImpl(mockGateway)
 	status, err := usecase.Execute(context.Background(), "1")
-	assert.Error(t, err)
+	assert.ErrorIs(t, err, entity.ErrOrderNotFound)
 	assert.Equal(t, OrderPaymentStatus{}, status)
 }
@@ -6,5 +6,5 @@ import (
 )
 
 type UpdateOrderStatusUseCase interface {
-	Execute(ctx context.Context, id string, status string) (entity.Order, error)
+	Execute(ctx context.Context, id string, status string, version int) (entity.Order, error)
 }
@@ -3,43 +3,82 @@ package order
 import (
 	"context"
 	"errors"
-	"fmt"
+	"log/slog"
 	entity "post-tech-challenge-10soat/internal/entities"
 	interfaces "post-tech-challenge-10soat/internal/interfaces/gateways"
+	"post-tech-challenge-10soat/internal/usecases/notification"
 	"post-tech-challenge-10soat/internal/utils"
+	"time"
 )
 
 type UpdateOrderStatusUseCaseImpl struct {
-	orderGateway interfaces.OrderGateway
+	orderGateway      interfaces.OrderGateway
+	orderEventGateway interfaces.OrderEventGateway
+	notifyOrderStatus notification.NotifyOrderStatusUseCase
+	metricsGateway    interfaces.MetricsGateway
+	notifyTimeout     time.Duration
+	background        *utils.Background
 }
 
-func NewUpdateOrderStatusUseCaseImpl(orderGateway interfaces.OrderGateway) UpdateOrderStatusUseCase {
+func NewUpdateOrderStatusUseCaseImpl(
+	orderGateway interfaces.OrderGateway,
+	orderEventGateway interfaces.OrderEventGateway,
+	notifyOrderStatus notification.NotifyOrderStatusUseCase,
+	metricsGateway interfaces.MetricsGateway,
+	notifyTimeout time.Duration,
+	background *utils.Background,
+) UpdateOrderStatusUseCase {
 	return &UpdateOrderStatusUseCaseImpl{
 		orderGateway,
+		orderEventGateway,
+		notifyOrderStatus,
+		metricsGateway,
+		notifyTimeout,
+		background,
 	}
 }
 
-func (u UpdateOrderStatusUseCaseImpl) Execute(ctx context.Context, id string, status string) (entity.Order, error) {
+// Execute changes the status of the order when it still has the version the
+// caller based the change on, answering entity.ErrVersionMismatch otherwise.
+func (u UpdateOrderStatusUseCaseImpl) Execute(ctx context.Context, id string, status string, version int) (entity.Order, error) {
 	order, err := u.orderGateway.GetOrderById(ctx, id)
 	if err != nil {
+		if errors.Is(err, entity.ErrDataNotFound) {
+			return entity.Order{}, entity.ErrOrderNotFound
+		}
 		return entity.Order{}, err
 	}
+	if order.Version != version {
+		return entity.Order{}, entity.ErrVersionMismatch
+	}
 	validTransitions := map[string][]string{
 		"received":  {"preparing"},
 		"preparing": {"ready"},
 		"ready":     {"completed"},
 		"completed": {},
 	}
-	allowed, exists := validTransitions[string(order.Status)]
-	if !exists {
-		return entity.Order{}, errors.New("invalid status")
-	}
+	allowed := validTransitions[string(order.Status)]
 	if !utils.Contains(allowed, status) {
-		return entity.Order{}, fmt.Errorf("cannot update order status for '%s' to '%s'", order.Status, status)
+		return entity.Order{}, entity.NewDomainError(entity.ErrConflictingData, entity.ErrCodeOrderInvalidTransition, "cannot update order status for '%s' to '%s'", order.Status, status)
 	}
-	updatedOrder, err := u.orderGateway.UpdateOrderStatus(ctx, id, status)
+	updatedOrder, err := u.orderGateway.UpdateOrderStatus(ctx, id, status, version)
 	if err != nil {
+		// The order existed a moment ago, so it was changed in between.
+		if errors.Is(err, entity.ErrDataNotFound) {
+			return entity.Order{}, entity.ErrVersionMismatch
+		}
 		return entity.Order{}, err
 	}
+	u.metricsGateway.OrderStatusChanged(order.Status, updatedOrder.Status)
+	u.orderEventGateway.PublishOrderStatus(ctx, updatedOrder)
+	// Customers are notified in background so a slow channel never holds the kitchen screen.
+	// The request is gone by then, so the notification keeps its values but gets its own deadline.
+	notifyCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), u.notifyTimeout)
+	u.background.Go(func() {
+		defer cancel()
+		if err := u.notifyOrderStatus.Execute(notifyCtx, updatedOrder); err != nil {
+			slog.ErrorContext(notifyCtx, "Error notifying order status", "order_id", updatedOrder.Id, "status", updatedOrder.Status, "error", err)
+		}
+	})
 	return updatedOrder, nil
 }
@@ -0,0 +1,10 @@
+package order
+
+import (
+	"context"
+	entity "post-tech-challenge-10soat/internal/entities"
+)
+
+type WatchOrderStatusUseCase interface {
+	Execute(ctx context.Context, orderId string) (<-chan entity.Order, error)
+}
@@ -0,0 +1,65 @@
+package order
+
+import (
+	"context"
+	"errors"
+	entity "post-tech-challenge-10soat/internal/entities"
+	interfaces "post-tech-challenge-10soat/internal/interfaces/gateways"
+)
+
+type WatchOrderStatusUseCaseImpl struct {
+	orderGateway      interfaces.OrderGateway
+	orderEventGateway interfaces.OrderEventGateway
+}
+
+func NewWatchOrderStatusUseCaseImpl(
+	orderGateway interfaces.OrderGateway,
+	orderEventGateway interfaces.OrderEventGateway,
+) WatchOrderStatusUseCase {
+	return &WatchOrderStatusUseCaseImpl{
+		orderGateway,
+		orderEventGateway,
+	}
+}
+
+// Execute streams status changes until the context is done. With an order id
+// the current state comes first, so a change made while subscribing is
+// never missed, and only newer versions of that order follow.
+func (w WatchOrderStatusUseCaseImpl) Execute(ctx context.Context, orderId string) (<-chan entity.Order, error) {
+	events := w.orderEventGateway.SubscribeOrderStatus(ctx)
+	var current []entity.Order
+	var currentVersion int
+	if orderId != "" {
+		order, err := w.orderGateway.GetOrderById(ctx, orderId)
+		if err != nil {
+			if errors.Is(err, entity.ErrDataNotFound) {
+				return nil, entity.ErrOrderNotFound
+			}
+			return nil, err
+		}
+		current = append(current, order)
+		currentVersion = order.Version
+	}
+	orders := make(chan entity.Order)
+	go func() {
+		defer close(orders)
+		for _, order := range current {
+			select {
+			case orders <- order:
+			case <-ctx.Done():
+				return
+			}
+		}
+		for order := range events {
+			if orderId != "" && (order.Id != orderId || order.Version <= currentVersion) {
+				continue
+			}
+			select {
+			case orders <- order:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return orders, nil
+}
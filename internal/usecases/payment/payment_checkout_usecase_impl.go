@@ -2,6 +2,7 @@ package payment
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	dto "post-tech-challenge-10soat/internal/dto/payment"
 	entity "post-tech-challenge-10soat/internal/entities"
@@ -9,12 +10,14 @@ import (
 )
 
 type PaymentCheckoutUseCaseImpl struct {
-	gateway interfaces.PaymentGateway
+	gateway        interfaces.PaymentGateway
+	metricsGateway interfaces.MetricsGateway
 }
 
-func NewPaymentCheckoutUsecaseImpl(gateway interfaces.PaymentGateway) PaymentCheckoutUseCase {
+func NewPaymentCheckoutUsecaseImpl(gateway interfaces.PaymentGateway, metricsGateway interfaces.MetricsGateway) PaymentCheckoutUseCase {
 	return &PaymentCheckoutUseCaseImpl{
 		gateway,
+		metricsGateway,
 	}
 }
 
@@ -25,11 +28,12 @@ func (s PaymentCheckoutUseCaseImpl) Execute(ctx context.Context, createPayment d
 		Provider: createPayment.Provider,
 	}
 	payment, err := s.gateway.CreatePayment(ctx, paymentInfo)
+	s.metricsGateway.PaymentFinished(err == nil)
 	if err != nil {
-		if err == entity.ErrConflictingData {
+		if errors.Is(err, entity.ErrConflictingData) {
 			return entity.Payment{}, err
 		}
-		return entity.Payment{}, fmt.Errorf("failed to make payment - %s", err.Error())
+		return entity.Payment{}, fmt.Errorf("failed to make payment - %w", err)
 	}
 	return payment, nil
 }
@@ -3,11 +3,11 @@ package payment
 import (
 	"context"
 	"errors"
-	"testing"
-
 	dto "post-tech-challenge-10soat/internal/dto/payment"
 	entity "post-tech-challenge-10soat/internal/entities"
 	interfaces "post-tech-challenge-10soat/internal/interfaces/gateways"
+	"testing"
+
 	"github.com/stretchr/testify/assert"
 )
 
@@ -20,20 +20,31 @@ func (m *mockPaymentGateway) CreatePayment(ctx context.Context, payment entity.P
 	return m.CreatePaymentFunc(ctx, payment)
 }
 
+type mockMetricsGateway struct {
+	interfaces.MetricsGateway
+	approved []bool
+}
+
+func (m *mockMetricsGateway) PaymentFinished(approved bool) {
+	m.approved = append(m.approved, approved)
+}
+
 func TestPaymentCheckoutUseCaseImpl_Execute_Success(t *testing.T) {
 	mockGateway := &mockPaymentGateway{
 		CreatePaymentFunc: func(ctx context.Context, payment entity.Payment) (entity.Payment, error) {
-			payment.ID = "p1"
+			payment.Id = "p1"
 			return payment, nil
 		},
 	}
-	usecase := NewPaymentCheckoutUsecaseImpl(mockGateway)
-	input := dto.CreatePaymentDTO{OrderID: "o1", Amount: 100.0}
+	metricsGateway := &mockMetricsGateway{}
+	usecase := NewPaymentCheckoutUsecaseImpl(mockGateway, metricsGateway)
+	input := dto.CreatePaymentDTO{Provider: entity.PaymentProviderMp, Type: entity.PaymentTypePixQRCode}
 	result, err := usecase.Execute(context.Background(), input)
 	assert.NoError(t, err)
-	assert.Equal(t, "p1", result.ID)
-	assert.Equal(t, "o1", result.OrderID)
-	assert.Equal(t, 100.0, result.Amount)
+	assert.Equal(t, "p1", result.Id)
+	assert.Equal(t, entity.PaymentProviderMp, result.Provider)
+	assert.Equal(t, entity.PaymentTypePixQRCode, result.Type)
+	assert.Equal(t, []bool{true}, metricsGateway.approved)
 }
 
 func TestPaymentCheckoutUseCaseImpl_Execute_Error(t *testing.T) {
@@ -43,10 +54,12 @@ func TestPaymentCheckoutUseCaseImpl_Execute_Error(t *testing.T) {
 			return entity.Payment{}, expectedErr
 		},
 	}
-	usecase := NewPaymentCheckoutUsecaseImpl(mockGateway)
-	input := dto.CreatePaymentDTO{OrderID: "o1", Amount: 100.0}
+	metricsGateway := &mockMetricsGateway{}
+	usecase := NewPaymentCheckoutUsecaseImpl(mockGateway, metricsGateway)
+	input := dto.CreatePaymentDTO{Provider: entity.PaymentProviderMp, Type: entity.PaymentTypePixQRCode}
 	result, err := usecase.Execute(context.Background(), input)
-	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "failed to checkout payment")
+	assert.ErrorIs(t, err, expectedErr)
+	assert.Contains(t, err.Error(), "failed to make payment")
 	assert.Equal(t, entity.Payment{}, result)
+	assert.Equal(t, []bool{false}, metricsGateway.approved)
 }
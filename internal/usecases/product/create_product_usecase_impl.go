@@ -2,6 +2,7 @@ package product
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	dto "post-tech-challenge-10soat/internal/dto/product"
 	entity "post-tech-challenge-10soat/internal/entities"
@@ -23,10 +24,10 @@ func NewCreateProductUsecaseImpl(productGateway interfaces.ProductGateway, categ
 func (s CreateProductUseCaseImpl) Execute(ctx context.Context, createProductDTO dto.CreateProductDTO) (entity.Product, error) {
 	category, err := s.categoryGateway.GetCategoryById(ctx, createProductDTO.CategoryId)
 	if err != nil {
-		if err == entity.ErrDataNotFound {
-			return entity.Product{}, err
+		if errors.Is(err, entity.ErrDataNotFound) {
+			return entity.Product{}, entity.ErrCategoryNotFound
 		}
-		return entity.Product{}, fmt.Errorf("cannot create product for this category - %s", err.Error())
+		return entity.Product{}, fmt.Errorf("cannot create product for this category - %w", err)
 	}
 	newProduct := entity.Product{
 		Name:        createProductDTO.Name,
@@ -38,7 +39,7 @@ func (s CreateProductUseCaseImpl) Execute(ctx context.Context, createProductDTO
 	}
 	product, err := s.productGateway.CreateProduct(ctx, newProduct)
 	if err != nil {
-		if err == entity.ErrConflictingData {
+		if errors.Is(err, entity.ErrConflictingData) {
 			return entity.Product{}, err
 		}
 		return entity.Product{}, entity.ErrInternal
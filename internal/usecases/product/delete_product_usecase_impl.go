@@ -2,6 +2,7 @@ package product
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	entity "post-tech-challenge-10soat/internal/entities"
 	interfaces "post-tech-challenge-10soat/internal/interfaces/gateways"
@@ -22,14 +23,14 @@ func NewDeleteProductUsecaseImpl(gateway interfaces.ProductGateway) DeleteProduc
 func (s DeleteProductUsecaseImpl) Execute(ctx context.Context, id string) error {
 	_, err := uuid.Parse(id)
 	if err != nil {
-		return fmt.Errorf("invalid product id")
+		return fmt.Errorf("%w: invalid product id", entity.ErrInvalidData)
 	}
 	_, err = s.gateway.GetProductById(ctx, id)
 	if err != nil {
-		if err == entity.ErrDataNotFound {
-			return err
+		if errors.Is(err, entity.ErrDataNotFound) {
+			return entity.ErrProductNotFound
 		}
-		return fmt.Errorf("cannot delete product for this identifier - %s", err.Error())
+		return fmt.Errorf("cannot delete product for this identifier - %w", err)
 	}
 	return s.gateway.DeleteProduct(ctx, id)
 }
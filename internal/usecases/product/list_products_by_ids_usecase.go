@@ -0,0 +1,10 @@
+package product
+
+import (
+	"context"
+	entity "post-tech-challenge-10soat/internal/entities"
+)
+
+type ListProductsByIdsUseCase interface {
+	Execute(ctx context.Context, ids []string) ([]entity.Product, error)
+}
@@ -0,0 +1,53 @@
+package product
+
+import (
+	"context"
+	entity "post-tech-challenge-10soat/internal/entities"
+	interfaces "post-tech-challenge-10soat/internal/interfaces/gateways"
+)
+
+type ListProductsByIdsUseCaseImpl struct {
+	productGateway  interfaces.ProductGateway
+	categoryGateway interfaces.CategoryGateway
+}
+
+func NewListProductsByIdsUseCaseImpl(productGateway interfaces.ProductGateway, categoryGateway interfaces.CategoryGateway) ListProductsByIdsUseCase {
+	return &ListProductsByIdsUseCaseImpl{
+		productGateway,
+		categoryGateway,
+	}
+}
+
+// Execute loads the products and their categories with one query each, for
+// callers that batch lookups. Unknown ids are left out of the result.
+func (s ListProductsByIdsUseCaseImpl) Execute(ctx context.Context, ids []string) ([]entity.Product, error) {
+	products, err := s.productGateway.ListProductsByIds(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	return withCategories(ctx, s.categoryGateway, products)
+}
+
+// withCategories fills the category of every product with a single query.
+func withCategories(ctx context.Context, categoryGateway interfaces.CategoryGateway, products []entity.Product) ([]entity.Product, error) {
+	categoryIds := make([]string, 0, len(products))
+	for _, product := range products {
+		categoryIds = append(categoryIds, product.CategoryId)
+	}
+	categories, err := categoryGateway.ListCategoriesByIds(ctx, categoryIds)
+	if err != nil {
+		return nil, err
+	}
+	categoriesById := make(map[string]entity.Category, len(categories))
+	for _, category := range categories {
+		categoriesById[category.Id] = category
+	}
+	for i, product := range products {
+		category, ok := categoriesById[product.CategoryId]
+		if !ok {
+			return nil, entity.ErrCategoryNotFound
+		}
+		products[i].Category = category
+	}
+	return products, nil
+}
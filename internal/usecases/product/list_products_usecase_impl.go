@@ -18,22 +18,11 @@ func NewListProductsUsecaseImpl(productGateway interfaces.ProductGateway, catego
 	}
 }
 
+// Execute loads the products and then their categories with a single query.
 func (s ListProductsUseCaseImpl) Execute(ctx context.Context, categoryId string) ([]entity.Product, error) {
-	var products []entity.Product
 	products, err := s.productGateway.ListProducts(ctx, categoryId)
 	if err != nil {
 		return nil, err
 	}
-	for i, product := range products {
-		category, err := s.categoryGateway.GetCategoryById(ctx, product.CategoryId)
-		if err != nil {
-			if err == entity.ErrDataNotFound {
-				return nil, err
-			}
-			return nil, err
-		}
-
-		products[i].Category = category
-	}
-	return products, nil
+	return withCategories(ctx, s.categoryGateway, products)
 }
@@ -3,24 +3,49 @@ package product
 import (
 	"context"
 	"errors"
-	"testing"
-
 	dto "post-tech-challenge-10soat/internal/dto/product"
 	entity "post-tech-challenge-10soat/internal/entities"
 	interfaces "post-tech-challenge-10soat/internal/interfaces/gateways"
+	"testing"
+
 	"github.com/stretchr/testify/assert"
 )
 
+const (
+	productId  = "6f1c1a3e-0d7b-4b8e-9c1a-3a8f2b7d5e10"
+	categoryId = "0b0f6c52-2f8e-4d6b-8a51-1c1f4f3b9a21"
+)
+
 type mockProductGateway struct {
 	interfaces.ProductGateway
-	CreateProductFunc func(ctx context.Context, product entity.Product) (entity.Product, error)
-	DeleteProductFunc func(ctx context.Context, id string) error
-	ListProductsFunc func(ctx context.Context, categoryId string) ([]entity.Product, error)
-	UpdateProductFunc func(ctx context.Context, product entity.Product) (entity.Product, error)
+	CreateProductFunc  func(ctx context.Context, product entity.Product) (entity.Product, error)
+	DeleteProductFunc  func(ctx context.Context, id string) error
+	GetProductByIdFunc func(ctx context.Context, id string) (entity.Product, error)
+	ListProductsFunc   func(ctx context.Context, categoryId string) ([]entity.Product, error)
+	UpdateProductFunc  func(ctx context.Context, product entity.Product) (entity.Product, error)
 }
 
 type mockCategoryGateway struct {
 	interfaces.CategoryGateway
+	listed [][]string
+}
+
+func (m *mockCategoryGateway) GetCategoryById(ctx context.Context, id string) (entity.Category, error) {
+	if id != categoryId {
+		return entity.Category{}, entity.ErrDataNotFound
+	}
+	return entity.Category{Id: id, Name: "Bebidas"}, nil
+}
+
+func (m *mockCategoryGateway) ListCategoriesByIds(ctx context.Context, ids []string) ([]entity.Category, error) {
+	m.listed = append(m.listed, ids)
+	var categories []entity.Category
+	for _, id := range ids {
+		if id == categoryId {
+			categories = append(categories, entity.Category{Id: id, Name: "Bebidas"})
+		}
+	}
+	return categories, nil
 }
 
 func (m *mockProductGateway) CreateProduct(ctx context.Context, product entity.Product) (entity.Product, error) {
@@ -29,6 +54,9 @@ func (m *mockProductGateway) CreateProduct(ctx context.Context, product entity.P
 func (m *mockProductGateway) DeleteProduct(ctx context.Context, id string) error {
 	return m.DeleteProductFunc(ctx, id)
 }
+func (m *mockProductGateway) GetProductById(ctx context.Context, id string) (entity.Product, error) {
+	return m.GetProductByIdFunc(ctx, id)
+}
 func (m *mockProductGateway) ListProducts(ctx context.Context, categoryId string) ([]entity.Product, error) {
 	return m.ListProductsFunc(ctx, categoryId)
 }
@@ -36,75 +64,108 @@ func (m *mockProductGateway) UpdateProduct(ctx context.Context, product entity.P
 	return m.UpdateProductFunc(ctx, product)
 }
 
+func existingProduct(ctx context.Context, id string) (entity.Product, error) {
+	return entity.Product{Id: id, Name: "Coca", Value: 10.0, CategoryId: categoryId, Version: 1}, nil
+}
+
 func TestCreateProductUsecaseImpl_Execute_Success(t *testing.T) {
 	mockProduct := &mockProductGateway{
 		CreateProductFunc: func(ctx context.Context, product entity.Product) (entity.Product, error) {
-			product.ID = "p1"
+			product.Id = productId
 			return product, nil
 		},
 	}
 	mockCategory := &mockCategoryGateway{}
 	usecase := NewCreateProductUsecaseImpl(mockProduct, mockCategory)
-	input := dto.CreateProductDTO{Name: "Coca", Price: 10.0, CategoryID: "c1"}
+	input := dto.CreateProductDTO{Name: "Coca", Value: 10.0, CategoryId: categoryId}
 	result, err := usecase.Execute(context.Background(), input)
 	assert.NoError(t, err)
-	assert.Equal(t, "p1", result.ID)
+	assert.Equal(t, productId, result.Id)
 	assert.Equal(t, "Coca", result.Name)
-	assert.Equal(t, 10.0, result.Price)
-	assert.Equal(t, "c1", result.CategoryID)
+	assert.Equal(t, 10.0, result.Value)
+	assert.Equal(t, categoryId, result.CategoryId)
+	assert.Equal(t, "Bebidas", result.Category.Name)
 }
 
 func TestCreateProductUsecaseImpl_Execute_Error(t *testing.T) {
-	expectedErr := errors.New("fail")
 	mockProduct := &mockProductGateway{
 		CreateProductFunc: func(ctx context.Context, product entity.Product) (entity.Product, error) {
-			return entity.Product{}, expectedErr
+			return entity.Product{}, errors.New("fail")
 		},
 	}
 	mockCategory := &mockCategoryGateway{}
 	usecase := NewCreateProductUsecaseImpl(mockProduct, mockCategory)
-	input := dto.CreateProductDTO{Name: "Coca", Price: 10.0, CategoryID: "c1"}
+	input := dto.CreateProductDTO{Name: "Coca", Value: 10.0, CategoryId: categoryId}
+	result, err := usecase.Execute(context.Background(), input)
+	assert.ErrorIs(t, err, entity.ErrInternal)
+	assert.Equal(t, entity.Product{}, result)
+}
+
+func TestCreateProductUsecaseImpl_Execute_CategoryNotFound(t *testing.T) {
+	usecase := NewCreateProductUsecaseImpl(&mockProductGateway{}, &mockCategoryGateway{})
+	input := dto.CreateProductDTO{Name: "Coca", Value: 10.0, CategoryId: productId}
 	result, err := usecase.Execute(context.Background(), input)
-	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "failed to create product")
+	assert.ErrorIs(t, err, entity.ErrCategoryNotFound)
 	assert.Equal(t, entity.Product{}, result)
 }
 
 func TestDeleteProductUsecaseImpl_Execute_Success(t *testing.T) {
 	mockProduct := &mockProductGateway{
+		GetProductByIdFunc: existingProduct,
 		DeleteProductFunc: func(ctx context.Context, id string) error {
 			return nil
 		},
 	}
 	usecase := NewDeleteProductUsecaseImpl(mockProduct)
-	err := usecase.Execute(context.Background(), "p1")
+	err := usecase.Execute(context.Background(), productId)
 	assert.NoError(t, err)
 }
 
 func TestDeleteProductUsecaseImpl_Execute_Error(t *testing.T) {
 	expectedErr := errors.New("fail")
 	mockProduct := &mockProductGateway{
+		GetProductByIdFunc: existingProduct,
 		DeleteProductFunc: func(ctx context.Context, id string) error {
 			return expectedErr
 		},
 	}
 	usecase := NewDeleteProductUsecaseImpl(mockProduct)
-	err := usecase.Execute(context.Background(), "p1")
-	assert.Error(t, err)
+	err := usecase.Execute(context.Background(), productId)
 	assert.Equal(t, expectedErr, err)
 }
 
+func TestDeleteProductUsecaseImpl_Execute_InvalidId(t *testing.T) {
+	usecase := NewDeleteProductUsecaseImpl(&mockProductGateway{})
+	err := usecase.Execute(context.Background(), "p1")
+	assert.ErrorIs(t, err, entity.ErrInvalidData)
+}
+
 func TestListProductsUsecaseImpl_Execute_Success(t *testing.T) {
 	mockProduct := &mockProductGateway{
 		ListProductsFunc: func(ctx context.Context, categoryId string) ([]entity.Product, error) {
-			return []entity.Product{{ID: "p1"}, {ID: "p2"}}, nil
+			return []entity.Product{{Id: "p1", CategoryId: categoryId}, {Id: "p2", CategoryId: categoryId}}, nil
 		},
 	}
 	mockCategory := &mockCategoryGateway{}
 	usecase := NewListProductsUsecaseImpl(mockProduct, mockCategory)
-	products, err := usecase.Execute(context.Background(), "c1")
+	products, err := usecase.Execute(context.Background(), categoryId)
 	assert.NoError(t, err)
 	assert.Len(t, products, 2)
+	assert.Equal(t, "Bebidas", products[0].Category.Name)
+	assert.Equal(t, "Bebidas", products[1].Category.Name)
+	assert.Equal(t, [][]string{{categoryId, categoryId}}, mockCategory.listed)
+}
+
+func TestListProductsUsecaseImpl_Execute_CategoryNotFound(t *testing.T) {
+	mockProduct := &mockProductGateway{
+		ListProductsFunc: func(ctx context.Context, categoryId string) ([]entity.Product, error) {
+			return []entity.Product{{Id: "p1", CategoryId: productId}}, nil
+		},
+	}
+	usecase := NewListProductsUsecaseImpl(mockProduct, &mockCategoryGateway{})
+	products, err := usecase.Execute(context.Background(), "")
+	assert.ErrorIs(t, err, entity.ErrCategoryNotFound)
+	assert.Nil(t, products)
 }
 
 func TestListProductsUsecaseImpl_Execute_Error(t *testing.T) {
@@ -116,37 +177,51 @@ func TestListProductsUsecaseImpl_Execute_Error(t *testing.T) {
 	}
 	mockCategory := &mockCategoryGateway{}
 	usecase := NewListProductsUsecaseImpl(mockProduct, mockCategory)
-	products, err := usecase.Execute(context.Background(), "c1")
-	assert.Error(t, err)
+	products, err := usecase.Execute(context.Background(), categoryId)
+	assert.ErrorIs(t, err, expectedErr)
 	assert.Nil(t, products)
 }
 
 func TestUpdateProductUsecaseImpl_Execute_Success(t *testing.T) {
 	mockProduct := &mockProductGateway{
+		GetProductByIdFunc: existingProduct,
 		UpdateProductFunc: func(ctx context.Context, product entity.Product) (entity.Product, error) {
-			product.Name = "Fanta"
+			product.Version++
 			return product, nil
 		},
 	}
 	mockCategory := &mockCategoryGateway{}
 	usecase := NewUpdateProductUsecaseImpl(mockProduct, mockCategory)
-	input := dto.UpdateProductDTO{ID: "p1", Name: "Fanta"}
+	input := dto.UpdateProductDTO{Id: productId, Name: "Fanta", Value: 10.0, Version: 1}
 	result, err := usecase.Execute(context.Background(), input)
 	assert.NoError(t, err)
 	assert.Equal(t, "Fanta", result.Name)
+	assert.Equal(t, categoryId, result.CategoryId)
+	assert.Equal(t, 2, result.Version)
 }
 
 func TestUpdateProductUsecaseImpl_Execute_Error(t *testing.T) {
-	expectedErr := errors.New("fail")
 	mockProduct := &mockProductGateway{
+		GetProductByIdFunc: existingProduct,
 		UpdateProductFunc: func(ctx context.Context, product entity.Product) (entity.Product, error) {
-			return entity.Product{}, expectedErr
+			return entity.Product{}, errors.New("fail")
 		},
 	}
 	mockCategory := &mockCategoryGateway{}
 	usecase := NewUpdateProductUsecaseImpl(mockProduct, mockCategory)
-	input := dto.UpdateProductDTO{ID: "p1", Name: "Fanta"}
+	input := dto.UpdateProductDTO{Id: productId, Name: "Fanta", Value: 10.0, Version: 1}
+	result, err := usecase.Execute(context.Background(), input)
+	assert.ErrorIs(t, err, entity.ErrInternal)
+	assert.Equal(t, entity.Product{}, result)
+}
+
+func TestUpdateProductUsecaseImpl_Execute_VersionMismatch(t *testing.T) {
+	mockProduct := &mockProductGateway{
+		GetProductByIdFunc: existingProduct,
+	}
+	usecase := NewUpdateProductUsecaseImpl(mockProduct, &mockCategoryGateway{})
+	input := dto.UpdateProductDTO{Id: productId, Name: "Fanta", Value: 10.0, Version: 2}
 	result, err := usecase.Execute(context.Background(), input)
-	assert.Error(t, err)
+	assert.ErrorIs(t, err, entity.ErrVersionMismatch)
 	assert.Equal(t, entity.Product{}, result)
 }
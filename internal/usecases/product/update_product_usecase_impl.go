@@ -2,6 +2,7 @@ package product
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	dto "post-tech-challenge-10soat/internal/dto/product"
 	entity "post-tech-challenge-10soat/internal/entities"
@@ -25,10 +26,13 @@ func NewUpdateProductUsecaseImpl(productGateway interfaces.ProductGateway, categ
 func (s UpdateProductUsecaseImpl) Execute(ctx context.Context, updateProductDTO dto.UpdateProductDTO) (entity.Product, error) {
 	existingProduct, err := s.productGateway.GetProductById(ctx, updateProductDTO.Id)
 	if err != nil {
-		if err == entity.ErrDataNotFound {
-			return entity.Product{}, err
+		if errors.Is(err, entity.ErrDataNotFound) {
+			return entity.Product{}, entity.ErrProductNotFound
 		}
-		return entity.Product{}, fmt.Errorf("cannot find product to update - %s", err.Error())
+		return entity.Product{}, fmt.Errorf("cannot find product to update - %w", err)
+	}
+	if existingProduct.Version != updateProductDTO.Version {
+		return entity.Product{}, entity.ErrVersionMismatch
 	}
 	emptyData := uuid.Validate(updateProductDTO.CategoryId) != nil &&
 		updateProductDTO.Name == "" &&
@@ -45,25 +49,32 @@ func (s UpdateProductUsecaseImpl) Execute(ctx context.Context, updateProductDTO
 	}
 	category, err := s.categoryGateway.GetCategoryById(ctx, updateProductDTO.CategoryId)
 	if err != nil {
-		if err == entity.ErrDataNotFound {
-			return entity.Product{}, err
+		if errors.Is(err, entity.ErrDataNotFound) {
+			return entity.Product{}, entity.ErrCategoryNotFound
 		}
-		return entity.Product{}, fmt.Errorf("cannot update product for this category - %s", err.Error())
+		return entity.Product{}, fmt.Errorf("cannot update product for this category - %w", err)
 	}
 	newUpdateProduct := entity.Product{
+		Id:          updateProductDTO.Id,
 		Name:        updateProductDTO.Name,
 		Description: updateProductDTO.Description,
 		Image:       updateProductDTO.Image,
 		Value:       updateProductDTO.Value,
 		CategoryId:  updateProductDTO.CategoryId,
 		Category:    category,
+		Version:     updateProductDTO.Version,
 	}
-	_, err = s.productGateway.UpdateProduct(ctx, newUpdateProduct)
+	updatedProduct, err := s.productGateway.UpdateProduct(ctx, newUpdateProduct)
 	if err != nil {
-		if err == entity.ErrConflictingData {
+		if errors.Is(err, entity.ErrConflictingData) {
 			return entity.Product{}, err
 		}
+		// The product existed a moment ago, so it was changed in between.
+		if errors.Is(err, entity.ErrDataNotFound) {
+			return entity.Product{}, entity.ErrVersionMismatch
+		}
 		return entity.Product{}, entity.ErrInternal
 	}
+	newUpdateProduct.Version = updatedProduct.Version
 	return newUpdateProduct, nil
 }
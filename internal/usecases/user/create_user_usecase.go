@@ -0,0 +1,11 @@
+package user
+
+import (
+	"context"
+	dto "post-tech-challenge-10soat/internal/dto/user"
+	entity "post-tech-challenge-10soat/internal/entities"
+)
+
+type CreateUserUseCase interface {
+	Execute(ctx context.Context, createUser dto.CreateUserDTO) (entity.User, error)
+}
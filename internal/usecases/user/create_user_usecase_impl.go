@@ -0,0 +1,41 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	dto "post-tech-challenge-10soat/internal/dto/user"
+	entity "post-tech-challenge-10soat/internal/entities"
+	interfaces "post-tech-challenge-10soat/internal/interfaces/gateways"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+type CreateUserUseCaseImpl struct {
+	userGateway interfaces.UserGateway
+}
+
+func NewCreateUserUseCaseImpl(userGateway interfaces.UserGateway) CreateUserUseCase {
+	return &CreateUserUseCaseImpl{
+		userGateway,
+	}
+}
+
+func (s CreateUserUseCaseImpl) Execute(ctx context.Context, createUser dto.CreateUserDTO) (entity.User, error) {
+	role := entity.Role(createUser.Role)
+	if !role.IsStaff() {
+		return entity.User{}, fmt.Errorf("%w: '%s' is not a staff role", entity.ErrForbidden, createUser.Role)
+	}
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(createUser.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return entity.User{}, fmt.Errorf("failed to hash password - %w", err)
+	}
+	user, err := s.userGateway.CreateUser(ctx, entity.User{
+		Username:     createUser.Username,
+		PasswordHash: string(passwordHash),
+		Role:         role,
+	})
+	if err != nil {
+		return entity.User{}, fmt.Errorf("failed to create user - %w", err)
+	}
+	return user, nil
+}
@@ -0,0 +1,10 @@
+package user
+
+import (
+	"context"
+	entity "post-tech-challenge-10soat/internal/entities"
+)
+
+type IssueStaffTokenUseCase interface {
+	Execute(ctx context.Context, username string, password string) (entity.Token, error)
+}
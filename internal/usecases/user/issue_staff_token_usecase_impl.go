@@ -0,0 +1,62 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	entity "post-tech-challenge-10soat/internal/entities"
+	interfaces "post-tech-challenge-10soat/internal/interfaces/gateways"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// unknownUserHash is compared against when the username does not exist so the
+// response time does not reveal which usernames are registered.
+var unknownUserHash, _ = bcrypt.GenerateFromPassword([]byte("unknown-user"), bcrypt.DefaultCost)
+
+var errInvalidCredentials = entity.NewDomainError(entity.ErrUnauthorized, entity.ErrCodeInvalidCredentials, "invalid credentials")
+
+type IssueStaffTokenUseCaseImpl struct {
+	userGateway  interfaces.UserGateway
+	tokenGateway interfaces.TokenGateway
+	sessionTTL   time.Duration
+}
+
+func NewIssueStaffTokenUseCaseImpl(
+	userGateway interfaces.UserGateway,
+	tokenGateway interfaces.TokenGateway,
+	sessionTTL time.Duration,
+) IssueStaffTokenUseCase {
+	return &IssueStaffTokenUseCaseImpl{
+		userGateway,
+		tokenGateway,
+		sessionTTL,
+	}
+}
+
+func (s IssueStaffTokenUseCaseImpl) Execute(ctx context.Context, username string, password string) (entity.Token, error) {
+	user, err := s.userGateway.GetUserByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, entity.ErrDataNotFound) {
+			_ = bcrypt.CompareHashAndPassword(unknownUserHash, []byte(password))
+			return entity.Token{}, errInvalidCredentials
+		}
+		return entity.Token{}, fmt.Errorf("failed to get user - %w", err)
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+		return entity.Token{}, errInvalidCredentials
+	}
+	if !user.Active || !user.Role.IsStaff() {
+		return entity.Token{}, fmt.Errorf("%w: user is disabled", entity.ErrForbidden)
+	}
+	token, err := s.tokenGateway.IssueToken(ctx, entity.Session{
+		Subject:   user.Id,
+		Role:      user.Role,
+		ExpiresAt: time.Now().UTC().Add(s.sessionTTL),
+	})
+	if err != nil {
+		return entity.Token{}, fmt.Errorf("failed to issue token - %w", err)
+	}
+	return token, nil
+}
@@ -0,0 +1,38 @@
+package utils
+
+import (
+	"context"
+	"sync"
+)
+
+// Background runs work that outlives the request which started it, such as
+// customer notifications, so the shutdown can wait for it before closing the
+// databases it uses. The zero value is ready to use.
+type Background struct {
+	wg sync.WaitGroup
+}
+
+// Go runs fn in a new goroutine tracked by Wait.
+func (b *Background) Go(fn func()) {
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+		fn()
+	}()
+}
+
+// Wait blocks until every function started by Go has returned, or the
+// context is done, answering the context error in that case.
+func (b *Background) Wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		b.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
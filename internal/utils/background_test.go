@@ -0,0 +1,41 @@
+package utils
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackground_Wait(t *testing.T) {
+	var background Background
+	var finished atomic.Int32
+	for range 3 {
+		background.Go(func() {
+			time.Sleep(10 * time.Millisecond)
+			finished.Add(1)
+		})
+	}
+
+	err := background.Wait(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), finished.Load())
+}
+
+func TestBackground_Wait_GivesUpWithTheContext(t *testing.T) {
+	var background Background
+	release := make(chan struct{})
+	defer close(release)
+	background.Go(func() {
+		<-release
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err := background.Wait(ctx)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -1,6 +1,10 @@
 package utils
 
-import "database/sql"
+import (
+	"database/sql"
+	"regexp"
+	"time"
+)
 
 func NullString(value string) sql.NullString {
 	if value == "" {
@@ -21,3 +25,27 @@ func Contains(slice []string, value string) bool {
 	}
 	return false
 }
+
+func NullTime(value time.Time) sql.NullTime {
+	if value.IsZero() {
+		return sql.NullTime{}
+	}
+
+	return sql.NullTime{
+		Time:  value,
+		Valid: true,
+	}
+}
+
+var (
+	urlPassword     = regexp.MustCompile(`(://[^:@/?#]*):[^@/?#]*@`)
+	keywordPassword = regexp.MustCompile(`(?i)\b(password|tlsCertificateKeyFilePassword)=[^\s&]*`)
+)
+
+// MaskCredentials hides the passwords of a connection string, either in the
+// user info of a URL or in a password parameter, keeping the user and the
+// rest of it readable for logs.
+func MaskCredentials(connection string) string {
+	masked := urlPassword.ReplaceAllString(connection, "$1:****@")
+	return keywordPassword.ReplaceAllString(masked, "$1=****")
+}
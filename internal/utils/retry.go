@@ -0,0 +1,52 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"time"
+)
+
+// Backoff is how an operation is retried: up to Retries times after the
+// first attempt, waiting Initial and then twice as long each time, up to
+// Max.
+type Backoff struct {
+	Retries int
+	Initial time.Duration
+	Max     time.Duration
+}
+
+// Delay is the wait before the retry number attempt, counted from 1. Half of
+// it is random, so replicas started together do not retry in lockstep.
+func (b Backoff) Delay(attempt int) time.Duration {
+	delay := b.Initial
+	for i := 1; i < attempt && delay < b.Max; i++ {
+		delay *= 2
+	}
+	delay = min(delay, b.Max)
+	return delay/2 + rand.N(delay/2+1)
+}
+
+// Retry calls fn until it succeeds, the retries run out or the context is
+// done, logging each failure. The last error is returned.
+func Retry(ctx context.Context, backoff Backoff, operation string, fn func(ctx context.Context) error) error {
+	for attempt := 1; ; attempt++ {
+		err := fn(ctx)
+		if err == nil {
+			return nil
+		}
+		if attempt > backoff.Retries {
+			return fmt.Errorf("%s failed after %d attempts - %w", operation, attempt, err)
+		}
+		delay := backoff.Delay(attempt)
+		slog.WarnContext(ctx, "Failed "+operation+", retrying", "attempt", attempt, "retry_in", delay, "error", err)
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("%s gave up after %d attempts - %w", operation, attempt, err)
+		case <-timer.C:
+		}
+	}
+}
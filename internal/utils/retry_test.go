@@ -0,0 +1,88 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackoff_Delay(t *testing.T) {
+	backoff := Backoff{Retries: 10, Initial: 100 * time.Millisecond, Max: time.Second}
+	tests := []struct {
+		attempt int
+		max     time.Duration
+	}{
+		{1, 100 * time.Millisecond},
+		{2, 200 * time.Millisecond},
+		{3, 400 * time.Millisecond},
+		{4, 800 * time.Millisecond},
+		{5, time.Second},
+		{60, time.Second},
+	}
+	for _, tt := range tests {
+		for range 20 {
+			delay := backoff.Delay(tt.attempt)
+			assert.GreaterOrEqual(t, delay, tt.max/2)
+			assert.LessOrEqual(t, delay, tt.max)
+		}
+	}
+}
+
+func TestRetry(t *testing.T) {
+	backoff := Backoff{Retries: 3, Initial: time.Millisecond, Max: 2 * time.Millisecond}
+
+	t.Run("succeeds after failing", func(t *testing.T) {
+		// Setup
+		attempts := 0
+
+		// Act
+		err := Retry(context.Background(), backoff, "connecting", func(ctx context.Context) error {
+			attempts++
+			if attempts < 3 {
+				return errors.New("connection refused")
+			}
+			return nil
+		})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 3, attempts)
+	})
+
+	t.Run("returns the last error once the retries run out", func(t *testing.T) {
+		// Setup
+		attempts := 0
+		refused := errors.New("connection refused")
+
+		// Act
+		err := Retry(context.Background(), backoff, "connecting", func(ctx context.Context) error {
+			attempts++
+			return refused
+		})
+
+		// Assert
+		assert.ErrorIs(t, err, refused)
+		assert.EqualError(t, err, "connecting failed after 4 attempts - connection refused")
+		assert.Equal(t, 4, attempts)
+	})
+
+	t.Run("stops when the context is done", func(t *testing.T) {
+		// Setup
+		ctx, cancel := context.WithCancel(context.Background())
+		attempts := 0
+
+		// Act
+		err := Retry(ctx, Backoff{Retries: 5, Initial: time.Hour, Max: time.Hour}, "connecting", func(ctx context.Context) error {
+			attempts++
+			cancel()
+			return errors.New("connection refused")
+		})
+
+		// Assert
+		assert.EqualError(t, err, "connecting gave up after 1 attempts - connection refused")
+		assert.Equal(t, 1, attempts)
+	})
+}